@@ -0,0 +1,303 @@
+// Package config はアプリケーションの設定を読み込む
+// 設定はプロファイルごとのデフォルト値、YAMLファイル（CONFIG_FILE）、環境変数の順に上書きして決定する
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"myblog/app/domain/model/reaction"
+	"myblog/app/infra/db/rdb"
+)
+
+// Profile : 実行環境（APP_ENV）
+type Profile string
+
+const (
+	// ProfileDev : 開発環境
+	ProfileDev Profile = "dev"
+	// ProfileTest : テスト環境
+	ProfileTest Profile = "test"
+	// ProfileProd : 本番環境
+	ProfileProd Profile = "prod"
+)
+
+// App : 設定を使用するアプリケーション（アプリケーションごとに必要な設定のみ検証する）
+type App string
+
+const (
+	// AppAPI : APIサーバー
+	AppAPI App = "api"
+	// AppBatch : バッチ
+	AppBatch App = "batch"
+)
+
+// devJWTSecret : 開発環境・テスト環境で使用するJWTの署名鍵（本番環境では使用できない）
+const devJWTSecret = "default_jwt_secret_for_development"
+
+// minJWTSecretLength : 本番環境のJWTの署名鍵の最小の長さ（バイト）
+const minJWTSecretLength = 32
+
+// Config : アプリケーションの設定
+type Config struct {
+	Profile  Profile        `yaml:"-"`
+	Server   ServerConfig   `yaml:"server"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Database DatabaseConfig `yaml:"database"`
+	Reaction ReactionConfig `yaml:"reaction"`
+	Batch    BatchConfig    `yaml:"batch"`
+}
+
+// ServerConfig : APIサーバーの設定
+type ServerConfig struct {
+	Port int `yaml:"port"`
+	// RequestTimeout : リクエストの処理のタイムアウト（ストリーミング配信には適用しない）
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// ShutdownTimeout : 停止時に処理中のリクエストの完了を待つ時間
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// StreamHeartbeat : ストリーミング配信で接続を維持するためのコメントを送る間隔
+	StreamHeartbeat time.Duration `yaml:"stream_heartbeat"`
+	// StreamTicketTTL : ストリーミング配信の接続用チケットの有効期間（期間内は再接続に何度でも使用できる）
+	StreamTicketTTL time.Duration `yaml:"stream_ticket_ttl"`
+	// CORSAllowedOrigins : クロスオリジンのリクエストを許可するオリジン（「*」は全て）
+	CORSAllowedOrigins []string `yaml:"cors_allowed_origins"`
+	// MetricsAddr : メトリクス（/debug/vars）を公開する内部向けのアドレス（空の場合は公開しない）
+	MetricsAddr string `yaml:"metrics_addr"`
+}
+
+// AuthConfig : 認証の設定
+type AuthConfig struct {
+	JWTSecret string `yaml:"jwt_secret"`
+}
+
+// DatabaseConfig : データベースの設定
+type DatabaseConfig struct {
+	// Driver : データベースの種類（mysql または sqlite）
+	Driver string `yaml:"driver"`
+	// Path : SQLiteのデータベースファイルのパス（「:memory:」はメモリ上のデータベース）
+	Path                 string        `yaml:"path"`
+	Host                 string        `yaml:"host"`
+	Port                 int           `yaml:"port"`
+	User                 string        `yaml:"user"`
+	Password             string        `yaml:"password"`
+	Name                 string        `yaml:"name"`
+	Timezone             string        `yaml:"timezone"`
+	MaxOpenConns         int           `yaml:"max_open_conns"`
+	MaxIdleConns         int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime      time.Duration `yaml:"conn_max_lifetime"`
+	ReplicaHosts         []string      `yaml:"replica_hosts"`
+	ReadPolicy           string        `yaml:"read_policy"`
+	HealthCheckInterval  time.Duration `yaml:"health_check_interval"`
+	ReadYourWritesWindow time.Duration `yaml:"read_your_writes_window"`
+}
+
+// ReactionConfig : リアクションの設定
+type ReactionConfig struct {
+	Emojis []string `yaml:"emojis"`
+}
+
+// BatchConfig : バッチの設定
+type BatchConfig struct {
+	// FailureWebhookURL : バッチの失敗を通知するWebhookのURL（空の場合は通知しない）
+	FailureWebhookURL string `yaml:"failure_webhook_url"`
+}
+
+// defaults : プロファイルごとのデフォルト値
+func defaults(profile Profile) Config {
+	config := Config{
+		Profile: profile,
+		Server: ServerConfig{
+			Port:               8080,
+			RequestTimeout:     60 * time.Second,
+			ShutdownTimeout:    30 * time.Second,
+			StreamHeartbeat:    30 * time.Second,
+			StreamTicketTTL:    10 * time.Minute,
+			CORSAllowedOrigins: []string{"*"},
+			MetricsAddr:        "127.0.0.1:9090",
+		},
+		Auth: AuthConfig{
+			JWTSecret: devJWTSecret,
+		},
+		Database: DatabaseConfig{
+			Driver:               string(rdb.DriverMySQL),
+			Host:                 "localhost",
+			Port:                 3306,
+			User:                 "root",
+			Password:             "password",
+			Name:                 "myblog",
+			Timezone:             "Asia/Tokyo",
+			MaxOpenConns:         25,
+			MaxIdleConns:         25,
+			ConnMaxLifetime:      5 * time.Minute,
+			ReadPolicy:           string(rdb.ReadPolicyRoundRobin),
+			HealthCheckInterval:  5 * time.Second,
+			ReadYourWritesWindow: 5 * time.Second,
+		},
+		Reaction: ReactionConfig{
+			Emojis: append([]string{}, reaction.DefaultEmojis...),
+		},
+	}
+
+	switch profile {
+	case ProfileTest:
+		config.Database.Name = "test"
+	case ProfileProd:
+		// 本番環境では認証情報と許可するオリジンを明示的に指定させる
+		config.Server.CORSAllowedOrigins = nil
+		config.Auth.JWTSecret = ""
+		config.Database.Password = ""
+	}
+
+	return config
+}
+
+// UsesDevJWTSecret : 開発用のJWTの署名鍵を使用しているかどうか
+func (c *Config) UsesDevJWTSecret() bool {
+	return c.Auth.JWTSecret == devJWTSecret
+}
+
+// RDB : データベース接続の設定
+func (c DatabaseConfig) RDB() rdb.Config {
+	return rdb.Config{
+		Driver:               rdb.Driver(c.Driver),
+		Path:                 c.Path,
+		User:                 c.User,
+		Password:             c.Password,
+		Host:                 c.Host,
+		Port:                 strconv.Itoa(c.Port),
+		Database:             c.Name,
+		Location:             c.Timezone,
+		MaxOpenConns:         c.MaxOpenConns,
+		MaxIdleConns:         c.MaxIdleConns,
+		ConnMaxLifetime:      c.ConnMaxLifetime,
+		ReplicaHosts:         c.ReplicaHosts,
+		ReadPolicy:           rdb.ReadPolicy(c.ReadPolicy),
+		HealthCheckInterval:  c.HealthCheckInterval,
+		ReadYourWritesWindow: c.ReadYourWritesWindow,
+	}
+}
+
+// Validate : 設定の検証（不正な設定を全てまとめたエラーを返す）
+func (c *Config) Validate(app App) error {
+	var errs []error
+	invalid := func(key string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	// データベース
+	db := c.Database
+	switch rdb.Driver(db.Driver) {
+	case rdb.DriverMySQL:
+		if db.Host == "" {
+			invalid("DB_HOST", "指定してください")
+		}
+		if db.Port <= 0 || db.Port > 65535 {
+			invalid("DB_PORT", "1から65535の範囲で指定してください（%d）", db.Port)
+		}
+		if db.User == "" {
+			invalid("DB_USER", "指定してください")
+		}
+		if db.Name == "" {
+			invalid("DB_NAME", "指定してください")
+		}
+		if c.Profile == ProfileProd && db.Password == "" {
+			invalid("DB_PASSWORD", "本番環境では指定してください（DB_PASSWORD_FILEでファイルから読み込むこともできます）")
+		}
+	case rdb.DriverSQLite:
+		if db.Path == "" {
+			invalid("DB_PATH", "SQLiteを使用する場合は指定してください")
+		}
+		if len(db.ReplicaHosts) > 0 {
+			invalid("DB_REPLICA_HOSTS", "SQLiteではレプリカを使用できません")
+		}
+	default:
+		invalid("DB_DRIVER", "%s または %s を指定してください（%q）", rdb.DriverMySQL, rdb.DriverSQLite, db.Driver)
+	}
+	if _, err := time.LoadLocation(db.Timezone); db.Timezone == "" || err != nil {
+		invalid("DB_TIMEZONE", "IANAのタイムゾーン名を指定してください（%q）", db.Timezone)
+	}
+	if db.MaxOpenConns <= 0 {
+		invalid("DB_MAX_OPEN_CONNS", "1以上を指定してください（%d）", db.MaxOpenConns)
+	}
+	if db.MaxIdleConns < 0 || db.MaxIdleConns > db.MaxOpenConns {
+		invalid("DB_MAX_IDLE_CONNS", "0以上DB_MAX_OPEN_CONNS以下を指定してください（%d）", db.MaxIdleConns)
+	}
+	if db.ConnMaxLifetime < 0 {
+		invalid("DB_CONN_MAX_LIFETIME", "0以上を指定してください（%s）", db.ConnMaxLifetime)
+	}
+	switch rdb.ReadPolicy(db.ReadPolicy) {
+	case rdb.ReadPolicyRoundRobin, rdb.ReadPolicyLeastConnections:
+	default:
+		invalid("DB_READ_POLICY", "%s または %s を指定してください（%q）", rdb.ReadPolicyRoundRobin, rdb.ReadPolicyLeastConnections, db.ReadPolicy)
+	}
+	for _, host := range db.ReplicaHosts {
+		if !strings.Contains(host, ":") {
+			invalid("DB_REPLICA_HOSTS", "host:portの形式で指定してください（%q）", host)
+		}
+	}
+	if len(db.ReplicaHosts) > 0 && db.HealthCheckInterval <= 0 {
+		invalid("DB_REPLICA_HEALTH_CHECK_INTERVAL", "0より大きい値を指定してください（%s）", db.HealthCheckInterval)
+	}
+	if db.ReadYourWritesWindow < 0 {
+		invalid("DB_READ_YOUR_WRITES_WINDOW", "0以上を指定してください（%s）", db.ReadYourWritesWindow)
+	}
+
+	if app == AppAPI {
+		// サーバー
+		server := c.Server
+		if server.Port <= 0 || server.Port > 65535 {
+			invalid("PORT", "1から65535の範囲で指定してください（%d）", server.Port)
+		}
+		if server.RequestTimeout <= 0 {
+			invalid("HTTP_REQUEST_TIMEOUT", "0より大きい値を指定してください（%s）", server.RequestTimeout)
+		}
+		if server.ShutdownTimeout <= 0 {
+			invalid("HTTP_SHUTDOWN_TIMEOUT", "0より大きい値を指定してください（%s）", server.ShutdownTimeout)
+		}
+		if server.StreamHeartbeat <= 0 {
+			invalid("STREAM_HEARTBEAT_INTERVAL", "0より大きい値を指定してください（%s）", server.StreamHeartbeat)
+		}
+		if server.StreamTicketTTL <= 0 {
+			invalid("STREAM_TICKET_TTL", "0より大きい値を指定してください（%s）", server.StreamTicketTTL)
+		}
+		if len(server.CORSAllowedOrigins) == 0 {
+			invalid("CORS_ALLOWED_ORIGINS", "指定してください")
+		}
+		for _, origin := range server.CORSAllowedOrigins {
+			if origin == "*" && c.Profile == ProfileProd {
+				invalid("CORS_ALLOWED_ORIGINS", "本番環境では「*」を指定できません。許可するオリジンを指定してください")
+			}
+		}
+		if server.MetricsAddr != "" {
+			if _, port, err := net.SplitHostPort(server.MetricsAddr); err != nil {
+				invalid("METRICS_ADDR", "host:portの形式で指定してください（%q）", server.MetricsAddr)
+			} else if port == strconv.Itoa(server.Port) {
+				invalid("METRICS_ADDR", "PORTと異なるポートを指定してください（%q）", server.MetricsAddr)
+			}
+		}
+
+		// 認証
+		switch {
+		case c.Auth.JWTSecret == "":
+			invalid("JWT_SECRET", "指定してください（JWT_SECRET_FILEでファイルから読み込むこともできます）")
+		case c.Profile == ProfileProd && c.UsesDevJWTSecret():
+			invalid("JWT_SECRET", "本番環境では開発用の署名鍵を使用できません")
+		case c.Profile == ProfileProd && len(c.Auth.JWTSecret) < minJWTSecretLength:
+			invalid("JWT_SECRET", "本番環境では%dバイト以上を指定してください", minJWTSecretLength)
+		}
+
+		// リアクション
+		if _, err := reaction.NewEmojiSet(c.Reaction.Emojis); err != nil {
+			invalid("REACTION_EMOJIS", "%v", err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("設定が不正です（%s）:\n%w", c.Profile, errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load : 環境変数とYAMLファイルから設定を読み込んで検証する
+// APP_ENVでプロファイル（dev, test, prod。省略時はdev）、CONFIG_FILEでYAMLファイルを指定する
+// 全ての環境変数は<名前>_FILEでファイルから読み込むこともできる（Docker/Kubernetesのシークレット用）
+func Load(app App) (*Config, error) {
+	env := &envReader{}
+
+	profile := ProfileDev
+	if v, ok := env.lookup("APP_ENV"); ok {
+		switch Profile(v) {
+		case ProfileDev, ProfileTest, ProfileProd:
+			profile = Profile(v)
+		default:
+			return nil, fmt.Errorf("APP_ENV: %s, %s, %s のいずれかを指定してください（%q）", ProfileDev, ProfileTest, ProfileProd, v)
+		}
+	}
+
+	config := defaults(profile)
+
+	// YAMLファイル（指定された項目のみデフォルト値を上書きする）
+	if path, ok := env.lookup("CONFIG_FILE"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("設定ファイルの読み込みエラー: %w", err)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("設定ファイルの解析エラー（%s）: %w", path, err)
+		}
+	}
+
+	// 環境変数（YAMLファイルより優先する）
+	env.int("PORT", &config.Server.Port)
+	env.duration("HTTP_REQUEST_TIMEOUT", &config.Server.RequestTimeout)
+	env.duration("HTTP_SHUTDOWN_TIMEOUT", &config.Server.ShutdownTimeout)
+	env.duration("STREAM_HEARTBEAT_INTERVAL", &config.Server.StreamHeartbeat)
+	env.duration("STREAM_TICKET_TTL", &config.Server.StreamTicketTTL)
+	env.list("CORS_ALLOWED_ORIGINS", &config.Server.CORSAllowedOrigins)
+	env.string("METRICS_ADDR", &config.Server.MetricsAddr)
+	env.string("JWT_SECRET", &config.Auth.JWTSecret)
+	env.string("DB_DRIVER", &config.Database.Driver)
+	env.string("DB_PATH", &config.Database.Path)
+	env.string("DB_HOST", &config.Database.Host)
+	env.int("DB_PORT", &config.Database.Port)
+	env.string("DB_USER", &config.Database.User)
+	env.string("DB_PASSWORD", &config.Database.Password)
+	env.string("DB_NAME", &config.Database.Name)
+	env.string("DB_TIMEZONE", &config.Database.Timezone)
+	env.int("DB_MAX_OPEN_CONNS", &config.Database.MaxOpenConns)
+	env.int("DB_MAX_IDLE_CONNS", &config.Database.MaxIdleConns)
+	env.duration("DB_CONN_MAX_LIFETIME", &config.Database.ConnMaxLifetime)
+	env.list("DB_REPLICA_HOSTS", &config.Database.ReplicaHosts)
+	env.string("DB_READ_POLICY", &config.Database.ReadPolicy)
+	env.duration("DB_REPLICA_HEALTH_CHECK_INTERVAL", &config.Database.HealthCheckInterval)
+	env.duration("DB_READ_YOUR_WRITES_WINDOW", &config.Database.ReadYourWritesWindow)
+	env.list("REACTION_EMOJIS", &config.Reaction.Emojis)
+	env.string("BATCH_FAILURE_WEBHOOK_URL", &config.Batch.FailureWebhookURL)
+	if len(env.errs) > 0 {
+		return nil, fmt.Errorf("環境変数が不正です:\n%w", errors.Join(env.errs...))
+	}
+
+	if err := config.Validate(app); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// envReader : 環境変数の読み込み（不正な値のエラーをまとめて返すため、エラーを蓄積する）
+type envReader struct {
+	errs []error
+}
+
+// lookup : 環境変数の取得（未設定または空の場合はfalse）
+// <key>_FILEが設定されている場合は<key>より優先し、そのファイルの内容（末尾の改行を除く）を値とする
+func (e *envReader) lookup(key string) (string, bool) {
+	value, ok := os.LookupEnv(key)
+	ok = ok && value != ""
+	path, fileOK := os.LookupEnv(key + "_FILE")
+	fileOK = fileOK && path != ""
+
+	if !fileOK {
+		return value, ok
+	}
+	// 両方が設定されている場合はファイルを優先する（シークレットをマウントした環境で古い値が残っていても上書きできるように）
+	data, err := os.ReadFile(path)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s_FILE: ファイルを読み込めません: %w", key, err))
+		return "", false
+	}
+	return strings.TrimRight(string(data), "\r\n"), true
+}
+
+// string : 文字列の環境変数
+func (e *envReader) string(key string, dst *string) {
+	if v, ok := e.lookup(key); ok {
+		*dst = v
+	}
+}
+
+// int : 整数の環境変数
+func (e *envReader) int(key string, dst *int) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: 整数を指定してください（%q）", key, v))
+		return
+	}
+	*dst = n
+}
+
+// duration : 期間の環境変数（例: 30s, 5m）
+func (e *envReader) duration(key string, dst *time.Duration) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(v))
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: 30s, 5mのような期間を指定してください（%q）", key, v))
+		return
+	}
+	*dst = d
+}
+
+// list : カンマ区切りの環境変数（各要素の前後の空白と空の要素は除く）
+func (e *envReader) list(key string, dst *[]string) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	var values []string
+	for _, value := range strings.Split(v, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	*dst = values
+}
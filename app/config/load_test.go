@@ -0,0 +1,249 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// envKeys : Loadが参照する環境変数（テストごとに未設定にする）
+var envKeys = []string{
+	"APP_ENV", "CONFIG_FILE",
+	"PORT", "HTTP_REQUEST_TIMEOUT", "HTTP_SHUTDOWN_TIMEOUT", "STREAM_HEARTBEAT_INTERVAL", "STREAM_TICKET_TTL",
+	"CORS_ALLOWED_ORIGINS", "METRICS_ADDR", "JWT_SECRET",
+	"DB_DRIVER", "DB_PATH", "DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME", "DB_TIMEZONE",
+	"DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME", "DB_REPLICA_HOSTS", "DB_READ_POLICY",
+	"DB_REPLICA_HEALTH_CHECK_INTERVAL", "DB_READ_YOUR_WRITES_WINDOW", "REACTION_EMOJIS", "BATCH_FAILURE_WEBHOOK_URL",
+}
+
+// setEnv : 環境変数の設定（指定していない環境変数は未設定として扱う）
+func setEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+
+	for _, key := range envKeys {
+		// 空の値は未設定として扱われる
+		t.Setenv(key, "")
+		t.Setenv(key+"_FILE", "")
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+}
+
+// writeFile : テスト用の一時ファイルの作成
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// prodEnv : 本番環境で必須の環境変数
+func prodEnv() map[string]string {
+	return map[string]string{
+		"APP_ENV":              "prod",
+		"JWT_SECRET":           strings.Repeat("s", minJWTSecretLength),
+		"DB_PASSWORD":          "secret",
+		"CORS_ALLOWED_ORIGINS": "https://example.com",
+	}
+}
+
+func TestLoad_Profile(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantProfile Profile
+		wantDBName  string
+		wantOrigins []string
+	}{
+		{
+			name:        "省略時は開発環境",
+			env:         map[string]string{},
+			wantProfile: ProfileDev,
+			wantDBName:  "myblog",
+			wantOrigins: []string{"*"},
+		},
+		{
+			name:        "テスト環境はテスト用のデータベース",
+			env:         map[string]string{"APP_ENV": "test"},
+			wantProfile: ProfileTest,
+			wantDBName:  "test",
+			wantOrigins: []string{"*"},
+		},
+		{
+			name:        "本番環境は許可するオリジンを指定する",
+			env:         prodEnv(),
+			wantProfile: ProfileProd,
+			wantDBName:  "myblog",
+			wantOrigins: []string{"https://example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+
+			config, err := Load(AppAPI)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.Profile != tt.wantProfile {
+				t.Errorf("プロファイルが異なります: got %s, want %s", config.Profile, tt.wantProfile)
+			}
+			if config.Database.Name != tt.wantDBName {
+				t.Errorf("データベース名が異なります: got %s, want %s", config.Database.Name, tt.wantDBName)
+			}
+			if strings.Join(config.Server.CORSAllowedOrigins, ",") != strings.Join(tt.wantOrigins, ",") {
+				t.Errorf("許可するオリジンが異なります: got %v, want %v", config.Server.CORSAllowedOrigins, tt.wantOrigins)
+			}
+		})
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	with := func(base map[string]string, overrides map[string]string) map[string]string {
+		env := make(map[string]string)
+		for k, v := range base {
+			env[k] = v
+		}
+		for k, v := range overrides {
+			env[k] = v
+		}
+		return env
+	}
+
+	tests := []struct {
+		name    string
+		app     App
+		env     map[string]string
+		wantErr []string
+	}{
+		{
+			name:    "不明なプロファイル",
+			app:     AppAPI,
+			env:     map[string]string{"APP_ENV": "staging"},
+			wantErr: []string{"APP_ENV: dev, test, prod のいずれかを指定してください"},
+		},
+		{
+			name: "本番環境で必須の値がない場合は全てまとめてエラー",
+			app:  AppAPI,
+			env:  map[string]string{"APP_ENV": "prod"},
+			wantErr: []string{
+				"DB_PASSWORD: 本番環境では指定してください",
+				"CORS_ALLOWED_ORIGINS: 指定してください",
+				"JWT_SECRET: 指定してください",
+			},
+		},
+		{
+			name:    "バッチはAPIサーバーの設定を検証しない",
+			app:     AppBatch,
+			env:     with(prodEnv(), map[string]string{"JWT_SECRET": "", "CORS_ALLOWED_ORIGINS": "", "DB_PASSWORD": ""}),
+			wantErr: []string{"DB_PASSWORD: 本番環境では指定してください"},
+		},
+		{
+			name:    "不正な形式の値",
+			app:     AppAPI,
+			env:     map[string]string{"PORT": "http", "STREAM_TICKET_TTL": "10"},
+			wantErr: []string{"PORT: 整数を指定してください", "STREAM_TICKET_TTL: 30s, 5mのような期間を指定してください"},
+		},
+		{
+			name:    "レプリカのヘルスチェック間隔が0",
+			app:     AppBatch,
+			env:     map[string]string{"DB_REPLICA_HOSTS": "replica:3306", "DB_REPLICA_HEALTH_CHECK_INTERVAL": "0s"},
+			wantErr: []string{"DB_REPLICA_HEALTH_CHECK_INTERVAL: 0より大きい値を指定してください"},
+		},
+		{
+			name:    "読み込めないファイル",
+			app:     AppAPI,
+			env:     map[string]string{"JWT_SECRET_FILE": "/nonexistent/jwt"},
+			wantErr: []string{"JWT_SECRET_FILE: ファイルを読み込めません"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+
+			_, err := Load(tt.app)
+			if err == nil {
+				t.Fatal("エラーが発生しませんでした")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("エラーに %q が含まれません: %v", want, err)
+				}
+			}
+			if tt.app == AppBatch && strings.Contains(err.Error(), "JWT_SECRET") {
+				t.Errorf("バッチでJWT_SECRETが検証されました: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoad_ConfigFile(t *testing.T) {
+	t.Run("環境変数はYAMLファイルより優先する", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "server:\n  port: 9000\n  stream_ticket_ttl: 1m\ndatabase:\n  name: from_yaml\n  replica_hosts: [replica-1:3306, replica-2:3306]\n")
+		setEnv(t, map[string]string{"CONFIG_FILE": path, "PORT": "9001"})
+
+		config, err := Load(AppAPI)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.Server.Port != 9001 {
+			t.Errorf("ポートが異なります: got %d, want 9001", config.Server.Port)
+		}
+		if config.Server.StreamTicketTTL != time.Minute || config.Database.Name != "from_yaml" || len(config.Database.ReplicaHosts) != 2 {
+			t.Errorf("YAMLファイルの値が反映されていません: %+v", config)
+		}
+		// YAMLファイルで指定していない項目はデフォルト値
+		if config.Database.HealthCheckInterval != 5*time.Second {
+			t.Errorf("デフォルト値が異なります: %s", config.Database.HealthCheckInterval)
+		}
+	})
+
+	t.Run("不明なキーはエラー", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "server:\n  port: 9000\n  request_timout: 30s\n")
+		setEnv(t, map[string]string{"CONFIG_FILE": path})
+
+		_, err := Load(AppAPI)
+		if err == nil || !strings.Contains(err.Error(), "request_timout") {
+			t.Fatalf("不明なキーのエラーが異なります: %v", err)
+		}
+	})
+}
+
+func TestLoad_FileEnv(t *testing.T) {
+	t.Run("ファイルの内容を末尾の改行を除いて値とする", func(t *testing.T) {
+		path := writeFile(t, "jwt", "secret-from-file\n")
+		setEnv(t, map[string]string{"JWT_SECRET_FILE": path})
+
+		config, err := Load(AppAPI)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.Auth.JWTSecret != "secret-from-file" {
+			t.Errorf("署名鍵が異なります: got %q", config.Auth.JWTSecret)
+		}
+	})
+
+	t.Run("<名前>_FILEは<名前>より優先する", func(t *testing.T) {
+		path := writeFile(t, "password", "from-file")
+		setEnv(t, map[string]string{"DB_PASSWORD": "from-env", "DB_PASSWORD_FILE": path, "DB_PORT_FILE": writeFile(t, "port", "3307\n")})
+
+		config, err := Load(AppBatch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.Database.Password != "from-file" {
+			t.Errorf("パスワードが異なります: got %q, want %q", config.Database.Password, "from-file")
+		}
+		if config.Database.Port != 3307 {
+			t.Errorf("ポートが異なります: got %d, want 3307", config.Database.Port)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/user"
+
+	"github.com/google/uuid"
+)
+
+// Type : イベント種別
+type Type string
+
+const (
+	// TypeBlogPublished : ブログが投稿された
+	TypeBlogPublished Type = "blog.published"
+	// TypeCommentAdded : コメントが投稿された
+	TypeCommentAdded Type = "comment.added"
+	// TypeUserRegistered : ユーザーが登録された
+	TypeUserRegistered Type = "user.registered"
+)
+
+// Event : ドメインイベント
+// 状態の変更と同じトランザクションでアウトボックスに記録し、リレーによって購読者に配信する
+type Event struct {
+	id             ID
+	eventType      Type
+	aggregateID    string // イベントの発生元のエンティティのID
+	payload        []byte // イベントの内容（JSON）
+	idempotencyKey string // 同じ出来事を表すイベントで共通のキー（重複した記録と配信の検出に使用する）
+	occurredAt     time.Time
+	publishedAt    *time.Time
+	attempts       int
+}
+
+// NewEvent : イベントの生成（payloadはJSONに変換して保持する）
+func NewEvent(eventType Type, aggregateID string, payload interface{}) (*Event, error) {
+	if eventType == "" {
+		return nil, errors.New("イベント種別が空です")
+	}
+	if aggregateID == "" {
+		return nil, errors.New("発生元のIDが空です")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("イベントの内容の変換に失敗しました: %w", err)
+	}
+
+	id, err := NewID(uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		id:             *id,
+		eventType:      eventType,
+		aggregateID:    aggregateID,
+		payload:        data,
+		idempotencyKey: string(eventType) + ":" + aggregateID,
+		occurredAt:     time.Now(),
+	}, nil
+}
+
+// Reconstruct : イベントの再構築（DBからの読み込み時など）
+func Reconstruct(id string, eventType Type, aggregateID string, payload []byte, idempotencyKey string, occurredAt time.Time, publishedAt *time.Time, attempts int) (*Event, error) {
+	eventID, err := NewID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		id:             *eventID,
+		eventType:      eventType,
+		aggregateID:    aggregateID,
+		payload:        payload,
+		idempotencyKey: idempotencyKey,
+		occurredAt:     occurredAt,
+		publishedAt:    publishedAt,
+		attempts:       attempts,
+	}, nil
+}
+
+// ID : IDの取得
+func (e Event) ID() ID {
+	return e.id
+}
+
+// Type : イベント種別の取得
+func (e Event) Type() Type {
+	return e.eventType
+}
+
+// AggregateID : 発生元のエンティティのIDの取得
+func (e Event) AggregateID() string {
+	return e.aggregateID
+}
+
+// Payload : イベントの内容（JSON）の取得
+func (e Event) Payload() []byte {
+	return e.payload
+}
+
+// Decode : イベントの内容をvに読み込む
+func (e Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.payload, v)
+}
+
+// IdempotencyKey : 冪等キーの取得
+func (e Event) IdempotencyKey() string {
+	return e.idempotencyKey
+}
+
+// OccurredAt : 発生日時の取得
+func (e Event) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// PublishedAt : 配信完了日時の取得（未配信の場合はnil）
+func (e Event) PublishedAt() *time.Time {
+	return e.publishedAt
+}
+
+// Attempts : 配信の試行回数の取得
+func (e Event) Attempts() int {
+	return e.attempts
+}
+
+// BlogPublished : ブログが投稿されたイベントの内容
+type BlogPublished struct {
+	BlogID string `json:"blog_id"`
+	UserID string `json:"user_id"`
+	Title  string `json:"title"`
+}
+
+// NewBlogPublished : ブログが投稿されたイベントの生成
+func NewBlogPublished(b *blog.Blog) (*Event, error) {
+	return NewEvent(TypeBlogPublished, b.ID().String(), BlogPublished{
+		BlogID: b.ID().String(),
+		UserID: b.UserID().String(),
+		Title:  b.Title(),
+	})
+}
+
+// CommentAdded : コメントが投稿されたイベントの内容
+type CommentAdded struct {
+	CommentID    string `json:"comment_id"`
+	BlogID       string `json:"blog_id"`
+	UserID       string `json:"user_id"`
+	BlogAuthorID string `json:"blog_author_id"`
+}
+
+// NewCommentAdded : コメントが投稿されたイベントの生成
+func NewCommentAdded(c *comment.Comment, blogAuthorID user.ID) (*Event, error) {
+	return NewEvent(TypeCommentAdded, c.ID().String(), CommentAdded{
+		CommentID:    c.ID().String(),
+		BlogID:       c.BlogID().String(),
+		UserID:       c.UserID().String(),
+		BlogAuthorID: blogAuthorID.String(),
+	})
+}
+
+// UserRegistered : ユーザーが登録されたイベントの内容
+type UserRegistered struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// NewUserRegistered : ユーザーが登録されたイベントの生成
+func NewUserRegistered(u *user.User) (*Event, error) {
+	return NewEvent(TypeUserRegistered, u.ID().String(), UserRegistered{
+		UserID:   u.ID().String(),
+		Username: u.Username(),
+	})
+}
@@ -0,0 +1,148 @@
+package jobrun
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status : ジョブ実行の状態
+type Status string
+
+const (
+	// StatusRunning : 実行中
+	StatusRunning Status = "running"
+	// StatusSucceeded : 成功
+	StatusSucceeded Status = "succeeded"
+	// StatusFailed : 失敗
+	StatusFailed Status = "failed"
+)
+
+// JobRun : バッチジョブの1回の実行を表すエンティティ
+type JobRun struct {
+	id            ID
+	jobName       string
+	params        string     // 実行時の引数とフラグ
+	scheduledAt   *time.Time // スケジューラーから実行された場合の予定実行日時
+	status        Status
+	rowsProcessed int64 // 処理した件数
+	attempts      int   // リトライを含む試行回数
+	errorMessage  string
+	startedAt     time.Time
+	finishedAt    *time.Time
+}
+
+// NewJobRun : 実行中のジョブ実行の生成（scheduledAtはスケジューラーから実行された場合のみ指定する）
+func NewJobRun(jobName string, params string, scheduledAt *time.Time) (*JobRun, error) {
+	if jobName == "" {
+		return nil, errors.New("ジョブ名が空です")
+	}
+
+	id, err := NewID(uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &JobRun{
+		id:          *id,
+		jobName:     jobName,
+		params:      params,
+		scheduledAt: scheduledAt,
+		status:      StatusRunning,
+		startedAt:   time.Now(),
+	}, nil
+}
+
+// Reconstruct : ジョブ実行の再構築（DBからの読み込み時など）
+func Reconstruct(id string, jobName string, params string, scheduledAt *time.Time, status Status, rowsProcessed int64, attempts int, errorMessage string, startedAt time.Time, finishedAt *time.Time) (*JobRun, error) {
+	jobRunID, err := NewID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JobRun{
+		id:            *jobRunID,
+		jobName:       jobName,
+		params:        params,
+		scheduledAt:   scheduledAt,
+		status:        status,
+		rowsProcessed: rowsProcessed,
+		attempts:      attempts,
+		errorMessage:  errorMessage,
+		startedAt:     startedAt,
+		finishedAt:    finishedAt,
+	}, nil
+}
+
+// Finish : ジョブ実行を終了する（errがnilの場合は成功、それ以外は失敗として記録する）
+func (j *JobRun) Finish(rowsProcessed int64, attempts int, err error) {
+	now := time.Now()
+	j.finishedAt = &now
+	j.rowsProcessed = rowsProcessed
+	j.attempts = attempts
+	if err != nil {
+		j.status = StatusFailed
+		j.errorMessage = err.Error()
+		return
+	}
+	j.status = StatusSucceeded
+}
+
+// ID : IDの取得
+func (j JobRun) ID() ID {
+	return j.id
+}
+
+// JobName : ジョブ名の取得
+func (j JobRun) JobName() string {
+	return j.jobName
+}
+
+// Params : 実行時の引数とフラグの取得
+func (j JobRun) Params() string {
+	return j.params
+}
+
+// ScheduledAt : 予定実行日時の取得（スケジューラー以外から実行された場合はnil）
+func (j JobRun) ScheduledAt() *time.Time {
+	return j.scheduledAt
+}
+
+// Status : 状態の取得
+func (j JobRun) Status() Status {
+	return j.status
+}
+
+// RowsProcessed : 処理した件数の取得
+func (j JobRun) RowsProcessed() int64 {
+	return j.rowsProcessed
+}
+
+// Attempts : リトライを含む試行回数の取得
+func (j JobRun) Attempts() int {
+	return j.attempts
+}
+
+// ErrorMessage : 失敗時のエラーメッセージの取得
+func (j JobRun) ErrorMessage() string {
+	return j.errorMessage
+}
+
+// StartedAt : 開始日時の取得
+func (j JobRun) StartedAt() time.Time {
+	return j.startedAt
+}
+
+// FinishedAt : 終了日時の取得（実行中の場合はnil）
+func (j JobRun) FinishedAt() *time.Time {
+	return j.finishedAt
+}
+
+// Duration : 実行時間の取得（実行中の場合は0）
+func (j JobRun) Duration() time.Duration {
+	if j.finishedAt == nil {
+		return 0
+	}
+	return j.finishedAt.Sub(j.startedAt)
+}
@@ -0,0 +1,45 @@
+package like
+
+import (
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/user"
+)
+
+// Like : いいねエンティティ（ユーザーはブログごとに1回のみいいねできる）
+type Like struct {
+	blogID    blog.ID
+	userID    user.ID
+	createdAt time.Time
+}
+
+// NewLike : いいねの生成
+func NewLike(blogID blog.ID, userID user.ID) *Like {
+	return &Like{
+		blogID:    blogID,
+		userID:    userID,
+		createdAt: time.Now(),
+	}
+}
+
+// BlogID : ブログIDの取得
+func (l Like) BlogID() blog.ID {
+	return l.blogID
+}
+
+// UserID : ユーザーIDの取得
+func (l Like) UserID() user.ID {
+	return l.userID
+}
+
+// CreatedAt : 作成日時の取得
+func (l Like) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+// Summary : ブログごとのいいね集計
+type Summary struct {
+	Count int  // いいね数
+	Liked bool // 閲覧ユーザー自身がいいね済みかどうか
+}
@@ -0,0 +1,139 @@
+package mention
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/user"
+
+	"github.com/google/uuid"
+)
+
+// Mention : メンションエンティティ
+type Mention struct {
+	id        ID
+	blogID    blog.ID
+	commentID *comment.ID // コメント内のメンションの場合のみ設定される
+	userID    user.ID     // メンションされたユーザー
+	username  string      // メンション時点のユーザー名
+	createdAt time.Time
+}
+
+// NewMention : メンションの生成
+func NewMention(blogID blog.ID, commentID *comment.ID, userID user.ID, username string) (*Mention, error) {
+	if username == "" {
+		return nil, errors.New("ユーザー名が空です")
+	}
+
+	id, err := NewID(uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mention{
+		id:        *id,
+		blogID:    blogID,
+		commentID: commentID,
+		userID:    userID,
+		username:  username,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// Reconstruct : メンションの再構築（DBからの読み込み時など）
+func Reconstruct(id string, blogID blog.ID, commentID *comment.ID, userID user.ID, username string, createdAt time.Time) (*Mention, error) {
+	mentionID, err := NewID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mention{
+		id:        *mentionID,
+		blogID:    blogID,
+		commentID: commentID,
+		userID:    userID,
+		username:  username,
+		createdAt: createdAt,
+	}, nil
+}
+
+// ID : IDの取得
+func (m Mention) ID() ID {
+	return m.id
+}
+
+// BlogID : ブログIDの取得
+func (m Mention) BlogID() blog.ID {
+	return m.blogID
+}
+
+// CommentID : コメントIDの取得（ブログ本文内のメンションの場合はnil）
+func (m Mention) CommentID() *comment.ID {
+	return m.commentID
+}
+
+// UserID : メンションされたユーザーIDの取得
+func (m Mention) UserID() user.ID {
+	return m.userID
+}
+
+// Username : ユーザー名の取得
+func (m Mention) Username() string {
+	return m.username
+}
+
+// CreatedAt : 作成日時の取得
+func (m Mention) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+// usernamePattern : @username 形式のメンション
+// メールアドレス等の誤検出を避けるため、直前が英数字・記号でない場合のみマッチさせる
+var usernamePattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_.@])@([A-Za-z0-9_]+)`)
+
+// Segment : 本文をメンションとそれ以外に分割した断片
+type Segment struct {
+	Text     string // 断片の文字列（メンションの場合は "@username"）
+	Username string // メンションの場合のみ設定される
+}
+
+// Split : 本文をメンションとそれ以外のテキストに分割する
+func Split(content string) []Segment {
+	var segments []Segment
+	last := 0
+	for _, loc := range usernamePattern.FindAllStringSubmatchIndex(content, -1) {
+		// loc[2], loc[3] はユーザー名のキャプチャ位置。直前の1文字が "@"
+		start, end := loc[2]-1, loc[3]
+		if start > last {
+			segments = append(segments, Segment{Text: content[last:start]})
+		}
+		segments = append(segments, Segment{Text: content[start:end], Username: content[loc[2]:loc[3]]})
+		last = end
+	}
+	if last < len(content) {
+		segments = append(segments, Segment{Text: content[last:]})
+	}
+	return segments
+}
+
+// ExtractUsernames : 本文からメンションされたユーザー名を重複なく抽出する
+func ExtractUsernames(content string) []string {
+	seen := make(map[string]bool)
+	var usernames []string
+	for _, segment := range Split(content) {
+		if segment.Username == "" {
+			continue
+		}
+		key := strings.ToLower(segment.Username)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		usernames = append(usernames, segment.Username)
+	}
+	return usernames
+}
@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor : カーソルの形式が不正であることを示すエラー
+var ErrInvalidCursor = errors.New("不正なカーソルです")
+
+// Cursor : 通知一覧のページング位置（このカーソルの通知より古い通知を取得する）
+// 作成日時が同じ通知はIDの降順で並べる
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// NewCursor : 通知の次の通知から取得するカーソルの生成
+func NewCursor(n *Notification) Cursor {
+	return Cursor{CreatedAt: n.CreatedAt(), ID: n.ID().String()}
+}
+
+// Encode : カーソルを不透明な文字列に変換
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor : 文字列からカーソルを取得
+func ParseCursor(value string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{CreatedAt: time.Unix(0, unixNano), ID: id}, nil
+}
@@ -0,0 +1,136 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/user"
+
+	"github.com/google/uuid"
+)
+
+// Type : 通知種別
+type Type string
+
+const (
+	// TypeMention : メンションされた
+	TypeMention Type = "mention"
+	// TypeComment : 自分のブログにコメントされた
+	TypeComment Type = "comment"
+	// TypeReply : 自分がコメントしたブログに他のユーザーがコメントした
+	TypeReply Type = "reply"
+)
+
+// Types : 全ての通知種別
+var Types = []Type{TypeMention, TypeComment, TypeReply}
+
+// ParseType : 文字列から通知種別を取得
+func ParseType(value string) (Type, error) {
+	for _, t := range Types {
+		if string(t) == value {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("不正な通知種別です: %s", value)
+}
+
+// Notification : 通知エンティティ
+type Notification struct {
+	id               ID
+	userID           user.ID // 通知を受け取るユーザー
+	actorID          user.ID // 通知のきっかけとなったユーザー
+	notificationType Type
+	blogID           blog.ID
+	commentID        *comment.ID // コメントに関する通知の場合のみ設定される
+	readAt           *time.Time
+	createdAt        time.Time
+}
+
+// NewNotification : 通知の生成
+func NewNotification(userID, actorID user.ID, notificationType Type, blogID blog.ID, commentID *comment.ID) (*Notification, error) {
+	if notificationType == "" {
+		return nil, errors.New("通知種別が空です")
+	}
+
+	id, err := NewID(uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		id:               *id,
+		userID:           userID,
+		actorID:          actorID,
+		notificationType: notificationType,
+		blogID:           blogID,
+		commentID:        commentID,
+		createdAt:        time.Now(),
+	}, nil
+}
+
+// Reconstruct : 通知の再構築（DBからの読み込み時など）
+func Reconstruct(id string, userID, actorID user.ID, notificationType Type, blogID blog.ID, commentID *comment.ID, readAt *time.Time, createdAt time.Time) (*Notification, error) {
+	notificationID, err := NewID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		id:               *notificationID,
+		userID:           userID,
+		actorID:          actorID,
+		notificationType: notificationType,
+		blogID:           blogID,
+		commentID:        commentID,
+		readAt:           readAt,
+		createdAt:        createdAt,
+	}, nil
+}
+
+// ID : IDの取得
+func (n Notification) ID() ID {
+	return n.id
+}
+
+// UserID : 通知を受け取るユーザーIDの取得
+func (n Notification) UserID() user.ID {
+	return n.userID
+}
+
+// ActorID : 通知のきっかけとなったユーザーIDの取得
+func (n Notification) ActorID() user.ID {
+	return n.actorID
+}
+
+// Type : 通知種別の取得
+func (n Notification) Type() Type {
+	return n.notificationType
+}
+
+// BlogID : ブログIDの取得
+func (n Notification) BlogID() blog.ID {
+	return n.blogID
+}
+
+// CommentID : コメントIDの取得（コメントに関する通知でない場合はnil）
+func (n Notification) CommentID() *comment.ID {
+	return n.commentID
+}
+
+// ReadAt : 既読日時の取得（未読の場合はnil）
+func (n Notification) ReadAt() *time.Time {
+	return n.readAt
+}
+
+// CreatedAt : 作成日時の取得
+func (n Notification) CreatedAt() time.Time {
+	return n.createdAt
+}
+
+// IsRead : 既読かどうか
+func (n Notification) IsRead() bool {
+	return n.readAt != nil
+}
@@ -0,0 +1,55 @@
+package notification
+
+import (
+	"myblog/app/domain/model/user"
+)
+
+// Preferences : ユーザーごとの通知設定
+// 設定されていない通知種別は受け取る
+type Preferences struct {
+	userID  user.ID
+	enabled map[Type]bool
+}
+
+// NewPreferences : 全ての通知を受け取る通知設定の生成
+func NewPreferences(userID user.ID) *Preferences {
+	return &Preferences{userID: userID, enabled: make(map[Type]bool)}
+}
+
+// ReconstructPreferences : 通知設定の再構築（DBからの読み込み時など）
+func ReconstructPreferences(userID user.ID, enabled map[Type]bool) *Preferences {
+	p := NewPreferences(userID)
+	for t, e := range enabled {
+		p.enabled[t] = e
+	}
+	return p
+}
+
+// UserID : ユーザーIDの取得
+func (p Preferences) UserID() user.ID {
+	return p.userID
+}
+
+// Enabled : 通知種別の通知を受け取るかどうか
+func (p Preferences) Enabled(t Type) bool {
+	enabled, ok := p.enabled[t]
+	return !ok || enabled
+}
+
+// Set : 通知種別の通知を受け取るかどうかの設定
+func (p *Preferences) Set(t Type, enabled bool) error {
+	if _, err := ParseType(string(t)); err != nil {
+		return err
+	}
+	p.enabled[t] = enabled
+	return nil
+}
+
+// All : 全ての通知種別の設定の取得
+func (p Preferences) All() map[Type]bool {
+	all := make(map[Type]bool, len(Types))
+	for _, t := range Types {
+		all[t] = p.Enabled(t)
+	}
+	return all
+}
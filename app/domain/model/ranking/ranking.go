@@ -1,25 +1,206 @@
 package ranking
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/user"
+
+	"github.com/google/uuid"
 )
 
 // Ranking は人気記事ランキングを表すドメインモデル
 type Ranking struct {
 	BlogID          blog.ID
 	RankingPosition int
-	Score           int
+	Score           float64
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
 
 // NewRanking はRankingのコンストラクタ
-func NewRanking(blogID blog.ID, rankingPosition int, score int) *Ranking {
+func NewRanking(blogID blog.ID, rankingPosition int, score float64) *Ranking {
 	return &Ranking{
 		BlogID:          blogID,
 		RankingPosition: rankingPosition,
 		Score:           score,
 	}
 }
+
+// AuthorRanking は著者ランキングを表すドメインモデル
+// スコアは著者の記事のスコアの合計
+type AuthorRanking struct {
+	UserID          user.ID
+	RankingPosition int
+	Score           float64
+	BlogCount       int
+}
+
+// NewAuthorRanking はAuthorRankingのコンストラクタ
+func NewAuthorRanking(userID user.ID, rankingPosition int, score float64, blogCount int) *AuthorRanking {
+	return &AuthorRanking{
+		UserID:          userID,
+		RankingPosition: rankingPosition,
+		Score:           score,
+		BlogCount:       blogCount,
+	}
+}
+
+// AuthorBlogRanking は著者ごとの人気記事ランキング（著者の記事の中での順位）を表すドメインモデル
+type AuthorBlogRanking struct {
+	UserID          user.ID
+	BlogID          blog.ID
+	RankingPosition int
+	Score           float64
+}
+
+// NewAuthorBlogRanking はAuthorBlogRankingのコンストラクタ
+func NewAuthorBlogRanking(userID user.ID, blogID blog.ID, rankingPosition int, score float64) *AuthorBlogRanking {
+	return &AuthorBlogRanking{
+		UserID:          userID,
+		BlogID:          blogID,
+		RankingPosition: rankingPosition,
+		Score:           score,
+	}
+}
+
+// Type はランキングの種別（集計期間）
+type Type string
+
+const (
+	// TypeDaily は過去1日間のランキング
+	TypeDaily Type = "daily"
+	// TypeWeekly は過去7日間のランキング
+	TypeWeekly Type = "weekly"
+	// TypeMonthly は過去30日間のランキング
+	TypeMonthly Type = "monthly"
+	// TypeAllTime は全期間のランキング
+	TypeAllTime Type = "all_time"
+)
+
+// Types は全てのランキング種別
+var Types = []Type{TypeDaily, TypeWeekly, TypeMonthly, TypeAllTime}
+
+// ParseType は文字列からランキング種別を取得する
+func ParseType(value string) (Type, error) {
+	for _, t := range Types {
+		if string(t) == value {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("不正なランキング種別です: %s", value)
+}
+
+// TypeForDays は日数に最も近い集計期間のランキング種別を返す（差が同じ場合は長い方）
+// 日数で集計期間を指定していた以前のバッチの引数との互換性のために使用する
+func TypeForDays(days int) (Type, error) {
+	if days <= 0 {
+		return "", fmt.Errorf("日数は1以上を指定してください: %d", days)
+	}
+
+	nearest := TypeDaily
+	for _, t := range Types {
+		if t.Days() == 0 {
+			continue
+		}
+		if abs(days-t.Days()) <= abs(days-nearest.Days()) {
+			nearest = t
+		}
+	}
+	return nearest, nil
+}
+
+// abs は整数の絶対値を返す
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Days は集計期間の日数を返す（全期間の場合は0）
+func (t Type) Days() int {
+	switch t {
+	case TypeDaily:
+		return 1
+	case TypeWeekly:
+		return 7
+	case TypeMonthly:
+		return 30
+	default:
+		return 0
+	}
+}
+
+// Since は基準日時から見た集計期間の開始日時を返す（全期間の場合はゼロ値）
+func (t Type) Since(now time.Time) time.Time {
+	if t.Days() == 0 {
+		return time.Time{}
+	}
+	return now.AddDate(0, 0, -t.Days())
+}
+
+// DefaultSize はランキングに含めるブログのデフォルトの件数
+const DefaultSize = 1000
+
+// DefaultAuthorSize は著者ランキングに含める著者のデフォルトの件数
+// 著者ごとの人気記事ランキングも著者ランキングに入った著者のみ作成する
+const DefaultAuthorSize = 100
+
+// DefaultAuthorBlogSize は著者ごとの人気記事ランキングに含めるブログのデフォルトの件数
+const DefaultAuthorBlogSize = 20
+
+// Snapshot はある時点で集計されたランキングを表すドメインモデル
+// 集計のたびに新しいスナップショットを作成し、過去のスナップショットは履歴として残す
+// スナップショットは全ての順位を書き込んでから公開し、公開前のスナップショットは参照されない
+type Snapshot struct {
+	ID           string
+	Type         Type
+	CalculatedAt time.Time
+}
+
+// NewSnapshot はSnapshotのコンストラクタ
+func NewSnapshot(rankingType Type, calculatedAt time.Time) *Snapshot {
+	return &Snapshot{
+		ID:           uuid.New().String(),
+		Type:         rankingType,
+		CalculatedAt: calculatedAt,
+	}
+}
+
+// Weights はランキングスコアの重み付け
+// 重み付き件数は 期間内の閲覧数 * View + 期間内のいいね数 * Like + 期間内のコメント数 * Comment で算出する
+type Weights struct {
+	View    int
+	Like    int
+	Comment int
+}
+
+// DefaultWeights はデフォルトの重み付け
+var DefaultWeights = Weights{
+	View:    1,
+	Like:    1,
+	Comment: 1,
+}
+
+// Validate は重み付けが妥当かどうかを検証する
+func (w Weights) Validate() error {
+	if w.View < 0 || w.Like < 0 || w.Comment < 0 {
+		return errors.New("重みには0以上を指定してください")
+	}
+	if w.View == 0 && w.Like == 0 && w.Comment == 0 {
+		return errors.New("いずれかの重みに1以上を指定してください")
+	}
+	return nil
+}
+
+// Result は1回の集計で作成したランキング
+type Result struct {
+	Snapshot           *Snapshot
+	Rankings           []*Ranking
+	AuthorRankings     []*AuthorRanking
+	AuthorBlogRankings []*AuthorBlogRanking
+	BlogCount          int // 集計対象となったブログの件数
+}
@@ -0,0 +1,146 @@
+package ranking
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"myblog/app/domain/model/blog"
+)
+
+// Stats はスコア計算に用いるブログの集計期間内の統計情報
+type Stats struct {
+	BlogID      blog.ID
+	Views       int
+	Likes       int
+	Comments    int
+	PublishedAt time.Time
+}
+
+// Points は統計情報の重み付き和を返す
+func (s Stats) Points(weights Weights) float64 {
+	return float64(s.Views*weights.View + s.Likes*weights.Like + s.Comments*weights.Comment)
+}
+
+// Scorer はランキングのスコアの算出方法
+type Scorer interface {
+	// Name は算出方法の名前を返す
+	Name() string
+	// Score は集計日時nowにおけるブログのスコアを返す
+	Score(stats Stats, now time.Time) float64
+}
+
+// 算出方法の名前
+const (
+	// ScoringCount は重み付き件数によるスコア
+	ScoringCount = "count"
+	// ScoringGravity は投稿からの経過時間で重力減衰させたスコア（Hacker News方式）
+	ScoringGravity = "gravity"
+	// ScoringHalfLife は投稿からの経過時間で半減期減衰させたスコア
+	ScoringHalfLife = "half-life"
+)
+
+// DefaultGravity は重力減衰のデフォルトの重力
+const DefaultGravity = 1.8
+
+// DefaultHalfLife は半減期減衰のデフォルトの半減期
+const DefaultHalfLife = 24 * time.Hour
+
+// countScorer は重み付き件数をそのままスコアとする
+type countScorer struct {
+	weights Weights
+}
+
+// NewCountScorer は重み付き件数によるScorerを生成する
+func NewCountScorer(weights Weights) (Scorer, error) {
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+	return &countScorer{weights: weights}, nil
+}
+
+// Name は算出方法の名前を返す
+func (s *countScorer) Name() string {
+	return ScoringCount
+}
+
+// Score は重み付き件数を返す
+func (s *countScorer) Score(stats Stats, now time.Time) float64 {
+	return stats.Points(s.weights)
+}
+
+// gravityScorer は 重み付き件数 / (経過時間 + 2) ^ gravity をスコアとする
+// 古い記事ほどスコアが小さくなるため、集計期間の境界付近の記事が急に順位を落とすことがない
+type gravityScorer struct {
+	weights Weights
+	gravity float64
+}
+
+// NewGravityScorer は重力減衰によるScorerを生成する
+func NewGravityScorer(weights Weights, gravity float64) (Scorer, error) {
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+	if gravity <= 0 {
+		return nil, errors.New("重力には0より大きい値を指定してください")
+	}
+	return &gravityScorer{weights: weights, gravity: gravity}, nil
+}
+
+// Name は算出方法の名前を返す
+func (s *gravityScorer) Name() string {
+	return ScoringGravity
+}
+
+// Score は経過時間（時間単位）で減衰させた重み付き件数を返す
+func (s *gravityScorer) Score(stats Stats, now time.Time) float64 {
+	return stats.Points(s.weights) / math.Pow(ageHours(stats, now)+2, s.gravity)
+}
+
+// halfLifeScorer は 重み付き件数 * 0.5 ^ (経過時間 / halfLife) をスコアとする
+type halfLifeScorer struct {
+	weights  Weights
+	halfLife time.Duration
+}
+
+// NewHalfLifeScorer は半減期減衰によるScorerを生成する
+func NewHalfLifeScorer(weights Weights, halfLife time.Duration) (Scorer, error) {
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+	if halfLife <= 0 {
+		return nil, errors.New("半減期には0より大きい値を指定してください")
+	}
+	return &halfLifeScorer{weights: weights, halfLife: halfLife}, nil
+}
+
+// Name は算出方法の名前を返す
+func (s *halfLifeScorer) Name() string {
+	return ScoringHalfLife
+}
+
+// Score は半減期ごとに半分になるよう減衰させた重み付き件数を返す
+func (s *halfLifeScorer) Score(stats Stats, now time.Time) float64 {
+	return stats.Points(s.weights) * math.Pow(0.5, ageHours(stats, now)/s.halfLife.Hours())
+}
+
+// ageHours は投稿からの経過時間を時間単位で返す（未来の日時の場合は0）
+func ageHours(stats Stats, now time.Time) float64 {
+	return math.Max(now.Sub(stats.PublishedAt).Hours(), 0)
+}
+
+// NewScorer は名前に対応するScorerを生成する
+// gravityは重力減衰、halfLifeは半減期減衰の場合のみ使用する
+func NewScorer(name string, weights Weights, gravity float64, halfLife time.Duration) (Scorer, error) {
+	switch name {
+	case ScoringCount:
+		return NewCountScorer(weights)
+	case ScoringGravity:
+		return NewGravityScorer(weights, gravity)
+	case ScoringHalfLife:
+		return NewHalfLifeScorer(weights, halfLife)
+	default:
+		return nil, fmt.Errorf("不正なスコアの算出方法です: %s", name)
+	}
+}
@@ -0,0 +1,186 @@
+package ranking
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScorer_Score(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	weights := Weights{View: 1, Like: 2, Comment: 3}
+
+	tests := []struct {
+		name     string
+		scoring  string
+		gravity  float64
+		halfLife time.Duration
+		stats    Stats
+		want     float64
+	}{
+		{
+			name:    "重み付き件数",
+			scoring: ScoringCount,
+			stats:   Stats{Views: 10, Likes: 2, Comments: 1, PublishedAt: now.Add(-100 * time.Hour)},
+			want:    17,
+		},
+		{
+			name:    "重力減衰: 投稿直後は (0 + 2) ^ gravity で割る",
+			scoring: ScoringGravity,
+			gravity: 2,
+			stats:   Stats{Views: 16, PublishedAt: now},
+			want:    4,
+		},
+		{
+			name:    "重力減衰: 経過時間とともに減衰する",
+			scoring: ScoringGravity,
+			gravity: 2,
+			stats:   Stats{Views: 16, PublishedAt: now.Add(-2 * time.Hour)},
+			want:    1,
+		},
+		{
+			name:    "重力減衰: 未来の投稿日時は経過時間0とする",
+			scoring: ScoringGravity,
+			gravity: 2,
+			stats:   Stats{Views: 16, PublishedAt: now.Add(time.Hour)},
+			want:    4,
+		},
+		{
+			name:     "半減期減衰: 投稿直後は減衰しない",
+			scoring:  ScoringHalfLife,
+			halfLife: 24 * time.Hour,
+			stats:    Stats{Likes: 4, PublishedAt: now},
+			want:     8,
+		},
+		{
+			name:     "半減期減衰: 半減期ごとに半分になる",
+			scoring:  ScoringHalfLife,
+			halfLife: 24 * time.Hour,
+			stats:    Stats{Likes: 4, PublishedAt: now.Add(-48 * time.Hour)},
+			want:     2,
+		},
+		{
+			name:     "半減期減衰: 半減期の途中も連続して減衰する",
+			scoring:  ScoringHalfLife,
+			halfLife: 24 * time.Hour,
+			stats:    Stats{Likes: 4, PublishedAt: now.Add(-12 * time.Hour)},
+			want:     8 / math.Sqrt2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer, err := NewScorer(tt.scoring, weights, tt.gravity, tt.halfLife)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if scorer.Name() != tt.scoring {
+				t.Errorf("算出方法の名前が異なります: got %s, want %s", scorer.Name(), tt.scoring)
+			}
+			if got := scorer.Score(tt.stats, now); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("スコアが異なります: got %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScorer_Ordering(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	// 古い人気記事と新しい記事（古い記事は重み付き件数が3倍）
+	old := Stats{Views: 30, PublishedAt: now.Add(-72 * time.Hour)}
+	fresh := Stats{Views: 10, PublishedAt: now.Add(-time.Hour)}
+
+	tests := []struct {
+		name        string
+		scoring     string
+		wantOldWins bool
+	}{
+		{name: "重み付き件数は経過時間によらず件数の多い記事が上位", scoring: ScoringCount, wantOldWins: true},
+		{name: "重力減衰は新しい記事が上位", scoring: ScoringGravity, wantOldWins: false},
+		{name: "半減期減衰は新しい記事が上位", scoring: ScoringHalfLife, wantOldWins: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer, err := NewScorer(tt.scoring, DefaultWeights, DefaultGravity, DefaultHalfLife)
+			if err != nil {
+				t.Fatal(err)
+			}
+			oldScore, freshScore := scorer.Score(old, now), scorer.Score(fresh, now)
+			if got := oldScore > freshScore; got != tt.wantOldWins {
+				t.Errorf("スコアの大小が異なります: old %g, fresh %g", oldScore, freshScore)
+			}
+		})
+	}
+}
+
+func TestNewScorer_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		scoring  string
+		weights  Weights
+		gravity  float64
+		halfLife time.Duration
+		wantErr  string
+	}{
+		{name: "不明な算出方法", scoring: "unknown", weights: DefaultWeights, wantErr: "不正なスコアの算出方法です: unknown"},
+		{name: "負の重み", scoring: ScoringCount, weights: Weights{View: -1, Like: 1}, wantErr: "重みには0以上を指定してください"},
+		{name: "全ての重みが0", scoring: ScoringCount, weights: Weights{}, wantErr: "いずれかの重みに1以上を指定してください"},
+		{name: "重力が0", scoring: ScoringGravity, weights: DefaultWeights, gravity: 0, wantErr: "重力には0より大きい値を指定してください"},
+		{name: "負の重力", scoring: ScoringGravity, weights: DefaultWeights, gravity: -1.8, wantErr: "重力には0より大きい値を指定してください"},
+		{name: "半減期が0", scoring: ScoringHalfLife, weights: DefaultWeights, halfLife: 0, wantErr: "半減期には0より大きい値を指定してください"},
+		{name: "負の半減期", scoring: ScoringHalfLife, weights: DefaultWeights, halfLife: -time.Hour, wantErr: "半減期には0より大きい値を指定してください"},
+		{name: "重み付き件数では重力と半減期を使用しない", scoring: ScoringCount, weights: DefaultWeights, gravity: -1, halfLife: -time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewScorer(tt.scoring, tt.weights, tt.gravity, tt.halfLife)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("予期しないエラー: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("エラーが一致しません: got %v, want %q を含む", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTypeForDays(t *testing.T) {
+	tests := []struct {
+		days    int
+		want    Type
+		wantErr bool
+	}{
+		{days: 1, want: TypeDaily},
+		{days: 3, want: TypeDaily},
+		{days: 4, want: TypeWeekly}, // dailyとweeklyから等距離の場合は長い方
+		{days: 7, want: TypeWeekly},
+		{days: 18, want: TypeWeekly},
+		{days: 19, want: TypeMonthly},
+		{days: 30, want: TypeMonthly},
+		{days: 365, want: TypeMonthly},
+		{days: 0, wantErr: true},
+		{days: -7, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := TypeForDays(tt.days)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("TypeForDays(%d): エラーが発生しませんでした", tt.days)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("TypeForDays(%d): 予期しないエラー: %v", tt.days, err)
+		}
+		if got != tt.want {
+			t.Errorf("TypeForDays(%d) = %s, want %s", tt.days, got, tt.want)
+		}
+	}
+}
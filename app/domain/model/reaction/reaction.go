@@ -0,0 +1,127 @@
+package reaction
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/user"
+)
+
+// TargetType : リアクション対象の種別
+type TargetType string
+
+const (
+	// TargetBlog : ブログへのリアクション
+	TargetBlog TargetType = "blog"
+	// TargetComment : コメントへのリアクション
+	TargetComment TargetType = "comment"
+)
+
+// DefaultEmojis : デフォルトで利用可能な絵文字
+var DefaultEmojis = []string{"👍", "❤️", "😂", "🎉", "😮", "😢"}
+
+// EmojiSet : 利用可能な絵文字の集合
+type EmojiSet struct {
+	emojis []string
+	index  map[string]bool
+}
+
+// NewEmojiSet : 絵文字集合の生成
+func NewEmojiSet(emojis []string) (*EmojiSet, error) {
+	if len(emojis) == 0 {
+		return nil, errors.New("絵文字が指定されていません")
+	}
+
+	index := make(map[string]bool, len(emojis))
+	for _, emoji := range emojis {
+		if emoji == "" {
+			return nil, errors.New("空の絵文字は指定できません")
+		}
+		if index[emoji] {
+			return nil, fmt.Errorf("絵文字が重複しています: %s", emoji)
+		}
+		index[emoji] = true
+	}
+
+	return &EmojiSet{
+		emojis: append([]string(nil), emojis...),
+		index:  index,
+	}, nil
+}
+
+// Contains : 利用可能な絵文字かどうか
+func (s EmojiSet) Contains(emoji string) bool {
+	return s.index[emoji]
+}
+
+// Emojis : 利用可能な絵文字一覧の取得
+func (s EmojiSet) Emojis() []string {
+	return append([]string(nil), s.emojis...)
+}
+
+// Reaction : リアクションエンティティ（ユーザーは対象ごとに1種類のみリアクションできる）
+type Reaction struct {
+	userID     user.ID
+	targetType TargetType
+	targetID   string
+	emoji      string
+	createdAt  time.Time
+}
+
+// NewReaction : リアクションの生成
+func NewReaction(userID user.ID, targetType TargetType, targetID, emoji string, emojis *EmojiSet) (*Reaction, error) {
+	if targetType != TargetBlog && targetType != TargetComment {
+		return nil, fmt.Errorf("不正なリアクション対象です: %s", targetType)
+	}
+	if targetID == "" {
+		return nil, errors.New("リアクション対象のIDが空です")
+	}
+	if !emojis.Contains(emoji) {
+		return nil, fmt.Errorf("利用できない絵文字です: %s", emoji)
+	}
+
+	return &Reaction{
+		userID:     userID,
+		targetType: targetType,
+		targetID:   targetID,
+		emoji:      emoji,
+		createdAt:  time.Now(),
+	}, nil
+}
+
+// UserID : ユーザーIDの取得
+func (r Reaction) UserID() user.ID {
+	return r.userID
+}
+
+// TargetType : リアクション対象の種別の取得
+func (r Reaction) TargetType() TargetType {
+	return r.targetType
+}
+
+// TargetID : リアクション対象のIDの取得
+func (r Reaction) TargetID() string {
+	return r.targetID
+}
+
+// Emoji : 絵文字の取得
+func (r Reaction) Emoji() string {
+	return r.emoji
+}
+
+// CreatedAt : 作成日時の取得
+func (r Reaction) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// Summary : 対象ごとのリアクション集計
+type Summary struct {
+	Counts map[string]int // 絵文字ごとの件数
+	Mine   string         // 閲覧ユーザー自身のリアクション（未リアクションの場合は空）
+}
+
+// NewSummary : 空の集計の生成
+func NewSummary() *Summary {
+	return &Summary{Counts: make(map[string]int)}
+}
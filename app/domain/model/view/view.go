@@ -0,0 +1,56 @@
+package view
+
+import (
+	"errors"
+	"time"
+
+	"myblog/app/domain/model/blog"
+)
+
+// View : ブログの閲覧
+type View struct {
+	blogID    blog.ID
+	viewerKey string // 閲覧者を識別するキー（ログインユーザーID、または匿名閲覧者のハッシュ）
+	viewedAt  time.Time
+}
+
+// NewView : 閲覧の生成
+func NewView(blogID blog.ID, viewerKey string) (*View, error) {
+	if viewerKey == "" {
+		return nil, errors.New("閲覧者キーが空です")
+	}
+
+	return &View{
+		blogID:    blogID,
+		viewerKey: viewerKey,
+		viewedAt:  time.Now(),
+	}, nil
+}
+
+// BlogID : ブログIDの取得
+func (v View) BlogID() blog.ID {
+	return v.blogID
+}
+
+// ViewerKey : 閲覧者キーの取得
+func (v View) ViewerKey() string {
+	return v.viewerKey
+}
+
+// ViewedAt : 閲覧日時の取得
+func (v View) ViewedAt() time.Time {
+	return v.viewedAt
+}
+
+// Date : 閲覧日（日別集計のキー）の取得
+func (v View) Date() time.Time {
+	y, m, d := v.viewedAt.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, v.viewedAt.Location())
+}
+
+// DailyCount : ブログごとの日別閲覧数
+type DailyCount struct {
+	BlogID blog.ID
+	Date   time.Time
+	Views  int
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"myblog/app/domain/model/view"
+)
+
+// BlogView : ブログ閲覧数リポジトリインターフェース
+type BlogView interface {
+	// IncrementDailyCounts : 日別閲覧数を加算する
+	IncrementDailyCounts(ctx context.Context, counts []*view.DailyCount) error
+}
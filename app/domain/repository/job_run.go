@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"myblog/app/domain/model/jobrun"
+)
+
+// JobRun : ジョブ実行リポジトリインターフェース
+type JobRun interface {
+	// Create : ジョブ実行の作成（同じジョブの同じ予定実行日時の実行が既にある場合はfalse）
+	Create(ctx context.Context, run *jobrun.JobRun) (bool, error)
+	// Update : ジョブ実行の状態の更新
+	Update(ctx context.Context, run *jobrun.JobRun) error
+	// FindRecent : ジョブ実行の検索（新しい順。jobNameが空の場合は全てのジョブ）
+	FindRecent(ctx context.Context, jobName string, limit int) ([]*jobrun.JobRun, error)
+}
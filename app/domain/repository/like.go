@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/like"
+	"myblog/app/domain/model/user"
+)
+
+// Like : いいねリポジトリインターフェース
+type Like interface {
+	// Save : いいねを保存する（いいね済みの場合は何もしない）
+	Save(ctx context.Context, like *like.Like) error
+	// Delete : いいねを削除する（存在しない場合も成功とする）
+	Delete(ctx context.Context, blogID blog.ID, userID user.ID) error
+	// CountByBlogIDs : ブログIDごとのいいね数を取得する
+	CountByBlogIDs(ctx context.Context, blogIDs []blog.ID) (map[string]int, error)
+	// FindLikedBlogIDs : 指定したブログのうちユーザーがいいね済みのブログIDを取得する
+	FindLikedBlogIDs(ctx context.Context, userID user.ID, blogIDs []blog.ID) (map[string]bool, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/mention"
+)
+
+// Mention : メンションリポジトリインターフェース
+type Mention interface {
+	SaveAll(ctx context.Context, mentions []*mention.Mention) error
+	FindByBlogIDs(ctx context.Context, blogIDs []blog.ID) ([]*mention.Mention, error)
+	FindByCommentIDs(ctx context.Context, commentIDs []comment.ID) ([]*mention.Mention, error)
+	DeleteByBlogID(ctx context.Context, blogID blog.ID) error
+	DeleteByCommentID(ctx context.Context, commentID comment.ID) error
+}
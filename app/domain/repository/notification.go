@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"myblog/app/domain/model/notification"
+	"myblog/app/domain/model/user"
+)
+
+// Notification : 通知リポジトリインターフェース
+type Notification interface {
+	SaveAll(ctx context.Context, notifications []*notification.Notification) error
+	// FindByUserID : ユーザーの通知の検索（新しい順。cursorが指定された場合はその通知より古い通知）
+	FindByUserID(ctx context.Context, userID user.ID, cursor *notification.Cursor, unreadOnly bool, limit int) ([]*notification.Notification, error)
+	CountUnread(ctx context.Context, userID user.ID) (int, error)
+	// MarkRead : ユーザーの通知の既読化（既読の通知と他のユーザーの通知は変更しない）
+	MarkRead(ctx context.Context, userID user.ID, ids []notification.ID, readAt time.Time) error
+	MarkAllRead(ctx context.Context, userID user.ID, readAt time.Time) error
+}
+
+// NotificationPreference : 通知設定リポジトリインターフェース
+type NotificationPreference interface {
+	// FindByUserIDs : ユーザーごとの通知設定の検索（設定していないユーザーは全ての通知を受け取る設定を返す）
+	FindByUserIDs(ctx context.Context, userIDs []user.ID) (map[string]*notification.Preferences, error)
+	Save(ctx context.Context, preferences *notification.Preferences) error
+}
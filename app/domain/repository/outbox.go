@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"myblog/app/domain/model/event"
+)
+
+// Outbox : アウトボックス（未配信のドメインイベント）リポジトリインターフェース
+type Outbox interface {
+	// Save : イベントの保存（同じ冪等キーのイベントが既にある場合は保存しない）
+	Save(ctx context.Context, events ...*event.Event) error
+	// FindPending : 配信予定日時を過ぎた未配信のイベントの検索（発生順）
+	FindPending(ctx context.Context, now time.Time, limit int) ([]*event.Event, error)
+	// MarkPublished : 全ての購読者への配信が完了したイベントの記録
+	MarkPublished(ctx context.Context, id event.ID, publishedAt time.Time) error
+	// MarkFailed : 配信に失敗したイベントの記録（nextAttemptAt以降に再配信する）
+	MarkFailed(ctx context.Context, id event.ID, errorMessage string, nextAttemptAt time.Time) error
+	// FindDeliveredSubscribers : イベントの配信が完了した購読者名の検索
+	FindDeliveredSubscribers(ctx context.Context, id event.ID) ([]string, error)
+	// SaveDelivery : 購読者へのイベントの配信完了の記録
+	SaveDelivery(ctx context.Context, id event.ID, subscriber string, deliveredAt time.Time) error
+}
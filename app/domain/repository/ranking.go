@@ -2,15 +2,35 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"myblog/app/domain/model/ranking"
 )
 
 // RankingRepository はランキングのリポジトリインターフェース
 type RankingRepository interface {
-	// SaveRankings はランキングを保存する
-	SaveRankings(ctx context.Context, rankings []*ranking.Ranking) error
+	// CreateSnapshot は未公開のスナップショットを作成する
+	CreateSnapshot(ctx context.Context, snapshot *ranking.Snapshot) error
 
-	// GetRankings はランキングを取得する
-	GetRankings(ctx context.Context, limit int) ([]*ranking.Ranking, error)
+	// SaveRankings はスナップショットにランキングを追加する
+	SaveRankings(ctx context.Context, snapshot *ranking.Snapshot, rankings []*ranking.Ranking) error
+
+	// SaveAuthorRankings はスナップショットに著者ランキングを追加する
+	SaveAuthorRankings(ctx context.Context, snapshot *ranking.Snapshot, rankings []*ranking.AuthorRanking) error
+
+	// SaveAuthorBlogRankings はスナップショットに著者ごとの人気記事ランキングを追加する
+	SaveAuthorBlogRankings(ctx context.Context, snapshot *ranking.Snapshot, rankings []*ranking.AuthorBlogRanking) error
+
+	// PublishSnapshot はスナップショットを公開し、最新のランキングとして参照できるようにする
+	PublishSnapshot(ctx context.Context, snapshot *ranking.Snapshot) error
+
+	// DeleteSnapshot はスナップショットを削除する
+	DeleteSnapshot(ctx context.Context, snapshot *ranking.Snapshot) error
+
+	// GetRankings は指定した種別の最新の公開済みランキングを取得する
+	GetRankings(ctx context.Context, rankingType ranking.Type, limit int) ([]*ranking.Ranking, error)
+
+	// DeleteSnapshotsBefore は指定日時より前に集計されたスナップショットを削除する
+	// 各種別の最新の公開済みスナップショットは削除しない
+	DeleteSnapshotsBefore(ctx context.Context, before time.Time) (int64, error)
 }
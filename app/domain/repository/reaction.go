@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"myblog/app/domain/model/reaction"
+	"myblog/app/domain/model/user"
+)
+
+// Reaction : リアクションリポジトリインターフェース
+type Reaction interface {
+	// Save : リアクションを保存する（既存のリアクションがあれば置き換える）
+	Save(ctx context.Context, reaction *reaction.Reaction) error
+	// Delete : リアクションを削除する（存在しない場合も成功とする）
+	Delete(ctx context.Context, userID user.ID, targetType reaction.TargetType, targetID string) error
+	// CountByTargetIDs : 対象IDごと・絵文字ごとのリアクション件数を取得する
+	CountByTargetIDs(ctx context.Context, targetType reaction.TargetType, targetIDs []string) (map[string]map[string]int, error)
+	// FindEmojisByUserID : ユーザーの対象IDごとのリアクションを取得する
+	FindEmojisByUserID(ctx context.Context, userID user.ID, targetType reaction.TargetType, targetIDs []string) (map[string]string, error)
+}
@@ -69,13 +69,7 @@ func (r *BlogRepository) Save(ctx context.Context, blog *blog.Blog) error {
 		"updated_at": blog.UpdatedAt(),
 	}
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		_, err := tx.NamedExec(query, params)
-		return err
-	}
-
-	_, err := r.db.Write(ctx).NamedExecContext(ctx, query, params)
+	_, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
 	return err
 }
 
@@ -92,19 +86,7 @@ func (r *BlogRepository) FindByID(ctx context.Context, id string) (*blog.Blog, e
 
 	var dto blogDTO
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		err := tx.QueryRowx(query, id).StructScan(&dto)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, fmt.Errorf("blog not found with id: %s", id)
-			}
-			return nil, err
-		}
-		return dto.toModel()
-	}
-
-	err := r.db.Read(ctx).QueryRowxContext(ctx, query, id).StructScan(&dto)
+	err := r.db.Reader(ctx).QueryRowxContext(ctx, query, id).StructScan(&dto)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("blog not found with id: %s", id)
@@ -130,17 +112,9 @@ func (r *BlogRepository) FindByUserID(ctx context.Context, userID user.ID) ([]*b
 
 	var dtos []blogDTO
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		err := tx.Select(&dtos, query, userID.String())
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		err := r.db.Read(ctx).SelectContext(ctx, &dtos, query, userID.String())
-		if err != nil {
-			return nil, err
-		}
+	err := r.db.Reader(ctx).SelectContext(ctx, &dtos, query, userID.String())
+	if err != nil {
+		return nil, err
 	}
 
 	blogs := make([]*blog.Blog, len(dtos))
@@ -169,17 +143,9 @@ func (r *BlogRepository) FindAll(ctx context.Context, offset, limit int) ([]*blo
 
 	var dtos []blogDTO
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		err := tx.Select(&dtos, query, limit, offset)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		err := r.db.Read(ctx).SelectContext(ctx, &dtos, query, limit, offset)
-		if err != nil {
-			return nil, err
-		}
+	err := r.db.Reader(ctx).SelectContext(ctx, &dtos, query, limit, offset)
+	if err != nil {
+		return nil, err
 	}
 
 	blogs := make([]*blog.Blog, len(dtos))
@@ -213,26 +179,7 @@ func (r *BlogRepository) Update(ctx context.Context, blog *blog.Blog) error {
 		"updated_at": time.Now(),
 	}
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		result, err := tx.NamedExec(query, params)
-		if err != nil {
-			return err
-		}
-
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			return err
-		}
-
-		if rowsAffected == 0 {
-			return fmt.Errorf("blog not found with id: %s", blog.ID().String())
-		}
-
-		return nil
-	}
-
-	result, err := r.db.Write(ctx).NamedExecContext(ctx, query, params)
+	result, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
 	if err != nil {
 		return err
 	}
@@ -256,26 +203,7 @@ func (r *BlogRepository) Delete(ctx context.Context, id string) error {
 		WHERE id = ?
 	`
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		result, err := tx.Exec(query, id)
-		if err != nil {
-			return err
-		}
-
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			return err
-		}
-
-		if rowsAffected == 0 {
-			return fmt.Errorf("blog not found with id: %s", id)
-		}
-
-		return nil
-	}
-
-	result, err := r.db.Write(ctx).ExecContext(ctx, query, id)
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,49 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"myblog/app/domain/model/view"
+	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
+)
+
+// BlogViewRepository : ブログ閲覧数リポジトリの実装
+type BlogViewRepository struct {
+	db *rdb.DB
+}
+
+// NewBlogViewRepository : BlogViewRepositoryの生成
+func NewBlogViewRepository(db *rdb.DB) repository.BlogView {
+	return &BlogViewRepository{db: db}
+}
+
+// IncrementDailyCounts : 日別閲覧数の加算（複数行をまとめて加算する）
+func (r *BlogViewRepository) IncrementDailyCounts(ctx context.Context, counts []*view.DailyCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO blog_view_counts (
+			blog_id, view_date, views
+		) VALUES (
+			:blog_id, :view_date, :views
+		)
+		%s
+			views = views + %s
+	`, onDuplicateKeyUpdate(r.db, "blog_id", "view_date"), insertedValue(r.db, "views"))
+
+	params := make([]map[string]interface{}, len(counts))
+	for i, count := range counts {
+		params[i] = map[string]interface{}{
+			"blog_id":   count.BlogID.String(),
+			"view_date": count.Date.Format("2006-01-02"),
+			"views":     count.Views,
+		}
+	}
+
+	_, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
+	return err
+}
@@ -75,13 +75,7 @@ func (r *CommentRepository) Save(ctx context.Context, comment *comment.Comment)
 		"updated_at": comment.UpdatedAt(),
 	}
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		_, err := tx.NamedExec(query, params)
-		return err
-	}
-
-	_, err := r.db.Write(ctx).NamedExecContext(ctx, query, params)
+	_, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
 	return err
 }
 
@@ -98,19 +92,7 @@ func (r *CommentRepository) FindByID(ctx context.Context, id string) (*comment.C
 
 	var dto commentDTO
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		err := tx.QueryRowx(query, id).StructScan(&dto)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, fmt.Errorf("comment not found with id: %s", id)
-			}
-			return nil, err
-		}
-		return dto.toModel()
-	}
-
-	err := r.db.Read(ctx).QueryRowxContext(ctx, query, id).StructScan(&dto)
+	err := r.db.Reader(ctx).QueryRowxContext(ctx, query, id).StructScan(&dto)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("comment not found with id: %s", id)
@@ -136,17 +118,9 @@ func (r *CommentRepository) FindByBlogID(ctx context.Context, blogID blog.ID) ([
 
 	var dtos []commentDTO
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		err := tx.Select(&dtos, query, blogID.String())
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		err := r.db.Read(ctx).SelectContext(ctx, &dtos, query, blogID.String())
-		if err != nil {
-			return nil, err
-		}
+	err := r.db.Reader(ctx).SelectContext(ctx, &dtos, query, blogID.String())
+	if err != nil {
+		return nil, err
 	}
 
 	comments := make([]*comment.Comment, len(dtos))
@@ -176,17 +150,9 @@ func (r *CommentRepository) FindByUserID(ctx context.Context, userID user.ID) ([
 
 	var dtos []commentDTO
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		err := tx.Select(&dtos, query, userID.String())
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		err := r.db.Read(ctx).SelectContext(ctx, &dtos, query, userID.String())
-		if err != nil {
-			return nil, err
-		}
+	err := r.db.Reader(ctx).SelectContext(ctx, &dtos, query, userID.String())
+	if err != nil {
+		return nil, err
 	}
 
 	comments := make([]*comment.Comment, len(dtos))
@@ -218,26 +184,7 @@ func (r *CommentRepository) Update(ctx context.Context, comment *comment.Comment
 		"updated_at": time.Now(),
 	}
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		result, err := tx.NamedExec(query, params)
-		if err != nil {
-			return err
-		}
-
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			return err
-		}
-
-		if rowsAffected == 0 {
-			return fmt.Errorf("comment not found with id: %s", comment.ID().String())
-		}
-
-		return nil
-	}
-
-	result, err := r.db.Write(ctx).NamedExecContext(ctx, query, params)
+	result, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
 	if err != nil {
 		return err
 	}
@@ -261,26 +208,7 @@ func (r *CommentRepository) Delete(ctx context.Context, id string) error {
 		WHERE id = ?
 	`
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		result, err := tx.Exec(query, id)
-		if err != nil {
-			return err
-		}
-
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			return err
-		}
-
-		if rowsAffected == 0 {
-			return fmt.Errorf("comment not found with id: %s", id)
-		}
-
-		return nil
-	}
-
-	result, err := r.db.Write(ctx).ExecContext(ctx, query, id)
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,94 @@
+package dao_test
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"myblog/app/config"
+	"myblog/app/infra/dao"
+	"myblog/app/infra/db/migration"
+	"myblog/app/infra/db/rdb"
+	"myblog/app/infra/query"
+	"myblog/app/testing/contract"
+	"myblog/db/migrations"
+)
+
+func TestContract_SQLite(t *testing.T) {
+	contract.Run(t, func(t *testing.T) contract.Repositories {
+		// メモリ上のデータベースはテストケースごとに空の状態から作成する
+		db, err := rdb.Open(rdb.Config{Driver: rdb.DriverSQLite, Path: ":memory:"})
+		if err != nil {
+			t.Fatalf("データベースの接続に失敗しました: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		migrate(t, db, migrations.SQLiteFS())
+		return repositories(db)
+	})
+}
+
+func TestContract_MySQL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("MySQLが必要なため-shortでは実行しない")
+	}
+
+	cfg, err := config.Load(config.AppBatch)
+	if err != nil {
+		t.Fatalf("設定の読み込みに失敗しました: %v", err)
+	}
+	// テストケースごとに全てのデータを削除するため、テスト用のデータベース以外では実行しない
+	if cfg.Profile != config.ProfileTest || cfg.Database.Driver != string(rdb.DriverMySQL) {
+		t.Skip("APP_ENV=testのMySQLのデータベース（rdb-test）でのみ実行する")
+	}
+
+	db, err := rdb.Open(cfg.Database.RDB())
+	if err != nil {
+		t.Fatalf("データベースの接続に失敗しました: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	migrate(t, db, migrations.FS)
+
+	contract.Run(t, func(t *testing.T) contract.Repositories {
+		// ユーザーを参照する行は外部キーの制約により連鎖して削除される
+		for _, table := range []string{"users", "ranking_snapshots", "outbox", "job_runs"} {
+			if _, err := db.Writer(context.Background()).ExecContext(context.Background(), "DELETE FROM "+table); err != nil {
+				t.Fatalf("データの削除に失敗しました: %v", err)
+			}
+		}
+		return repositories(db)
+	})
+}
+
+// migrate : 未適用のマイグレーションを全て適用
+func migrate(t *testing.T, db *rdb.DB, fsys fs.FS) {
+	t.Helper()
+
+	files, err := migration.Load(fsys)
+	if err != nil {
+		t.Fatalf("マイグレーションファイルの読み込みに失敗しました: %v", err)
+	}
+	if _, err := migration.NewMigrator(db, files).Up(context.Background(), 0); err != nil {
+		t.Fatalf("マイグレーションの適用に失敗しました: %v", err)
+	}
+}
+
+// repositories : DAOによるリポジトリの生成
+func repositories(db *rdb.DB) contract.Repositories {
+	return contract.Repositories{
+		User:                   dao.NewUserRepository(db),
+		Blog:                   dao.NewBlogRepository(db),
+		Comment:                dao.NewCommentRepository(db),
+		Mention:                dao.NewMentionRepository(db),
+		Notification:           dao.NewNotificationRepository(db),
+		NotificationPreference: dao.NewNotificationPreferenceRepository(db),
+		Reaction:               dao.NewReactionRepository(db),
+		Like:                   dao.NewLikeRepository(db),
+		BlogView:               dao.NewBlogViewRepository(db),
+		Ranking:                dao.NewRankingRepository(db),
+		Outbox:                 dao.NewOutboxRepository(db),
+		JobRun:                 dao.NewJobRunRepository(db),
+		BlogStats:              query.NewBlogStats(db),
+		RankingList:            query.NewRankingList(db),
+	}
+}
@@ -0,0 +1,32 @@
+package dao
+
+import (
+	"strings"
+
+	"myblog/app/infra/db/rdb"
+)
+
+// insertIgnore : 一意制約に違反する行を挿入せずに無視するINSERT文の先頭
+func insertIgnore(db *rdb.DB) string {
+	if db.Driver() == rdb.DriverSQLite {
+		return "INSERT OR IGNORE"
+	}
+	return "INSERT IGNORE"
+}
+
+// onDuplicateKeyUpdate : 一意制約（keys）に違反する場合に既存の行を更新する句（続けて「列 = 式」を指定する）
+// 式で挿入しようとした値を参照する場合はinsertedValueを使用する
+func onDuplicateKeyUpdate(db *rdb.DB, keys ...string) string {
+	if db.Driver() == rdb.DriverSQLite {
+		return "ON CONFLICT (" + strings.Join(keys, ", ") + ") DO UPDATE SET"
+	}
+	return "ON DUPLICATE KEY UPDATE"
+}
+
+// insertedValue : onDuplicateKeyUpdateの更新の式で挿入しようとした列の値を参照する
+func insertedValue(db *rdb.DB, column string) string {
+	if db.Driver() == rdb.DriverSQLite {
+		return "excluded." + column
+	}
+	return "VALUES(" + column + ")"
+}
@@ -0,0 +1,169 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/jobrun"
+	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
+)
+
+// jobRunDTO : ジョブ実行のデータ転送オブジェクト
+type jobRunDTO struct {
+	ID            string         `db:"id"`
+	JobName       string         `db:"job_name"`
+	Params        sql.NullString `db:"params"`
+	ScheduledAt   sql.NullTime   `db:"scheduled_at"`
+	Status        string         `db:"status"`
+	RowsProcessed int64          `db:"rows_processed"`
+	Attempts      int            `db:"attempts"`
+	ErrorMessage  sql.NullString `db:"error_message"`
+	StartedAt     time.Time      `db:"started_at"`
+	FinishedAt    sql.NullTime   `db:"finished_at"`
+}
+
+// toModel : DTOからドメインモデルへの変換
+func (dto *jobRunDTO) toModel() (*jobrun.JobRun, error) {
+	var scheduledAt *time.Time
+	if dto.ScheduledAt.Valid {
+		scheduledAtValue := dto.ScheduledAt.Time
+		scheduledAt = &scheduledAtValue
+	}
+
+	var finishedAt *time.Time
+	if dto.FinishedAt.Valid {
+		finishedAtValue := dto.FinishedAt.Time
+		finishedAt = &finishedAtValue
+	}
+
+	return jobrun.Reconstruct(
+		dto.ID,
+		dto.JobName,
+		dto.Params.String,
+		scheduledAt,
+		jobrun.Status(dto.Status),
+		dto.RowsProcessed,
+		dto.Attempts,
+		dto.ErrorMessage.String,
+		dto.StartedAt,
+		finishedAt,
+	)
+}
+
+// JobRunRepository : ジョブ実行リポジトリの実装
+type JobRunRepository struct {
+	db *rdb.DB
+}
+
+// NewJobRunRepository : JobRunRepositoryの生成
+func NewJobRunRepository(db *rdb.DB) repository.JobRun {
+	return &JobRunRepository{db: db}
+}
+
+// Create : ジョブ実行の作成（同じジョブの同じ予定実行日時の実行が既にある場合はfalse）
+func (r *JobRunRepository) Create(ctx context.Context, run *jobrun.JobRun) (bool, error) {
+	query := fmt.Sprintf(`
+		%s INTO job_runs (
+			id, job_name, params, scheduled_at, status, rows_processed, attempts, error_message, started_at, finished_at
+		) VALUES (
+			?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+		)
+	`, insertIgnore(r.db))
+
+	args := []interface{}{
+		run.ID().String(),
+		run.JobName(),
+		nullString(run.Params()),
+		nullTime(run.ScheduledAt()),
+		string(run.Status()),
+		run.RowsProcessed(),
+		run.Attempts(),
+		nullString(run.ErrorMessage()),
+		run.StartedAt(),
+		nullTime(run.FinishedAt()),
+	}
+
+	var result sql.Result
+	var err error
+
+	result, err = r.db.Writer(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Update : ジョブ実行の状態の更新
+func (r *JobRunRepository) Update(ctx context.Context, run *jobrun.JobRun) error {
+	query := `
+		UPDATE job_runs
+		SET status = ?, rows_processed = ?, attempts = ?, error_message = ?, finished_at = ?
+		WHERE id = ?
+	`
+
+	args := []interface{}{
+		string(run.Status()),
+		run.RowsProcessed(),
+		run.Attempts(),
+		nullString(run.ErrorMessage()),
+		nullTime(run.FinishedAt()),
+		run.ID().String(),
+	}
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, args...)
+	return err
+}
+
+// FindRecent : ジョブ実行の検索（新しい順。jobNameが空の場合は全てのジョブ）
+func (r *JobRunRepository) FindRecent(ctx context.Context, jobName string, limit int) ([]*jobrun.JobRun, error) {
+	query := `
+		SELECT
+			id, job_name, params, scheduled_at, status, rows_processed, attempts, error_message, started_at, finished_at
+		FROM
+			job_runs
+		WHERE
+			? = '' OR job_name = ?
+		ORDER BY
+			started_at DESC
+		LIMIT ?
+	`
+
+	var dtos []jobRunDTO
+
+	err := r.db.Reader(ctx).SelectContext(ctx, &dtos, query, jobName, jobName, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*jobrun.JobRun, len(dtos))
+	for i, dto := range dtos {
+		run, err := dto.toModel()
+		if err != nil {
+			return nil, err
+		}
+		runs[i] = run
+	}
+
+	return runs, nil
+}
+
+// nullString : 空文字列をNULLとして扱う
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// nullTime : nilをNULLとして扱う
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *value, Valid: true}
+}
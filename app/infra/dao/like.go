@@ -0,0 +1,140 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/like"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// LikeRepository : いいねリポジトリの実装
+type LikeRepository struct {
+	db *rdb.DB
+}
+
+// NewLikeRepository : LikeRepositoryの生成
+func NewLikeRepository(db *rdb.DB) repository.Like {
+	return &LikeRepository{db: db}
+}
+
+// Save : いいねの保存（いいね済みの場合は何もしない）
+func (r *LikeRepository) Save(ctx context.Context, like *like.Like) error {
+	query := fmt.Sprintf(`
+		%s INTO blog_likes (
+			blog_id, user_id, created_at
+		) VALUES (
+			:blog_id, :user_id, :created_at
+		)
+	`, insertIgnore(r.db))
+
+	params := map[string]interface{}{
+		"blog_id":    like.BlogID().String(),
+		"user_id":    like.UserID().String(),
+		"created_at": like.CreatedAt(),
+	}
+
+	_, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
+	return err
+}
+
+// Delete : いいねの削除（存在しない場合も成功とする）
+func (r *LikeRepository) Delete(ctx context.Context, blogID blog.ID, userID user.ID) error {
+	query := `
+		DELETE FROM blog_likes
+		WHERE blog_id = ? AND user_id = ?
+	`
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, blogID.String(), userID.String())
+	return err
+}
+
+// likeCountDTO : いいね数のデータ転送オブジェクト
+type likeCountDTO struct {
+	BlogID string `db:"blog_id"`
+	Count  int    `db:"count"`
+}
+
+// CountByBlogIDs : ブログIDごとのいいね数の取得
+func (r *LikeRepository) CountByBlogIDs(ctx context.Context, blogIDs []blog.ID) (map[string]int, error) {
+	result := make(map[string]int)
+	if len(blogIDs) == 0 {
+		return result, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT
+			blog_id, COUNT(*) AS count
+		FROM
+			blog_likes
+		WHERE
+			blog_id IN (?)
+		GROUP BY
+			blog_id
+	`, blogIDStrings(blogIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	var dtos []likeCountDTO
+
+	db := r.db.Reader(ctx)
+	err = db.SelectContext(ctx, &dtos, db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		result[dto.BlogID] = dto.Count
+	}
+
+	return result, nil
+}
+
+// FindLikedBlogIDs : 指定したブログのうちユーザーがいいね済みのブログIDの取得
+func (r *LikeRepository) FindLikedBlogIDs(ctx context.Context, userID user.ID, blogIDs []blog.ID) (map[string]bool, error) {
+	result := make(map[string]bool)
+	if len(blogIDs) == 0 {
+		return result, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT
+			blog_id
+		FROM
+			blog_likes
+		WHERE
+			user_id = ? AND blog_id IN (?)
+	`, userID.String(), blogIDStrings(blogIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	db := r.db.Reader(ctx)
+	err = db.SelectContext(ctx, &ids, db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		result[id] = true
+	}
+
+	return result, nil
+}
+
+// blogIDStrings : ブログIDを文字列のスライスに変換
+func blogIDStrings(blogIDs []blog.ID) []string {
+	ids := make([]string, len(blogIDs))
+	for i, id := range blogIDs {
+		ids[i] = id.String()
+	}
+	return ids
+}
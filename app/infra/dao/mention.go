@@ -0,0 +1,187 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/mention"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// mentionDTO : メンションのデータ転送オブジェクト
+type mentionDTO struct {
+	ID        string         `db:"id"`
+	BlogID    string         `db:"blog_id"`
+	CommentID sql.NullString `db:"comment_id"`
+	UserID    string         `db:"user_id"`
+	Username  string         `db:"username"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+// toModel : DTOからドメインモデルへの変換
+func (dto *mentionDTO) toModel() (*mention.Mention, error) {
+	blogID, err := blog.NewID(dto.BlogID)
+	if err != nil {
+		return nil, err
+	}
+
+	var commentID *comment.ID
+	if dto.CommentID.Valid {
+		commentID, err = comment.NewID(dto.CommentID.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	userID, err := user.NewID(dto.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mention.Reconstruct(
+		dto.ID,
+		*blogID,
+		commentID,
+		*userID,
+		dto.Username,
+		dto.CreatedAt,
+	)
+}
+
+// MentionRepository : メンションリポジトリの実装
+type MentionRepository struct {
+	db *rdb.DB
+}
+
+// NewMentionRepository : MentionRepositoryの生成
+func NewMentionRepository(db *rdb.DB) repository.Mention {
+	return &MentionRepository{db: db}
+}
+
+// SaveAll : メンションの一括保存
+func (r *MentionRepository) SaveAll(ctx context.Context, mentions []*mention.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO mentions (
+			id, blog_id, comment_id, user_id, username, created_at
+		) VALUES (
+			:id, :blog_id, :comment_id, :user_id, :username, :created_at
+		)
+	`
+
+	params := make([]map[string]interface{}, len(mentions))
+	for i, m := range mentions {
+		var commentID sql.NullString
+		if m.CommentID() != nil {
+			commentID = sql.NullString{String: m.CommentID().String(), Valid: true}
+		}
+
+		params[i] = map[string]interface{}{
+			"id":         m.ID().String(),
+			"blog_id":    m.BlogID().String(),
+			"comment_id": commentID,
+			"user_id":    m.UserID().String(),
+			"username":   m.Username(),
+			"created_at": m.CreatedAt(),
+		}
+	}
+
+	_, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
+	return err
+}
+
+// FindByBlogIDs : ブログ本文内のメンション検索
+func (r *MentionRepository) FindByBlogIDs(ctx context.Context, blogIDs []blog.ID) ([]*mention.Mention, error) {
+	return r.findIn(ctx, `
+		SELECT
+			id, blog_id, comment_id, user_id, username, created_at
+		FROM
+			mentions
+		WHERE
+			blog_id IN (?) AND comment_id IS NULL
+		ORDER BY
+			created_at ASC
+	`, blogIDStrings(blogIDs))
+}
+
+// FindByCommentIDs : コメント内のメンション検索
+func (r *MentionRepository) FindByCommentIDs(ctx context.Context, commentIDs []comment.ID) ([]*mention.Mention, error) {
+	ids := make([]string, len(commentIDs))
+	for i, id := range commentIDs {
+		ids[i] = id.String()
+	}
+
+	return r.findIn(ctx, `
+		SELECT
+			id, blog_id, comment_id, user_id, username, created_at
+		FROM
+			mentions
+		WHERE
+			comment_id IN (?)
+		ORDER BY
+			created_at ASC
+	`, ids)
+}
+
+// findIn : IN句を含むクエリでメンションを検索
+func (r *MentionRepository) findIn(ctx context.Context, query string, ids []string) ([]*mention.Mention, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(query, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var dtos []mentionDTO
+
+	db := r.db.Reader(ctx)
+	err = db.SelectContext(ctx, &dtos, db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions := make([]*mention.Mention, len(dtos))
+	for i, dto := range dtos {
+		mention, err := dto.toModel()
+		if err != nil {
+			return nil, err
+		}
+		mentions[i] = mention
+	}
+
+	return mentions, nil
+}
+
+// DeleteByBlogID : ブログ本文内のメンションの削除
+func (r *MentionRepository) DeleteByBlogID(ctx context.Context, blogID blog.ID) error {
+	query := `
+		DELETE FROM mentions
+		WHERE blog_id = ? AND comment_id IS NULL
+	`
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, blogID.String())
+	return err
+}
+
+// DeleteByCommentID : コメント内のメンションの削除
+func (r *MentionRepository) DeleteByCommentID(ctx context.Context, commentID comment.ID) error {
+	query := `
+		DELETE FROM mentions
+		WHERE comment_id = ?
+	`
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, commentID.String())
+	return err
+}
@@ -0,0 +1,218 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/notification"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// notificationDTO : 通知のデータ転送オブジェクト
+type notificationDTO struct {
+	ID        string         `db:"id"`
+	UserID    string         `db:"user_id"`
+	ActorID   string         `db:"actor_id"`
+	Type      string         `db:"type"`
+	BlogID    string         `db:"blog_id"`
+	CommentID sql.NullString `db:"comment_id"`
+	ReadAt    sql.NullTime   `db:"read_at"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+// toModel : DTOからドメインモデルへの変換
+func (dto *notificationDTO) toModel() (*notification.Notification, error) {
+	userID, err := user.NewID(dto.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID, err := user.NewID(dto.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	blogID, err := blog.NewID(dto.BlogID)
+	if err != nil {
+		return nil, err
+	}
+
+	var commentID *comment.ID
+	if dto.CommentID.Valid {
+		commentID, err = comment.NewID(dto.CommentID.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var readAt *time.Time
+	if dto.ReadAt.Valid {
+		readAtValue := dto.ReadAt.Time
+		readAt = &readAtValue
+	}
+
+	return notification.Reconstruct(
+		dto.ID,
+		*userID,
+		*actorID,
+		notification.Type(dto.Type),
+		*blogID,
+		commentID,
+		readAt,
+		dto.CreatedAt,
+	)
+}
+
+// NotificationRepository : 通知リポジトリの実装
+type NotificationRepository struct {
+	db *rdb.DB
+}
+
+// NewNotificationRepository : NotificationRepositoryの生成
+func NewNotificationRepository(db *rdb.DB) repository.Notification {
+	return &NotificationRepository{db: db}
+}
+
+// SaveAll : 通知の一括保存
+func (r *NotificationRepository) SaveAll(ctx context.Context, notifications []*notification.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO notifications (
+			id, user_id, actor_id, type, blog_id, comment_id, read_at, created_at
+		) VALUES (
+			:id, :user_id, :actor_id, :type, :blog_id, :comment_id, :read_at, :created_at
+		)
+	`
+
+	params := make([]map[string]interface{}, len(notifications))
+	for i, n := range notifications {
+		var commentID sql.NullString
+		if n.CommentID() != nil {
+			commentID = sql.NullString{String: n.CommentID().String(), Valid: true}
+		}
+
+		var readAt sql.NullTime
+		if n.ReadAt() != nil {
+			readAt = sql.NullTime{Time: *n.ReadAt(), Valid: true}
+		}
+
+		params[i] = map[string]interface{}{
+			"id":         n.ID().String(),
+			"user_id":    n.UserID().String(),
+			"actor_id":   n.ActorID().String(),
+			"type":       string(n.Type()),
+			"blog_id":    n.BlogID().String(),
+			"comment_id": commentID,
+			"read_at":    readAt,
+			"created_at": n.CreatedAt(),
+		}
+	}
+
+	_, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
+	return err
+}
+
+// FindByUserID : ユーザーIDによる通知検索（新しい順。cursorが指定された場合はその通知より古い通知）
+func (r *NotificationRepository) FindByUserID(ctx context.Context, userID user.ID, cursor *notification.Cursor, unreadOnly bool, limit int) ([]*notification.Notification, error) {
+	query := `
+		SELECT
+			id, user_id, actor_id, type, blog_id, comment_id, read_at, created_at
+		FROM
+			notifications
+		WHERE
+			user_id = ?
+	`
+	args := []interface{}{userID.String()}
+
+	if cursor != nil {
+		query += ` AND (created_at < ? OR (created_at = ? AND id < ?))`
+		args = append(args, cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+	if unreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+
+	query += `
+		ORDER BY
+			created_at DESC, id DESC
+		LIMIT ?
+	`
+	args = append(args, limit)
+
+	var dtos []notificationDTO
+
+	err := r.db.Reader(ctx).SelectContext(ctx, &dtos, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*notification.Notification, len(dtos))
+	for i, dto := range dtos {
+		notification, err := dto.toModel()
+		if err != nil {
+			return nil, err
+		}
+		notifications[i] = notification
+	}
+
+	return notifications, nil
+}
+
+// CountUnread : ユーザーの未読の通知の件数
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID user.ID) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM notifications WHERE user_id = ? AND read_at IS NULL
+	`
+
+	var count int
+
+	err := r.db.Reader(ctx).GetContext(ctx, &count, query, userID.String())
+	return count, err
+}
+
+// MarkRead : ユーザーの通知の既読化（既読の通知と他のユーザーの通知は変更しない）
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID user.ID, ids []notification.ID, readAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = id.String()
+	}
+
+	query, args, err := sqlx.In(`
+		UPDATE notifications
+		SET read_at = ?
+		WHERE user_id = ? AND read_at IS NULL AND id IN (?)
+	`, readAt, userID.String(), idStrings)
+	if err != nil {
+		return err
+	}
+
+	db := r.db.Writer(ctx)
+	_, err = db.ExecContext(ctx, db.Rebind(query), args...)
+	return err
+}
+
+// MarkAllRead : ユーザーの全ての未読の通知の既読化
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID user.ID, readAt time.Time) error {
+	query := `
+		UPDATE notifications
+		SET read_at = ?
+		WHERE user_id = ? AND read_at IS NULL
+	`
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, readAt, userID.String())
+	return err
+}
@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"myblog/app/domain/model/notification"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// notificationPreferenceDTO : 通知設定のデータ転送オブジェクト
+type notificationPreferenceDTO struct {
+	UserID  string `db:"user_id"`
+	Type    string `db:"type"`
+	Enabled bool   `db:"enabled"`
+}
+
+// NotificationPreferenceRepository : 通知設定リポジトリの実装
+type NotificationPreferenceRepository struct {
+	db *rdb.DB
+}
+
+// NewNotificationPreferenceRepository : NotificationPreferenceRepositoryの生成
+func NewNotificationPreferenceRepository(db *rdb.DB) repository.NotificationPreference {
+	return &NotificationPreferenceRepository{db: db}
+}
+
+// FindByUserIDs : ユーザーごとの通知設定の検索（設定していないユーザーは全ての通知を受け取る設定を返す）
+func (r *NotificationPreferenceRepository) FindByUserIDs(ctx context.Context, userIDs []user.ID) (map[string]*notification.Preferences, error) {
+	result := make(map[string]*notification.Preferences, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+		result[id.String()] = notification.NewPreferences(id)
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT
+			user_id, type, enabled
+		FROM
+			notification_preferences
+		WHERE
+			user_id IN (?)
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var dtos []notificationPreferenceDTO
+
+	db := r.db.Reader(ctx)
+	err = db.SelectContext(ctx, &dtos, db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		preferences, ok := result[dto.UserID]
+		if !ok {
+			continue
+		}
+		// 廃止された通知種別の設定は無視する
+		t, err := notification.ParseType(dto.Type)
+		if err != nil {
+			continue
+		}
+		if err := preferences.Set(t, dto.Enabled); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// Save : 通知設定の保存（全ての通知種別の設定を保存する）
+func (r *NotificationPreferenceRepository) Save(ctx context.Context, preferences *notification.Preferences) error {
+	query := fmt.Sprintf(`
+		INSERT INTO notification_preferences (
+			user_id, type, enabled
+		) VALUES (
+			:user_id, :type, :enabled
+		)
+		%s enabled = %s
+	`, onDuplicateKeyUpdate(r.db, "user_id", "type"), insertedValue(r.db, "enabled"))
+
+	var params []map[string]interface{}
+	for t, enabled := range preferences.All() {
+		params = append(params, map[string]interface{}{
+			"user_id": preferences.UserID().String(),
+			"type":    string(t),
+			"enabled": enabled,
+		})
+	}
+
+	_, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
+	return err
+}
@@ -0,0 +1,168 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/event"
+	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
+)
+
+// outboxDTO : アウトボックスのデータ転送オブジェクト
+type outboxDTO struct {
+	ID             string       `db:"id"`
+	EventType      string       `db:"event_type"`
+	AggregateID    string       `db:"aggregate_id"`
+	Payload        []byte       `db:"payload"`
+	IdempotencyKey string       `db:"idempotency_key"`
+	OccurredAt     time.Time    `db:"occurred_at"`
+	PublishedAt    sql.NullTime `db:"published_at"`
+	Attempts       int          `db:"attempts"`
+}
+
+// toModel : DTOからドメインモデルへの変換
+func (dto *outboxDTO) toModel() (*event.Event, error) {
+	var publishedAt *time.Time
+	if dto.PublishedAt.Valid {
+		publishedAtValue := dto.PublishedAt.Time
+		publishedAt = &publishedAtValue
+	}
+
+	return event.Reconstruct(
+		dto.ID,
+		event.Type(dto.EventType),
+		dto.AggregateID,
+		dto.Payload,
+		dto.IdempotencyKey,
+		dto.OccurredAt,
+		publishedAt,
+		dto.Attempts,
+	)
+}
+
+// OutboxRepository : アウトボックスリポジトリの実装
+type OutboxRepository struct {
+	db *rdb.DB
+}
+
+// NewOutboxRepository : OutboxRepositoryの生成
+func NewOutboxRepository(db *rdb.DB) repository.Outbox {
+	return &OutboxRepository{db: db}
+}
+
+// Save : イベントの保存（同じ冪等キーのイベントが既にある場合は保存しない）
+// 状態の変更と同じトランザクションで記録するため、トランザクション内で呼び出すこと
+func (r *OutboxRepository) Save(ctx context.Context, events ...*event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(`
+		%s INTO outbox (
+			id, event_type, aggregate_id, payload, idempotency_key, occurred_at, attempts, next_attempt_at
+		) VALUES (
+			:id, :event_type, :aggregate_id, :payload, :idempotency_key, :occurred_at, :attempts, :next_attempt_at
+		)
+	`, insertIgnore(r.db))
+
+	rows := make([]map[string]interface{}, len(events))
+	for i, e := range events {
+		rows[i] = map[string]interface{}{
+			"id":              e.ID().String(),
+			"event_type":      string(e.Type()),
+			"aggregate_id":    e.AggregateID(),
+			"payload":         e.Payload(),
+			"idempotency_key": e.IdempotencyKey(),
+			"occurred_at":     e.OccurredAt(),
+			"attempts":        e.Attempts(),
+			"next_attempt_at": e.OccurredAt(),
+		}
+	}
+
+	_, err := r.db.Writer(ctx).NamedExecContext(ctx, query, rows)
+	return err
+}
+
+// FindPending : 配信予定日時を過ぎた未配信のイベントの検索（発生順）
+func (r *OutboxRepository) FindPending(ctx context.Context, now time.Time, limit int) ([]*event.Event, error) {
+	query := `
+		SELECT
+			id, event_type, aggregate_id, payload, idempotency_key, occurred_at, published_at, attempts
+		FROM
+			outbox
+		WHERE
+			published_at IS NULL AND next_attempt_at <= ?
+		ORDER BY
+			occurred_at ASC
+		LIMIT ?
+	`
+
+	var dtos []outboxDTO
+
+	err := r.db.Writer(ctx).SelectContext(ctx, &dtos, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*event.Event, len(dtos))
+	for i, dto := range dtos {
+		e, err := dto.toModel()
+		if err != nil {
+			return nil, err
+		}
+		events[i] = e
+	}
+
+	return events, nil
+}
+
+// MarkPublished : 全ての購読者への配信が完了したイベントの記録
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id event.ID, publishedAt time.Time) error {
+	query := `
+		UPDATE outbox
+		SET published_at = ?, attempts = attempts + 1, last_error = NULL
+		WHERE id = ?
+	`
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, publishedAt, id.String())
+	return err
+}
+
+// MarkFailed : 配信に失敗したイベントの記録（nextAttemptAt以降に再配信する）
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id event.ID, errorMessage string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, errorMessage, nextAttemptAt, id.String())
+	return err
+}
+
+// FindDeliveredSubscribers : イベントの配信が完了した購読者名の検索
+func (r *OutboxRepository) FindDeliveredSubscribers(ctx context.Context, id event.ID) ([]string, error) {
+	query := `
+		SELECT subscriber FROM outbox_deliveries WHERE event_id = ?
+	`
+
+	var subscribers []string
+
+	if err := r.db.Writer(ctx).SelectContext(ctx, &subscribers, query, id.String()); err != nil {
+		return nil, err
+	}
+	return subscribers, nil
+}
+
+// SaveDelivery : 購読者へのイベントの配信完了の記録（既に記録されている場合は何もしない）
+func (r *OutboxRepository) SaveDelivery(ctx context.Context, id event.ID, subscriber string, deliveredAt time.Time) error {
+	query := fmt.Sprintf(`
+		%s INTO outbox_deliveries (event_id, subscriber, delivered_at) VALUES (?, ?, ?)
+	`, insertIgnore(r.db))
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, id.String(), subscriber, deliveredAt)
+	return err
+}
@@ -21,34 +21,104 @@ func NewRankingRepository(db *rdb.DB) repository.RankingRepository {
 	}
 }
 
-// SaveRankings はランキングを保存する
-func (r *rankingRepository) SaveRankings(ctx context.Context, rankings []*ranking.Ranking) error {
-	tx, err := r.db.Begin()
-	if err != nil {
-		return err
-	}
+// saveRankingsBatchSize は1回のINSERT文で挿入するランキングの件数
+const saveRankingsBatchSize = 500
 
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-			return
+// CreateSnapshot は未公開のスナップショットを作成する
+func (r *rankingRepository) CreateSnapshot(ctx context.Context, snapshot *ranking.Snapshot) error {
+	query := "INSERT INTO ranking_snapshots (id, ranking_type, calculated_at, created_at) VALUES (?, ?, ?, ?)"
+	args := []interface{}{snapshot.ID, string(snapshot.Type), snapshot.CalculatedAt, time.Now()}
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, args...)
+	return err
+}
+
+// SaveRankings はスナップショットにランキングを追加する
+func (r *rankingRepository) SaveRankings(ctx context.Context, snapshot *ranking.Snapshot, rankings []*ranking.Ranking) error {
+	query := `
+		INSERT INTO ranking_entries (
+			snapshot_id, blog_id, ranking_position, score, created_at
+		) VALUES (
+			:snapshot_id, :blog_id, :ranking_position, :score, :created_at
+		)
+	`
+
+	now := time.Now()
+	params := make([]map[string]interface{}, len(rankings))
+	for i, rank := range rankings {
+		params[i] = map[string]interface{}{
+			"snapshot_id":      snapshot.ID,
+			"blog_id":          rank.BlogID.String(),
+			"ranking_position": rank.RankingPosition,
+			"score":            rank.Score,
+			"created_at":       now,
 		}
-		tx.Commit()
-	}()
+	}
 
-	// 既存のランキングを削除
-	_, err = tx.Exec("DELETE FROM rankings")
-	if err != nil {
-		return err
+	return r.insertBatches(ctx, query, params)
+}
+
+// SaveAuthorRankings はスナップショットに著者ランキングを追加する
+func (r *rankingRepository) SaveAuthorRankings(ctx context.Context, snapshot *ranking.Snapshot, rankings []*ranking.AuthorRanking) error {
+	query := `
+		INSERT INTO ranking_author_entries (
+			snapshot_id, user_id, ranking_position, score, blog_count, created_at
+		) VALUES (
+			:snapshot_id, :user_id, :ranking_position, :score, :blog_count, :created_at
+		)
+	`
+
+	now := time.Now()
+	params := make([]map[string]interface{}, len(rankings))
+	for i, rank := range rankings {
+		params[i] = map[string]interface{}{
+			"snapshot_id":      snapshot.ID,
+			"user_id":          rank.UserID.String(),
+			"ranking_position": rank.RankingPosition,
+			"score":            rank.Score,
+			"blog_count":       rank.BlogCount,
+			"created_at":       now,
+		}
 	}
 
-	// 新しいランキングを挿入
-	for _, rank := range rankings {
-		_, err = tx.Exec(
-			"INSERT INTO rankings (blog_id, ranking_position, score, created_at, updated_at) VALUES (?, ?, ?, ?, ?)",
-			rank.BlogID.String(), rank.RankingPosition, rank.Score, time.Now(), time.Now(),
+	return r.insertBatches(ctx, query, params)
+}
+
+// SaveAuthorBlogRankings はスナップショットに著者ごとの人気記事ランキングを追加する
+func (r *rankingRepository) SaveAuthorBlogRankings(ctx context.Context, snapshot *ranking.Snapshot, rankings []*ranking.AuthorBlogRanking) error {
+	query := `
+		INSERT INTO ranking_author_blog_entries (
+			snapshot_id, user_id, blog_id, ranking_position, score, created_at
+		) VALUES (
+			:snapshot_id, :user_id, :blog_id, :ranking_position, :score, :created_at
 		)
-		if err != nil {
+	`
+
+	now := time.Now()
+	params := make([]map[string]interface{}, len(rankings))
+	for i, rank := range rankings {
+		params[i] = map[string]interface{}{
+			"snapshot_id":      snapshot.ID,
+			"user_id":          rank.UserID.String(),
+			"blog_id":          rank.BlogID.String(),
+			"ranking_position": rank.RankingPosition,
+			"score":            rank.Score,
+			"created_at":       now,
+		}
+	}
+
+	return r.insertBatches(ctx, query, params)
+}
+
+// insertBatches は複数行のINSERT文でsaveRankingsBatchSize件ずつ挿入する
+func (r *rankingRepository) insertBatches(ctx context.Context, query string, params []map[string]interface{}) error {
+	for start := 0; start < len(params); start += saveRankingsBatchSize {
+		end := start + saveRankingsBatchSize
+		if end > len(params) {
+			end = len(params)
+		}
+
+		if _, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params[start:end]); err != nil {
 			return err
 		}
 	}
@@ -56,11 +126,36 @@ func (r *rankingRepository) SaveRankings(ctx context.Context, rankings []*rankin
 	return nil
 }
 
-// GetRankings はランキングを取得する
-func (r *rankingRepository) GetRankings(ctx context.Context, limit int) ([]*ranking.Ranking, error) {
-	rows, err := r.db.Read(ctx).Query(
-		"SELECT blog_id, ranking_position, score, created_at, updated_at FROM rankings ORDER BY ranking_position ASC LIMIT ?",
-		limit,
+// PublishSnapshot はスナップショットを公開し、最新のランキングとして参照できるようにする
+func (r *rankingRepository) PublishSnapshot(ctx context.Context, snapshot *ranking.Snapshot) error {
+	query := "UPDATE ranking_snapshots SET published_at = ? WHERE id = ?"
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, time.Now(), snapshot.ID)
+	return err
+}
+
+// DeleteSnapshot はスナップショットを削除する（ランキングはカスケード削除される）
+func (r *rankingRepository) DeleteSnapshot(ctx context.Context, snapshot *ranking.Snapshot) error {
+	query := "DELETE FROM ranking_snapshots WHERE id = ?"
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query, snapshot.ID)
+	return err
+}
+
+// GetRankings は指定した種別の最新の公開済みランキングを取得する
+func (r *rankingRepository) GetRankings(ctx context.Context, rankingType ranking.Type, limit int) ([]*ranking.Ranking, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx,
+		`SELECT e.blog_id, e.ranking_position, e.score, s.calculated_at, s.calculated_at
+		FROM ranking_entries e
+		INNER JOIN (
+			SELECT id, calculated_at FROM ranking_snapshots
+			WHERE ranking_type = ? AND published_at IS NOT NULL
+			ORDER BY calculated_at DESC
+			LIMIT 1
+		) s ON s.id = e.snapshot_id
+		ORDER BY e.ranking_position ASC
+		LIMIT ?`,
+		string(rankingType), limit,
 	)
 	if err != nil {
 		return nil, err
@@ -94,3 +189,37 @@ func (r *rankingRepository) GetRankings(ctx context.Context, limit int) ([]*rank
 
 	return rankings, nil
 }
+
+// DeleteSnapshotsBefore は指定日時より前に集計されたスナップショットを削除する
+// 各種別の最新の公開済みスナップショットは削除しない（失敗した集計の未公開のスナップショットは削除する）
+func (r *rankingRepository) DeleteSnapshotsBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		DELETE s FROM ranking_snapshots s
+		LEFT JOIN (
+			SELECT ranking_type, MAX(calculated_at) AS latest
+			FROM ranking_snapshots
+			WHERE published_at IS NOT NULL
+			GROUP BY ranking_type
+		) l ON l.ranking_type = s.ranking_type AND l.latest = s.calculated_at AND s.published_at IS NOT NULL
+		WHERE s.calculated_at < ? AND l.latest IS NULL
+	`
+	if r.db.Driver() == rdb.DriverSQLite {
+		// SQLiteは複数テーブルを結合したDELETE文に対応していないため、相関サブクエリで最新のスナップショットを除く
+		query = `
+			DELETE FROM ranking_snapshots
+			WHERE calculated_at < ? AND NOT (
+				published_at IS NOT NULL AND calculated_at = (
+					SELECT MAX(l.calculated_at)
+					FROM ranking_snapshots l
+					WHERE l.ranking_type = ranking_snapshots.ranking_type AND l.published_at IS NOT NULL
+				)
+			)
+		`
+	}
+
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
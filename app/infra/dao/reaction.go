@@ -0,0 +1,187 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/reaction"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// reactionTable : リアクション対象ごとのテーブル名と対象IDのカラム名
+type reactionTable struct {
+	name   string
+	column string
+}
+
+// reactionTables : リアクション対象ごとのテーブル定義
+var reactionTables = map[reaction.TargetType]reactionTable{
+	reaction.TargetBlog:    {name: "blog_reactions", column: "blog_id"},
+	reaction.TargetComment: {name: "comment_reactions", column: "comment_id"},
+}
+
+// tableFor : リアクション対象に対応するテーブルを取得
+func tableFor(targetType reaction.TargetType) (reactionTable, error) {
+	table, ok := reactionTables[targetType]
+	if !ok {
+		return reactionTable{}, fmt.Errorf("unknown reaction target type: %s", targetType)
+	}
+	return table, nil
+}
+
+// ReactionRepository : リアクションリポジトリの実装
+type ReactionRepository struct {
+	db *rdb.DB
+}
+
+// NewReactionRepository : ReactionRepositoryの生成
+func NewReactionRepository(db *rdb.DB) repository.Reaction {
+	return &ReactionRepository{db: db}
+}
+
+// Save : リアクションの保存（既存のリアクションがあれば絵文字を置き換える）
+func (r *ReactionRepository) Save(ctx context.Context, reaction *reaction.Reaction) error {
+	table, err := tableFor(reaction.TargetType())
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (
+			%s, user_id, emoji, created_at, updated_at
+		) VALUES (
+			:target_id, :user_id, :emoji, :created_at, :updated_at
+		)
+		%[3]s
+			emoji = %[4]s,
+			updated_at = %[5]s
+	`, table.name, table.column, onDuplicateKeyUpdate(r.db, table.column, "user_id"), insertedValue(r.db, "emoji"), insertedValue(r.db, "updated_at"))
+
+	params := map[string]interface{}{
+		"target_id":  reaction.TargetID(),
+		"user_id":    reaction.UserID().String(),
+		"emoji":      reaction.Emoji(),
+		"created_at": reaction.CreatedAt(),
+		"updated_at": time.Now(),
+	}
+
+	_, err = r.db.Writer(ctx).NamedExecContext(ctx, query, params)
+	return err
+}
+
+// Delete : リアクションの削除（存在しない場合も成功とする）
+func (r *ReactionRepository) Delete(ctx context.Context, userID user.ID, targetType reaction.TargetType, targetID string) error {
+	table, err := tableFor(targetType)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE %s = ? AND user_id = ?
+	`, table.name, table.column)
+
+	_, err = r.db.Writer(ctx).ExecContext(ctx, query, targetID, userID.String())
+	return err
+}
+
+// reactionCountDTO : リアクション件数のデータ転送オブジェクト
+type reactionCountDTO struct {
+	TargetID string `db:"target_id"`
+	Emoji    string `db:"emoji"`
+	Count    int    `db:"count"`
+}
+
+// CountByTargetIDs : 対象IDごと・絵文字ごとのリアクション件数の取得
+func (r *ReactionRepository) CountByTargetIDs(ctx context.Context, targetType reaction.TargetType, targetIDs []string) (map[string]map[string]int, error) {
+	result := make(map[string]map[string]int)
+	if len(targetIDs) == 0 {
+		return result, nil
+	}
+
+	table, err := tableFor(targetType)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sqlx.In(fmt.Sprintf(`
+		SELECT
+			%[2]s AS target_id, emoji, COUNT(*) AS count
+		FROM
+			%[1]s
+		WHERE
+			%[2]s IN (?)
+		GROUP BY
+			%[2]s, emoji
+	`, table.name, table.column), targetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var dtos []reactionCountDTO
+
+	db := r.db.Reader(ctx)
+	err = db.SelectContext(ctx, &dtos, db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		if result[dto.TargetID] == nil {
+			result[dto.TargetID] = make(map[string]int)
+		}
+		result[dto.TargetID][dto.Emoji] = dto.Count
+	}
+
+	return result, nil
+}
+
+// reactionEmojiDTO : ユーザーのリアクションのデータ転送オブジェクト
+type reactionEmojiDTO struct {
+	TargetID string `db:"target_id"`
+	Emoji    string `db:"emoji"`
+}
+
+// FindEmojisByUserID : ユーザーの対象IDごとのリアクションの取得
+func (r *ReactionRepository) FindEmojisByUserID(ctx context.Context, userID user.ID, targetType reaction.TargetType, targetIDs []string) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(targetIDs) == 0 {
+		return result, nil
+	}
+
+	table, err := tableFor(targetType)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sqlx.In(fmt.Sprintf(`
+		SELECT
+			%[2]s AS target_id, emoji
+		FROM
+			%[1]s
+		WHERE
+			user_id = ? AND %[2]s IN (?)
+	`, table.name, table.column), userID.String(), targetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var dtos []reactionEmojiDTO
+
+	db := r.db.Reader(ctx)
+	err = db.SelectContext(ctx, &dtos, db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		result[dto.TargetID] = dto.Emoji
+	}
+
+	return result, nil
+}
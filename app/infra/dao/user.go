@@ -10,6 +10,8 @@ import (
 	"myblog/app/domain/model/user"
 	"myblog/app/domain/repository"
 	"myblog/app/infra/db/rdb"
+
+	"github.com/jmoiron/sqlx"
 )
 
 // userDTO : ユーザーのデータ転送オブジェクト
@@ -63,13 +65,7 @@ func (r *UserRepository) Save(ctx context.Context, user *user.User) error {
 		"updated_at": user.UpdatedAt(),
 	}
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		_, err := tx.NamedExec(query, params)
-		return err
-	}
-
-	_, err := r.db.Write(ctx).NamedExecContext(ctx, query, params)
+	_, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
 	return err
 }
 
@@ -86,19 +82,7 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*user.User, e
 
 	var dto userDTO
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		err := tx.QueryRowx(query, id).StructScan(&dto)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, fmt.Errorf("user not found with id: %s", id)
-			}
-			return nil, err
-		}
-		return dto.toModel()
-	}
-
-	err := r.db.Read(ctx).QueryRowxContext(ctx, query, id).StructScan(&dto)
+	err := r.db.Reader(ctx).QueryRowxContext(ctx, query, id).StructScan(&dto)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("user not found with id: %s", id)
@@ -122,19 +106,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.U
 
 	var dto userDTO
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		err := tx.QueryRowx(query, email).StructScan(&dto)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, fmt.Errorf("user not found with email: %s", email)
-			}
-			return nil, err
-		}
-		return dto.toModel()
-	}
-
-	err := r.db.Read(ctx).QueryRowxContext(ctx, query, email).StructScan(&dto)
+	err := r.db.Reader(ctx).QueryRowxContext(ctx, query, email).StructScan(&dto)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("user not found with email: %s", email)
@@ -145,6 +117,46 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.U
 	return dto.toModel()
 }
 
+// FindByUsernames : ユーザー名による複数ユーザー検索（作成日時の昇順）
+func (r *UserRepository) FindByUsernames(ctx context.Context, usernames []string) ([]*user.User, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT
+			id, username, email, password, created_at, updated_at
+		FROM
+			users
+		WHERE
+			username IN (?)
+		ORDER BY
+			created_at ASC
+	`, usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	var dtos []userDTO
+
+	db := r.db.Reader(ctx)
+	err = db.SelectContext(ctx, &dtos, db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*user.User, len(dtos))
+	for i, dto := range dtos {
+		user, err := dto.toModel()
+		if err != nil {
+			return nil, err
+		}
+		users[i] = user
+	}
+
+	return users, nil
+}
+
 // Update : ユーザーの更新
 func (r *UserRepository) Update(ctx context.Context, user *user.User) error {
 	query := `
@@ -166,26 +178,7 @@ func (r *UserRepository) Update(ctx context.Context, user *user.User) error {
 		"updated_at": time.Now(),
 	}
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		result, err := tx.NamedExec(query, params)
-		if err != nil {
-			return err
-		}
-
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			return err
-		}
-
-		if rowsAffected == 0 {
-			return fmt.Errorf("user not found with id: %s", user.ID().String())
-		}
-
-		return nil
-	}
-
-	result, err := r.db.Write(ctx).NamedExecContext(ctx, query, params)
+	result, err := r.db.Writer(ctx).NamedExecContext(ctx, query, params)
 	if err != nil {
 		return err
 	}
@@ -209,26 +202,7 @@ func (r *UserRepository) Delete(ctx context.Context, id string) error {
 		WHERE id = ?
 	`
 
-	// トランザクションがあれば使用
-	if tx, ok := rdb.GetTx(ctx); ok {
-		result, err := tx.Exec(query, id)
-		if err != nil {
-			return err
-		}
-
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			return err
-		}
-
-		if rowsAffected == 0 {
-			return fmt.Errorf("user not found with id: %s", id)
-		}
-
-		return nil
-	}
-
-	result, err := r.db.Write(ctx).ExecContext(ctx, query, id)
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,189 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// downDir : 取り消すSQLを配置するディレクトリ（適用するSQLと同じファイル名で配置する）
+const downDir = "down"
+
+// fileNamePattern : マイグレーションファイル名の形式（<バージョン>_<名前>.sql）
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.sql$`)
+
+// Migration : マイグレーション
+type Migration struct {
+	Version int64
+	Name    string
+	// Up : 適用するSQL
+	Up string
+	// Down : 取り消すSQL（取り消せないマイグレーションの場合は空）
+	Down string
+	// Checksum : 適用するSQLのSHA-256（適用後にファイルが変更されていないかの確認に使用）
+	Checksum string
+}
+
+// Reversible : 取り消せるかどうか
+func (m Migration) Reversible() bool {
+	return strings.TrimSpace(m.Down) != ""
+}
+
+// String : 表示用の文字列（<バージョン>_<名前>）
+func (m Migration) String() string {
+	return fmt.Sprintf("%d_%s", m.Version, m.Name)
+}
+
+// Load : マイグレーションファイルの読み込み（バージョンの昇順）
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("マイグレーションファイルの一覧の取得エラー: %w", err)
+	}
+
+	var migrations []Migration
+	versions := make(map[int64]string)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("マイグレーションファイル名は<バージョン>_<名前>.sqlの形式にしてください: %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("マイグレーションのバージョンが不正です: %s: %w", entry.Name(), err)
+		}
+		if other, ok := versions[version]; ok {
+			return nil, fmt.Errorf("マイグレーションのバージョンが重複しています: %s, %s", other, entry.Name())
+		}
+		versions[version] = entry.Name()
+
+		up, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("マイグレーションファイルの読み込みエラー: %w", err)
+		}
+		down, err := fs.ReadFile(fsys, path.Join(downDir, entry.Name()))
+		if err != nil && !isNotExist(err) {
+			return nil, fmt.Errorf("マイグレーションファイルの読み込みエラー: %w", err)
+		}
+
+		sum := sha256.Sum256(up)
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     matches[2],
+			Up:       string(up),
+			Down:     string(down),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	// 対応する適用するSQLがない取り消すSQLは、ファイル名の誤りの可能性が高いためエラーにする
+	downEntries, err := fs.ReadDir(fsys, downDir)
+	if err != nil && !isNotExist(err) {
+		return nil, fmt.Errorf("マイグレーションファイルの一覧の取得エラー: %w", err)
+	}
+	for _, entry := range downEntries {
+		if _, err := fs.Stat(fsys, entry.Name()); err != nil {
+			return nil, fmt.Errorf("取り消すSQLに対応するマイグレーションファイルがありません: %s", path.Join(downDir, entry.Name()))
+		}
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// isNotExist : ファイルが存在しないエラーかどうか
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// splitStatements : SQLを文ごとに分割する（コメントと空の文は除く）
+// ドライバーは1回の実行で複数の文を受け付けないため、セミコロンで区切って1文ずつ実行する
+// 文字列・識別子の引用符の中とコメントの中のセミコロンは区切りとして扱わない
+func splitStatements(sql string) []string {
+	var statements []string
+	var current strings.Builder
+
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// 引用符の終わりまで（バックスラッシュによるエスケープと引用符の重ね書きを考慮する）
+			end := i + 1
+			for end < len(sql) {
+				if sql[end] == '\\' && c != '`' {
+					end += 2
+					continue
+				}
+				if sql[end] == c {
+					if end+1 < len(sql) && sql[end+1] == c {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			if end >= len(sql) {
+				end = len(sql) - 1
+			}
+			current.WriteString(sql[i : end+1])
+			i = end
+		case isLineComment(sql[i:]):
+			// 行末までのコメント
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				i = len(sql)
+				continue
+			}
+			i += end
+			current.WriteByte('\n')
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+				continue
+			}
+			i += end + 3
+			current.WriteByte(' ')
+		case c == ';':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+
+	return statements
+}
+
+// isLineComment : 行末までのコメントの開始かどうか（MySQLでは「--」の後に空白が必要）
+func isLineComment(s string) bool {
+	if s[0] == '#' {
+		return true
+	}
+	if !strings.HasPrefix(s, "--") {
+		return false
+	}
+	return len(s) == 2 || strings.ContainsRune(" \t\r\n", rune(s[2]))
+}
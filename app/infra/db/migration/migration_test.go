@@ -0,0 +1,165 @@
+package migration
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{
+			name: "セミコロンで区切る",
+			sql:  "CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);\n",
+			want: []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"},
+		},
+		{
+			name: "最後の文にセミコロンがない",
+			sql:  "INSERT INTO a VALUES (1);\nINSERT INTO a VALUES (2)",
+			want: []string{"INSERT INTO a VALUES (1)", "INSERT INTO a VALUES (2)"},
+		},
+		{
+			name: "空の文は除く",
+			sql:  ";;\n  ;\nSELECT 1;;",
+			want: []string{"SELECT 1"},
+		},
+		{
+			name: "空のSQL",
+			sql:  "  \n\t",
+			want: nil,
+		},
+		{
+			name: "文字列の中のセミコロン",
+			sql:  "INSERT INTO a VALUES ('x;y'); INSERT INTO a VALUES (\"p;q\")",
+			want: []string{"INSERT INTO a VALUES ('x;y')", "INSERT INTO a VALUES (\"p;q\")"},
+		},
+		{
+			name: "識別子の中のセミコロン",
+			sql:  "CREATE TABLE `a;b` (id INT); SELECT 1",
+			want: []string{"CREATE TABLE `a;b` (id INT)", "SELECT 1"},
+		},
+		{
+			name: "バックスラッシュでエスケープした引用符",
+			sql:  `INSERT INTO a VALUES ('it\'s; fine'); SELECT 1`,
+			want: []string{`INSERT INTO a VALUES ('it\'s; fine')`, "SELECT 1"},
+		},
+		{
+			name: "重ねてエスケープした引用符",
+			sql:  "INSERT INTO a VALUES ('it''s; fine'); SELECT 1",
+			want: []string{"INSERT INTO a VALUES ('it''s; fine')", "SELECT 1"},
+		},
+		{
+			name: "文字列の中のコメントの記号",
+			sql:  "INSERT INTO a VALUES ('-- not a comment; /* nor this */'); SELECT 1",
+			want: []string{"INSERT INTO a VALUES ('-- not a comment; /* nor this */')", "SELECT 1"},
+		},
+		{
+			name: "行コメントの中のセミコロン",
+			sql:  "-- 作成; 削除\nCREATE TABLE a (id INT); # メモ; メモ\nSELECT 1",
+			want: []string{"CREATE TABLE a (id INT)", "SELECT 1"},
+		},
+		{
+			name: "空白のない--はコメントではない",
+			sql:  "SELECT 1--1; SELECT 2",
+			want: []string{"SELECT 1--1", "SELECT 2"},
+		},
+		{
+			name: "ブロックコメントの中のセミコロン",
+			sql:  "CREATE TABLE a (/* id; */ id INT); /* 次の文;\n */ SELECT 1",
+			want: []string{"CREATE TABLE a (  id INT)", "SELECT 1"},
+		},
+		{
+			name: "コメントのみ",
+			sql:  "-- コメント\n/* コメント; */\n# コメント",
+			want: nil,
+		},
+		{
+			name: "閉じていない引用符",
+			sql:  "SELECT 1; SELECT 'abc;",
+			want: []string{"SELECT 1", "SELECT 'abc;"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitStatements(tt.sql)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("分割結果が異なります:\ngot  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        fstest.MapFS
+		wantVersions []int64
+		wantErr      string
+	}{
+		{
+			name: "バージョンの昇順に読み込む",
+			files: fstest.MapFS{
+				"2_second.sql":      {Data: []byte("SELECT 2")},
+				"1_first.sql":       {Data: []byte("SELECT 1")},
+				"down/1_first.sql":  {Data: []byte("SELECT -1")},
+				"README.md":         {Data: []byte("ignored")},
+				"10_tenth.sql":      {Data: []byte("SELECT 10")},
+				"down/10_tenth.sql": {Data: []byte("SELECT -10")},
+			},
+			wantVersions: []int64{1, 2, 10},
+		},
+		{
+			name:    "ファイル名の形式が不正",
+			files:   fstest.MapFS{"first.sql": {Data: []byte("SELECT 1")}},
+			wantErr: "<バージョン>_<名前>.sql",
+		},
+		{
+			name: "バージョンの重複",
+			files: fstest.MapFS{
+				"1_first.sql": {Data: []byte("SELECT 1")},
+				"01_same.sql": {Data: []byte("SELECT 1")},
+			},
+			wantErr: "バージョンが重複しています",
+		},
+		{
+			name: "対応するファイルがない取り消すSQL",
+			files: fstest.MapFS{
+				"1_first.sql":     {Data: []byte("SELECT 1")},
+				"down/2_typo.sql": {Data: []byte("SELECT -2")},
+			},
+			wantErr: "対応するマイグレーションファイルがありません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.files)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("エラーが異なります: got %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("読み込みに失敗しました: %v", err)
+			}
+
+			var versions []int64
+			for _, migration := range migrations {
+				versions = append(versions, migration.Version)
+			}
+			if !reflect.DeepEqual(versions, tt.wantVersions) {
+				t.Errorf("バージョンが異なります: got %v, want %v", versions, tt.wantVersions)
+			}
+			if !migrations[0].Reversible() || migrations[1].Reversible() {
+				t.Errorf("取り消せるかどうかが異なります: %v, %v", migrations[0].Reversible(), migrations[1].Reversible())
+			}
+		})
+	}
+}
@@ -0,0 +1,523 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"myblog/app/infra/db/rdb"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrLocked : 他のプロセスがマイグレーションを実行中のためロックを取得できなかったことを示すエラー
+var ErrLocked = errors.New("他のプロセスがマイグレーションを実行中です")
+
+// ErrDrift : 適用済みのマイグレーションとマイグレーションファイルが一致しないことを示すエラー
+var ErrDrift = errors.New("適用済みのマイグレーションとマイグレーションファイルが一致しません")
+
+// ErrIrreversible : 取り消すSQLがないマイグレーションを取り消そうとしたことを示すエラー
+var ErrIrreversible = errors.New("取り消せないマイグレーションです")
+
+// DefaultLockTimeout : ロックの取得を待機する時間のデフォルト値
+const DefaultLockTimeout = time.Minute
+
+// createTableQuery : 適用済みのマイグレーションを記録するテーブルの作成
+// dirtyは適用または取り消しの途中で失敗したことを示す（MySQLのDDLはトランザクションで取り消せないため）
+const createTableQuery = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum CHAR(64) NOT NULL,
+		dirty BOOLEAN NOT NULL DEFAULT FALSE,
+		applied_at TIMESTAMP(6) NOT NULL
+	)
+`
+
+// sqliteCreateTableQuery : SQLiteの適用済みのマイグレーションを記録するテーブルの作成
+// SQLiteのドライバーは精度を指定した日時の型を日時として読み込まないため、精度を指定しない
+const sqliteCreateTableQuery = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum CHAR(64) NOT NULL,
+		dirty BOOLEAN NOT NULL DEFAULT FALSE,
+		applied_at TIMESTAMP NOT NULL
+	)
+`
+
+// appliedDTO : 適用済みのマイグレーションのデータ転送オブジェクト
+type appliedDTO struct {
+	Version   int64     `db:"version"`
+	Name      string    `db:"name"`
+	Checksum  string    `db:"checksum"`
+	Dirty     bool      `db:"dirty"`
+	AppliedAt time.Time `db:"applied_at"`
+}
+
+// Status : マイグレーションの適用状況
+type Status struct {
+	Version int64
+	Name    string
+	// Migration : マイグレーションファイル（適用済みのマイグレーションのファイルが削除された場合はnil）
+	Migration *Migration
+	Applied   bool
+	AppliedAt *time.Time
+	// Dirty : 適用または取り消しの途中で失敗した
+	Dirty bool
+	// Drift : マイグレーションファイルと一致しない理由（一致する場合は空）
+	Drift string
+}
+
+// Direction : マイグレーションの実行方向
+type Direction string
+
+const (
+	// DirectionUp : 適用
+	DirectionUp Direction = "up"
+	// DirectionDown : 取り消し
+	DirectionDown Direction = "down"
+)
+
+// Step : 実行したマイグレーション
+type Step struct {
+	Migration Migration
+	Direction Direction
+	Duration  time.Duration
+}
+
+// Migrator : マイグレーションの実行
+// 実行中はデータベースの名前付きロック（SQLiteの場合は書き込みロック）を保持し、複数のプロセスが同時に実行しても重複して適用しない
+type Migrator struct {
+	db          *rdb.DB
+	migrations  []Migration
+	lockTimeout time.Duration
+}
+
+// NewMigrator : Migratorの生成（migrationsはバージョンの昇順）
+func NewMigrator(db *rdb.DB, migrations []Migration) *Migrator {
+	return &Migrator{
+		db:          db,
+		migrations:  migrations,
+		lockTimeout: DefaultLockTimeout,
+	}
+}
+
+// SetLockTimeout : ロックの取得を待機する時間の変更
+func (m *Migrator) SetLockTimeout(lockTimeout time.Duration) {
+	m.lockTimeout = lockTimeout
+}
+
+// Status : マイグレーションの適用状況の取得（バージョンの昇順）
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.findApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		statuses = m.statuses(applied)
+		return nil
+	})
+	return statuses, err
+}
+
+// Up : 未適用のマイグレーションを古い順にsteps件適用（stepsが0の場合は全て）
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Step, error) {
+	return m.run(ctx, func(statuses []Status) ([]Migration, Direction, error) {
+		pending := pendingMigrations(statuses)
+		if steps > 0 && len(pending) > steps {
+			pending = pending[:steps]
+		}
+		return pending, DirectionUp, nil
+	})
+}
+
+// Down : 適用済みのマイグレーションを新しい順にsteps件取り消す
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Step, error) {
+	return m.run(ctx, func(statuses []Status) ([]Migration, Direction, error) {
+		applied := appliedMigrations(statuses)
+		if len(applied) > steps {
+			applied = applied[:steps]
+		}
+		return applied, DirectionDown, nil
+	})
+}
+
+// To : 指定したバージョンまで適用または取り消す（0の場合は全て取り消す）
+// 指定したバージョンより新しい適用済みのマイグレーションがある場合は取り消し、それ以外は指定したバージョンまで適用する
+func (m *Migrator) To(ctx context.Context, version int64) ([]Step, error) {
+	if version != 0 && m.find(version) == nil {
+		return nil, fmt.Errorf("バージョン %d のマイグレーションファイルがありません", version)
+	}
+
+	return m.run(ctx, func(statuses []Status) ([]Migration, Direction, error) {
+		var revert []Migration
+		for _, migration := range appliedMigrations(statuses) {
+			if migration.Version > version {
+				revert = append(revert, migration)
+			}
+		}
+		if len(revert) > 0 {
+			return revert, DirectionDown, nil
+		}
+
+		var apply []Migration
+		for _, migration := range pendingMigrations(statuses) {
+			if migration.Version <= version {
+				apply = append(apply, migration)
+			}
+		}
+		return apply, DirectionUp, nil
+	})
+}
+
+// Baseline : 指定したバージョンまでのマイグレーションをSQLを実行せずに適用済みとして記録
+// マイグレーションの記録を始める前に作成されたデータベースで使用する
+func (m *Migrator) Baseline(ctx context.Context, version int64) ([]Migration, error) {
+	if m.find(version) == nil {
+		return nil, fmt.Errorf("バージョン %d のマイグレーションファイルがありません", version)
+	}
+
+	var recorded []Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.findApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := verify(m.statuses(applied)); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.record(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			recorded = append(recorded, migration)
+		}
+		return nil
+	})
+	return recorded, err
+}
+
+// Repair : 途中で失敗したマイグレーションの記録の削除（未適用に戻す）
+// スキーマを手作業で適用前の状態に戻してから実行する
+func (m *Migrator) Repair(ctx context.Context, version int64) error {
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		result, err := conn.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ? AND dirty", version)
+		if err != nil {
+			return fmt.Errorf("マイグレーションの記録の削除エラー: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("バージョン %d は途中で失敗したマイグレーションではありません", version)
+		}
+		return nil
+	})
+}
+
+// run : ロックを取得して適用状況を確認してから、planが返すマイグレーションを順に実行
+func (m *Migrator) run(ctx context.Context, plan func(statuses []Status) ([]Migration, Direction, error)) ([]Step, error) {
+	var steps []Step
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.findApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		statuses := m.statuses(applied)
+		if err := verify(statuses); err != nil {
+			return err
+		}
+
+		migrations, direction, err := plan(statuses)
+		if err != nil {
+			return err
+		}
+
+		// 途中で取り消せないマイグレーションに到達しないよう、実行前に全て確認する
+		if direction == DirectionDown {
+			for _, migration := range migrations {
+				if !migration.Reversible() {
+					return fmt.Errorf("%w: %s", ErrIrreversible, migration)
+				}
+			}
+		}
+
+		for _, migration := range migrations {
+			start := time.Now()
+			if direction == DirectionUp {
+				err = m.apply(ctx, conn, migration)
+			} else {
+				err = m.revert(ctx, conn, migration)
+			}
+			if err != nil {
+				return err
+			}
+			steps = append(steps, Step{Migration: migration, Direction: direction, Duration: time.Since(start)})
+		}
+		return nil
+	})
+	if err != nil && m.transactional() {
+		// 全体がロールバックされるため、実行したマイグレーションはない
+		steps = nil
+	}
+	return steps, err
+}
+
+// apply : マイグレーションの適用
+func (m *Migrator) apply(ctx context.Context, conn *sqlx.Conn, migration Migration) error {
+	if err := m.record(ctx, conn, migration, true); err != nil {
+		return err
+	}
+
+	for _, statement := range splitStatements(migration.Up) {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			if m.transactional() {
+				return fmt.Errorf("マイグレーション %s の適用エラー: %w", migration, err)
+			}
+			return fmt.Errorf("マイグレーション %s の適用エラー（途中まで適用された可能性があるため、スキーマを適用前の状態に戻してから repair %d を実行してください）: %w", migration, migration.Version, err)
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "UPDATE schema_migrations SET dirty = FALSE, applied_at = ? WHERE version = ?", time.Now(), migration.Version); err != nil {
+		return fmt.Errorf("マイグレーションの記録の更新エラー: %w", err)
+	}
+	return nil
+}
+
+// revert : マイグレーションの取り消し
+func (m *Migrator) revert(ctx context.Context, conn *sqlx.Conn, migration Migration) error {
+	if _, err := conn.ExecContext(ctx, "UPDATE schema_migrations SET dirty = TRUE WHERE version = ?", migration.Version); err != nil {
+		return fmt.Errorf("マイグレーションの記録の更新エラー: %w", err)
+	}
+
+	for _, statement := range splitStatements(migration.Down) {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			if m.transactional() {
+				return fmt.Errorf("マイグレーション %s の取り消しエラー: %w", migration, err)
+			}
+			return fmt.Errorf("マイグレーション %s の取り消しエラー（途中まで取り消された可能性があるため、スキーマを適用前の状態に戻してから repair %d を実行してください）: %w", migration, migration.Version, err)
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", migration.Version); err != nil {
+		return fmt.Errorf("マイグレーションの記録の削除エラー: %w", err)
+	}
+	return nil
+}
+
+// record : マイグレーションを適用済みとして記録
+func (m *Migrator) record(ctx context.Context, conn *sqlx.Conn, migration Migration, dirty bool) error {
+	query := `
+		INSERT INTO schema_migrations (version, name, checksum, dirty, applied_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	if _, err := conn.ExecContext(ctx, query, migration.Version, migration.Name, migration.Checksum, dirty, time.Now()); err != nil {
+		return fmt.Errorf("マイグレーションの記録エラー: %w", err)
+	}
+	return nil
+}
+
+// findApplied : 適用済みのマイグレーションの取得
+func (m *Migrator) findApplied(ctx context.Context, conn *sqlx.Conn) (map[int64]appliedDTO, error) {
+	var dtos []appliedDTO
+	if err := sqlx.SelectContext(ctx, conn, &dtos, "SELECT version, name, checksum, dirty, applied_at FROM schema_migrations"); err != nil {
+		return nil, fmt.Errorf("適用済みのマイグレーションの取得エラー: %w", err)
+	}
+
+	applied := make(map[int64]appliedDTO, len(dtos))
+	for _, dto := range dtos {
+		applied[dto.Version] = dto
+	}
+	return applied, nil
+}
+
+// statuses : マイグレーションファイルと適用済みのマイグレーションの突き合わせ
+func (m *Migrator) statuses(applied map[int64]appliedDTO) []Status {
+	var latestApplied int64
+	for version := range applied {
+		if version > latestApplied {
+			latestApplied = version
+		}
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for i := range m.migrations {
+		migration := &m.migrations[i]
+		status := Status{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Migration: migration,
+		}
+
+		if dto, ok := applied[migration.Version]; ok {
+			appliedAt := dto.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Dirty = dto.Dirty
+			if dto.Checksum != migration.Checksum {
+				status.Drift = "適用後にファイルが変更されています"
+			}
+		} else if migration.Version < latestApplied {
+			status.Drift = "適用済みのマイグレーションより古い未適用のマイグレーションです"
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	// 適用済みでファイルが削除されたマイグレーション
+	for version, dto := range applied {
+		if m.find(version) != nil {
+			continue
+		}
+		appliedAt := dto.AppliedAt
+		statuses = append(statuses, Status{
+			Version:   version,
+			Name:      dto.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Dirty:     dto.Dirty,
+			Drift:     "適用済みのマイグレーションのファイルがありません",
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses
+}
+
+// find : バージョンによるマイグレーションの検索
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// withLock : マイグレーション用の名前付きロックを取得してfnを実行
+// 名前付きロックは接続に紐づくため、ロックの取得からfnの実行、解放まで同じ接続を使用する
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Write(ctx).Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("データベース接続の取得エラー: %w", err)
+	}
+	defer conn.Close()
+
+	if m.transactional() {
+		return m.withSQLiteLock(ctx, conn, fn)
+	}
+
+	var acquired sql.NullInt64
+	err = conn.QueryRowxContext(ctx, "SELECT GET_LOCK(CONCAT('schema_migrations:', DATABASE()), ?)", int(m.lockTimeout.Seconds())).Scan(&acquired)
+	if err != nil {
+		return fmt.Errorf("ロックの取得エラー: %w", err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return ErrLocked
+	}
+	defer func() {
+		// ctxが終了していてもロックは解放する
+		releaseCtx := context.WithoutCancel(ctx)
+		conn.ExecContext(releaseCtx, "SELECT RELEASE_LOCK(CONCAT('schema_migrations:', DATABASE()))")
+	}()
+
+	if _, err := conn.ExecContext(ctx, createTableQuery); err != nil {
+		return fmt.Errorf("schema_migrationsテーブルの作成エラー: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// withSQLiteLock : SQLiteの書き込みロックを取得し、1つのトランザクションでfnを実行
+// SQLiteはDDLもトランザクションで取り消せるため、失敗した場合は全体を取り消す
+func (m *Migrator) withSQLiteLock(ctx context.Context, conn *sqlx.Conn, fn func(conn *sqlx.Conn) error) error {
+	// 他のプロセスが書き込みロックを保持している場合はlockTimeoutまで待機する
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("PRAGMA busy_timeout = %d", m.lockTimeout.Milliseconds())); err != nil {
+		return fmt.Errorf("ロックの待機時間の設定エラー: %w", err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), fmt.Sprintf("PRAGMA busy_timeout = %d", rdb.SQLiteBusyTimeout.Milliseconds()))
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		if rdb.IsTransient(err) {
+			return ErrLocked
+		}
+		return fmt.Errorf("ロックの取得エラー: %w", err)
+	}
+
+	err := func() error {
+		if _, err := conn.ExecContext(ctx, sqliteCreateTableQuery); err != nil {
+			return fmt.Errorf("schema_migrationsテーブルの作成エラー: %w", err)
+		}
+		return fn(conn)
+	}()
+	if err != nil {
+		conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK")
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK")
+		return fmt.Errorf("マイグレーションのコミットエラー: %w", err)
+	}
+	return nil
+}
+
+// transactional : マイグレーション全体を1つのトランザクションで実行するかどうか（SQLiteの場合）
+func (m *Migrator) transactional() bool {
+	return m.db.Driver() == rdb.DriverSQLite
+}
+
+// verify : 適用状況の確認（ファイルと一致しない、または途中で失敗したマイグレーションがある場合はエラー）
+func verify(statuses []Status) error {
+	var problems []string
+	for _, status := range statuses {
+		if status.Drift != "" {
+			problems = append(problems, fmt.Sprintf("%d_%s: %s", status.Version, status.Name, status.Drift))
+		}
+		if status.Dirty {
+			problems = append(problems, fmt.Sprintf("%d_%s: 途中で失敗しています（スキーマを適用前の状態に戻してから repair %d を実行してください）", status.Version, status.Name, status.Version))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w:\n%s", ErrDrift, strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// pendingMigrations : 未適用のマイグレーション（古い順）
+func pendingMigrations(statuses []Status) []Migration {
+	var migrations []Migration
+	for _, status := range statuses {
+		if !status.Applied && status.Migration != nil {
+			migrations = append(migrations, *status.Migration)
+		}
+	}
+	return migrations
+}
+
+// appliedMigrations : 適用済みのマイグレーション（新しい順）
+func appliedMigrations(statuses []Status) []Migration {
+	var migrations []Migration
+	for i := len(statuses) - 1; i >= 0; i-- {
+		if statuses[i].Applied && statuses[i].Migration != nil {
+			migrations = append(migrations, *statuses[i].Migration)
+		}
+	}
+	return migrations
+}
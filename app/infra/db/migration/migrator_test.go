@@ -0,0 +1,281 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"myblog/app/infra/db/rdb"
+)
+
+// testFiles : テスト用のマイグレーションファイル（3は取り消せない）
+func testFiles() fstest.MapFS {
+	return fstest.MapFS{
+		"1_create_users.sql":      {Data: []byte("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);")},
+		"down/1_create_users.sql": {Data: []byte("DROP TABLE users;")},
+		"2_create_posts.sql": {Data: []byte("-- 投稿; コメント\nCREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT NOT NULL);\n" +
+			"INSERT INTO posts (title) VALUES ('hello; world')")},
+		"down/2_create_posts.sql": {Data: []byte("DROP TABLE posts;")},
+		"3_seed_users.sql":        {Data: []byte("INSERT INTO users (name) VALUES ('alice');")},
+	}
+}
+
+// newTestMigrator : メモリ上のSQLiteとマイグレーションファイルからMigratorを生成
+func newTestMigrator(t *testing.T, files fstest.MapFS) (*Migrator, *rdb.DB) {
+	t.Helper()
+
+	db, err := rdb.Open(rdb.Config{Driver: rdb.DriverSQLite, Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("データベースの接続に失敗しました: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return loadMigrator(t, db, files), db
+}
+
+// loadMigrator : マイグレーションファイルを読み込んでMigratorを生成
+func loadMigrator(t *testing.T, db *rdb.DB, files fstest.MapFS) *Migrator {
+	t.Helper()
+
+	migrations, err := Load(files)
+	if err != nil {
+		t.Fatalf("マイグレーションファイルの読み込みに失敗しました: %v", err)
+	}
+	return NewMigrator(db, migrations)
+}
+
+// stepVersions : 実行したマイグレーションのバージョン
+func stepVersions(steps []Step) []int64 {
+	var versions []int64
+	for _, step := range steps {
+		versions = append(versions, step.Migration.Version)
+	}
+	return versions
+}
+
+// appliedVersions : 適用済みのマイグレーションのバージョン
+func appliedVersions(t *testing.T, m *Migrator) []int64 {
+	t.Helper()
+
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		t.Fatalf("適用状況の取得に失敗しました: %v", err)
+	}
+	var versions []int64
+	for _, status := range statuses {
+		if status.Applied {
+			versions = append(versions, status.Version)
+		}
+	}
+	return versions
+}
+
+// tableExists : テーブルが存在するかどうか
+func tableExists(t *testing.T, db *rdb.DB, name string) bool {
+	t.Helper()
+
+	var count int
+	if err := db.Write(context.Background()).Get(&count, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name); err != nil {
+		t.Fatalf("テーブルの確認に失敗しました: %v", err)
+	}
+	return count > 0
+}
+
+func TestMigrator_UpDownTo(t *testing.T) {
+	ctx := context.Background()
+	m, db := newTestMigrator(t, testFiles())
+
+	tests := []struct {
+		name      string
+		run       func() ([]Step, error)
+		wantErr   error
+		wantSteps []int64
+		wantAfter []int64
+	}{
+		{name: "件数を指定して適用", run: func() ([]Step, error) { return m.Up(ctx, 1) }, wantSteps: []int64{1}, wantAfter: []int64{1}},
+		{name: "残りを全て適用", run: func() ([]Step, error) { return m.Up(ctx, 0) }, wantSteps: []int64{2, 3}, wantAfter: []int64{1, 2, 3}},
+		{name: "未適用がなければ何もしない", run: func() ([]Step, error) { return m.Up(ctx, 0) }, wantAfter: []int64{1, 2, 3}},
+		{name: "取り消せないマイグレーションは取り消さない", run: func() ([]Step, error) { return m.Down(ctx, 1) }, wantErr: ErrIrreversible, wantAfter: []int64{1, 2, 3}},
+		{name: "取り消せないマイグレーションを含む範囲は実行前に拒否", run: func() ([]Step, error) { return m.To(ctx, 1) }, wantErr: ErrIrreversible, wantAfter: []int64{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := tt.run()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("エラーが異なります: got %v, want %v", err, tt.wantErr)
+			}
+			if got := stepVersions(steps); !reflect.DeepEqual(got, tt.wantSteps) {
+				t.Errorf("実行したマイグレーションが異なります: got %v, want %v", got, tt.wantSteps)
+			}
+			if got := appliedVersions(t, m); !reflect.DeepEqual(got, tt.wantAfter) {
+				t.Errorf("適用済みのマイグレーションが異なります: got %v, want %v", got, tt.wantAfter)
+			}
+		})
+	}
+
+	t.Run("指定したバージョンまで取り消し、再度適用する", func(t *testing.T) {
+		// 取り消せないマイグレーションを除いたファイルで実行する
+		files := testFiles()
+		delete(files, "3_seed_users.sql")
+		m := loadMigrator(t, db, files)
+		if _, err := db.Write(ctx).ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = 3"); err != nil {
+			t.Fatal(err)
+		}
+
+		steps, err := m.To(ctx, 1)
+		if err != nil {
+			t.Fatalf("取り消しに失敗しました: %v", err)
+		}
+		if got := stepVersions(steps); !reflect.DeepEqual(got, []int64{2}) || steps[0].Direction != DirectionDown {
+			t.Errorf("取り消したマイグレーションが異なります: %v", got)
+		}
+		if tableExists(t, db, "posts") {
+			t.Error("取り消したテーブルが残っています")
+		}
+
+		steps, err = m.To(ctx, 2)
+		if err != nil {
+			t.Fatalf("適用に失敗しました: %v", err)
+		}
+		if got := stepVersions(steps); !reflect.DeepEqual(got, []int64{2}) || steps[0].Direction != DirectionUp {
+			t.Errorf("適用したマイグレーションが異なります: %v", got)
+		}
+
+		steps, err = m.Down(ctx, 2)
+		if err != nil {
+			t.Fatalf("取り消しに失敗しました: %v", err)
+		}
+		if got := stepVersions(steps); !reflect.DeepEqual(got, []int64{2, 1}) {
+			t.Errorf("取り消したマイグレーションが異なります: %v", got)
+		}
+		if tableExists(t, db, "users") || tableExists(t, db, "posts") {
+			t.Error("取り消したテーブルが残っています")
+		}
+
+		if _, err := m.To(ctx, 9); err == nil {
+			t.Error("存在しないバージョンを指定してもエラーになりませんでした")
+		}
+	})
+}
+
+func TestMigrator_Drift(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		change func(files fstest.MapFS)
+	}{
+		{name: "適用後にファイルが変更された", change: func(files fstest.MapFS) {
+			files["1_create_users.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE users (id INTEGER PRIMARY KEY);")}
+		}},
+		{name: "適用済みのファイルが削除された", change: func(files fstest.MapFS) {
+			delete(files, "2_create_posts.sql")
+			delete(files, "down/2_create_posts.sql")
+		}},
+		{name: "適用済みより古い未適用のファイルが追加された", change: func(files fstest.MapFS) {
+			files["0_legacy.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE legacy (id INTEGER);")}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := testFiles()
+			delete(files, "3_seed_users.sql")
+			m, db := newTestMigrator(t, files)
+			if _, err := m.Up(ctx, 0); err != nil {
+				t.Fatalf("適用に失敗しました: %v", err)
+			}
+
+			tt.change(files)
+			files["4_create_tags.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE tags (id INTEGER);")}
+			changed := loadMigrator(t, db, files)
+
+			steps, err := changed.Up(ctx, 0)
+			if !errors.Is(err, ErrDrift) {
+				t.Fatalf("ErrDriftではありません: %v", err)
+			}
+			if len(steps) > 0 || tableExists(t, db, "tags") || tableExists(t, db, "legacy") {
+				t.Error("一致しない状態でマイグレーションが実行されました")
+			}
+			if _, err := changed.Down(ctx, 1); !errors.Is(err, ErrDrift) {
+				t.Errorf("取り消しがErrDriftではありません: %v", err)
+			}
+
+			statuses, err := changed.Status(ctx)
+			if err != nil {
+				t.Fatalf("適用状況の取得に失敗しました: %v", err)
+			}
+			drifted := 0
+			for _, status := range statuses {
+				if status.Drift != "" {
+					drifted++
+				}
+			}
+			if drifted != 1 {
+				t.Errorf("一致しないマイグレーションの数が異なります: %d", drifted)
+			}
+		})
+	}
+}
+
+func TestMigrator_Dirty(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("失敗したマイグレーションは全て取り消す", func(t *testing.T) {
+		files := testFiles()
+		files["3_seed_users.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE tags (id INTEGER);\nINSERT INTO missing VALUES (1);")}
+		m, db := newTestMigrator(t, files)
+
+		steps, err := m.Up(ctx, 0)
+		if err == nil {
+			t.Fatal("エラーになりませんでした")
+		}
+		if len(steps) > 0 {
+			t.Errorf("実行したマイグレーションが返されました: %v", stepVersions(steps))
+		}
+		if got := appliedVersions(t, m); len(got) > 0 {
+			t.Errorf("適用済みとして記録されています: %v", got)
+		}
+		if tableExists(t, db, "users") || tableExists(t, db, "tags") {
+			t.Error("失敗したマイグレーションのテーブルが残っています")
+		}
+	})
+
+	t.Run("途中で失敗したマイグレーションがある場合はrepairするまで実行しない", func(t *testing.T) {
+		m, db := newTestMigrator(t, testFiles())
+		if _, err := m.Up(ctx, 1); err != nil {
+			t.Fatalf("適用に失敗しました: %v", err)
+		}
+		// MySQLで適用の途中で失敗した状態
+		query := "INSERT INTO schema_migrations (version, name, checksum, dirty, applied_at) VALUES (?, ?, ?, TRUE, ?)"
+		if _, err := db.Write(ctx).ExecContext(ctx, query, 2, "create_posts", m.migrations[1].Checksum, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := m.Up(ctx, 0); !errors.Is(err, ErrDrift) {
+			t.Fatalf("ErrDriftではありません: %v", err)
+		}
+		if tableExists(t, db, "posts") {
+			t.Error("途中で失敗した状態でマイグレーションが実行されました")
+		}
+
+		if err := m.Repair(ctx, 1); err == nil {
+			t.Error("途中で失敗していないマイグレーションをrepairできました")
+		}
+		if err := m.Repair(ctx, 2); err != nil {
+			t.Fatalf("repairに失敗しました: %v", err)
+		}
+
+		steps, err := m.Up(ctx, 0)
+		if err != nil {
+			t.Fatalf("repair後の適用に失敗しました: %v", err)
+		}
+		if got := stepVersions(steps); !reflect.DeepEqual(got, []int64{2, 3}) {
+			t.Errorf("適用したマイグレーションが異なります: %v", got)
+		}
+	})
+}
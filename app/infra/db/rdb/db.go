@@ -3,7 +3,9 @@ package rdb
 import (
 	"context"
 	"fmt"
-	"os"
+	"net"
+	"net/url"
+	"sync"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -11,117 +13,204 @@ import (
 )
 
 // DB : データベース接続を管理する構造体
+// 書き込みはプライマリ、読み取りは正常なレプリカに振り分ける（レプリカがない場合はプライマリ）
 type DB struct {
-	db *sqlx.DB
+	driver   Driver
+	location *time.Location
+	db       *sqlx.DB
+	replicas *replicaSet
+	writes   *writeTracker
+	done     chan struct{}
+	wg       sync.WaitGroup
 }
 
-// NewDB : DBの生成
-func NewDB() (*DB, error) {
-	// 環境変数から接続情報を取得
-	user := getEnv("DB_USER", "root")
-	password := getEnv("DB_PASSWORD", "password")
-	host := getEnv("DB_HOST", "localhost")
-	port := getEnv("DB_PORT", "3306")
-	database := getEnv("DB_NAME", "myblog")
+// Config : データベース接続の設定
+type Config struct {
+	// Driver : データベースの種類（省略時はMySQL）
+	Driver Driver
+	// Path : SQLiteのデータベースファイルのパス（「:memory:」の場合はメモリ上のデータベース）
+	Path     string
+	User     string
+	Password string
+	Host     string
+	Port     string
+	Database string
+	// Location : 日時の解釈に使用するタイムゾーン（IANAのタイムゾーン名。空の場合はUTC）
+	Location string
+	// MaxOpenConns : 接続ごとのコネクションプールの最大接続数
+	MaxOpenConns int
+	// MaxIdleConns : 接続ごとのコネクションプールの最大アイドル接続数
+	MaxIdleConns int
+	// ConnMaxLifetime : 接続を再利用する最大の期間
+	ConnMaxLifetime time.Duration
+	// ReplicaHosts : 読み取りに使用するレプリカのホスト（host:port。認証情報とデータベース名はプライマリと同じ）
+	ReplicaHosts []string
+	// ReadPolicy : レプリカの選択方法
+	ReadPolicy ReadPolicy
+	// HealthCheckInterval : レプリカの死活監視の間隔（応答しないレプリカは復旧するまで読み取りに使用しない）
+	HealthCheckInterval time.Duration
+	// ReadYourWritesWindow : 書き込み後に同じユーザーの読み取りをプライマリに振り分ける期間（レプリカの遅延対策）
+	ReadYourWritesWindow time.Duration
+}
+
+// dsn : ホストに接続するDSN (Data Source Name) の構築
+func (c Config) dsn(hostPort string) string {
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?parseTime=true&loc=%s", c.User, c.Password, hostPort, c.Database, url.QueryEscape(c.Location))
+}
 
-	// DSN (Data Source Name) の構築
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&loc=Asia%%2FTokyo", user, password, host, port, database)
+// Open : 設定によるDBの生成
+func Open(config Config) (*DB, error) {
+	switch config.Driver {
+	case DriverMySQL, "":
+	case DriverSQLite:
+		return openSQLite(config)
+	default:
+		return nil, fmt.Errorf("unknown database driver: %s", config.Driver)
+	}
 
-	// データベース接続
-	db, err := sqlx.Open("mysql", dsn)
+	location, err := time.LoadLocation(config.Location)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, fmt.Errorf("unknown location: %s: %w", config.Location, err)
+	}
+
+	switch config.ReadPolicy {
+	case ReadPolicyRoundRobin, ReadPolicyLeastConnections:
+	default:
+		return nil, fmt.Errorf("unknown read policy: %s", config.ReadPolicy)
+	}
+
+	// プライマリへの接続
+	db, err := openPool(string(DriverMySQL), config, config.dsn(net.JoinHostPort(config.Host, config.Port)))
+	if err != nil {
+		return nil, err
 	}
 
 	// 接続確認
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	// レプリカへの接続（起動時に応答しないレプリカは死活監視で復旧するまで使用しない）
+	replicas := &replicaSet{policy: config.ReadPolicy}
+	for _, host := range config.ReplicaHosts {
+		replicaDB, err := openPool(string(DriverMySQL), config, config.dsn(host))
+		if err != nil {
+			replicas.close()
+			db.Close()
+			return nil, fmt.Errorf("failed to open replica %s: %w", host, err)
+		}
+		r := &replica{host: host, db: replicaDB}
+		r.healthy.Store(replicaDB.Ping() == nil)
+		replicas.replicas = append(replicas.replicas, r)
+	}
+
+	// レプリカがない場合は読み取りも常にプライマリで行うため、書き込みを記録しない
+	window := config.ReadYourWritesWindow
+	if len(replicas.replicas) == 0 {
+		window = 0
+	}
+
+	d := &DB{
+		driver:   DriverMySQL,
+		location: location,
+		db:       db,
+		replicas: replicas,
+		writes:   newWriteTracker(window),
+		done:     make(chan struct{}),
+	}
+
+	if len(replicas.replicas) > 0 && config.HealthCheckInterval > 0 {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.monitor(config.HealthCheckInterval)
+		}()
+	}
+
+	return d, nil
+}
+
+// openPool : コネクションプールの生成
+func openPool(driverName string, config Config, dsn string) (*sqlx.DB, error) {
+	db, err := sqlx.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
 	// コネクションプールの設定
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
 
-	return &DB{db: db}, nil
+	return db, nil
+}
+
+// monitor : レプリカの死活監視と書き込み記録の掃除をDBが閉じられるまで繰り返す
+func (d *DB) monitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.C:
+			d.replicas.checkHealth(interval)
+			d.writes.prune()
+		}
+	}
 }
 
 // Close : データベース接続のクローズ
 func (d *DB) Close() error {
+	close(d.done)
+	d.wg.Wait()
+	d.replicas.close()
 	return d.db.Close()
 }
 
-// Begin : トランザクションの開始
-func (d *DB) Begin() (*sqlx.Tx, error) {
-	return d.db.Beginx()
+// Driver : データベースの種類
+func (d *DB) Driver() Driver {
+	return d.driver
+}
+
+// Location : 日時の解釈に使用するタイムゾーン（日付の境界の計算に使用する）
+func (d *DB) Location() *time.Location {
+	return d.location
 }
 
 // Read : 読み取り用のデータベース接続を取得
+// 同じリクエストまたはユーザーが直近に書き込んだ場合と、正常なレプリカがない場合はプライマリを返す
 func (d *DB) Read(ctx context.Context) *sqlx.DB {
+	if d.writes.recentlyWrote(ctx) {
+		return d.db
+	}
+	if replica := d.replicas.pick(); replica != nil {
+		return replica
+	}
 	return d.db
 }
 
-// Write : 書き込み用のデータベース接続を取得
+// Write : 書き込み用のデータベース接続（プライマリ）を取得
+// 以降の同じリクエストまたはユーザーの読み取りは一定期間プライマリに振り分ける
 func (d *DB) Write(ctx context.Context) *sqlx.DB {
+	d.writes.markWrite(ctx)
 	return d.db
 }
 
-// getEnv : 環境変数を取得（デフォルト値付き）
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// Reader : 読み取りに使用するExecutorを取得（コンテキストにトランザクションがあればトランザクション）
+func (d *DB) Reader(ctx context.Context) Executor {
+	if tx, ok := GetTx(ctx); ok {
+		return tx
 	}
-	return value
-}
-
-// TransactionManager : トランザクション管理インターフェース
-type TransactionManager interface {
-	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	return d.Read(ctx)
 }
 
-// DefaultTransactionManager : デフォルトのトランザクション管理実装
-type DefaultTransactionManager struct {
-	db *DB
-}
-
-// NewDefaultTransactionManager : DefaultTransactionManagerの生成
-func NewDefaultTransactionManager(db *DB) TransactionManager {
-	return &DefaultTransactionManager{db: db}
-}
-
-// Transaction : トランザクションを実行
-func (tm *DefaultTransactionManager) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	tx, err := tm.db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-
-	// トランザクションをコンテキストに設定
-	txCtx := context.WithValue(ctx, txKey{}, tx)
-
-	// 関数実行
-	if err := fn(txCtx); err != nil {
-		// エラー発生時はロールバック
-		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("failed to rollback transaction: %w (original error: %v)", rbErr, err)
-		}
-		return err
+// Writer : 書き込みに使用するExecutorを取得（コンテキストにトランザクションがあればトランザクション）
+func (d *DB) Writer(ctx context.Context) Executor {
+	if tx, ok := GetTx(ctx); ok {
+		return tx
 	}
-
-	// コミット
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	return nil
-}
-
-// トランザクションをコンテキストに格納するためのキー
-type txKey struct{}
-
-// GetTx : コンテキストからトランザクションを取得
-func GetTx(ctx context.Context) (*sqlx.Tx, bool) {
-	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
-	return tx, ok
+	return d.Write(ctx)
 }
@@ -0,0 +1,81 @@
+package rdb
+
+import (
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/glebarez/go-sqlite"
+	"github.com/go-sql-driver/mysql"
+)
+
+// transientErrorNumbers : 再実行で成功する可能性のあるMySQLのエラー番号
+var transientErrorNumbers = map[uint16]bool{
+	1040: true, // ER_CON_COUNT_ERROR: 接続数の上限
+	1053: true, // ER_SERVER_SHUTDOWN: サーバーの停止中
+	1205: true, // ER_LOCK_WAIT_TIMEOUT: ロック待ちのタイムアウト
+	1213: true, // ER_LOCK_DEADLOCK: デッドロック
+}
+
+// IsTransient : 一時的なデータベースエラー（接続断・デッドロックなど再実行で成功する可能性のあるエラー）かどうかを判定
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) {
+		return true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return transientErrorNumbers[mysqlErr.Number]
+	}
+
+	if _, ok := sqliteBusyReason(err); ok {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryableTxErrorNumbers : トランザクション全体の再実行で解消するMySQLのエラー番号と名前
+var retryableTxErrorNumbers = map[uint16]string{
+	1205: "lock_wait_timeout", // ER_LOCK_WAIT_TIMEOUT: ロック待ちのタイムアウト
+	1213: "deadlock",          // ER_LOCK_DEADLOCK: デッドロック（トランザクションはロールバック済み）
+}
+
+// IsRetryableTx : トランザクションをロールバックして最初から再実行すれば成功する可能性のあるエラーかどうかを判定
+// IsTransientと異なり、接続断など再実行しても同じ結果になりやすいエラーは含まない
+func IsRetryableTx(err error) bool {
+	_, ok := retryableTxReason(err)
+	return ok
+}
+
+// retryableTxReason : 再実行可能なエラーの名前を返す
+func retryableTxReason(err error) (string, bool) {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return sqliteBusyReason(err)
+	}
+	reason, ok := retryableTxErrorNumbers[mysqlErr.Number]
+	return reason, ok
+}
+
+// sqliteBusyCodes : 他の接続がデータベースをロックしているため失敗したことを示すSQLiteの結果コードと名前
+var sqliteBusyCodes = map[int]string{
+	5: "busy",   // SQLITE_BUSY: busy_timeoutの間に書き込みロックを取得できなかった
+	6: "locked", // SQLITE_LOCKED: 同じ接続内のロックの競合
+}
+
+// sqliteBusyReason : SQLiteのロックの競合によるエラーの名前を返す
+func sqliteBusyReason(err error) (string, bool) {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return "", false
+	}
+	// 拡張結果コードの下位8ビットが基本の結果コード
+	reason, ok := sqliteBusyCodes[sqliteErr.Code()&0xff]
+	return reason, ok
+}
@@ -0,0 +1,22 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Executor : クエリの実行インターフェース（*sqlx.DBと*sqlx.Txが実装する）
+// 全てのメソッドがコンテキストを受け取り、キャンセルされたクエリは中断される
+type Executor interface {
+	sqlx.ExtContext
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+}
+
+var (
+	_ Executor = (*sqlx.DB)(nil)
+	_ Executor = (*sqlx.Tx)(nil)
+)
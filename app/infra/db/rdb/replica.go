@@ -0,0 +1,100 @@
+package rdb
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ReadPolicy : 読み取りに使用するレプリカの選択方法
+type ReadPolicy string
+
+const (
+	// ReadPolicyRoundRobin : 正常なレプリカを順番に使用する
+	ReadPolicyRoundRobin ReadPolicy = "round-robin"
+	// ReadPolicyLeastConnections : 使用中の接続が最も少ないレプリカを使用する
+	ReadPolicyLeastConnections ReadPolicy = "least-connections"
+)
+
+// replica : 読み取り用のレプリカ
+type replica struct {
+	host    string
+	db      *sqlx.DB
+	healthy atomic.Bool
+}
+
+// replicaSet : レプリカの集合
+type replicaSet struct {
+	policy   ReadPolicy
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+// pick : 正常なレプリカを選択する（正常なレプリカがない場合はnil）
+func (s *replicaSet) pick() *sqlx.DB {
+	n := len(s.replicas)
+	if n == 0 {
+		return nil
+	}
+
+	switch s.policy {
+	case ReadPolicyLeastConnections:
+		var picked *replica
+		for _, r := range s.replicas {
+			if !r.healthy.Load() {
+				continue
+			}
+			if picked == nil || r.db.Stats().InUse < picked.db.Stats().InUse {
+				picked = r
+			}
+		}
+		if picked == nil {
+			return nil
+		}
+		return picked.db
+	default:
+		start := int(s.next.Add(1) % uint64(n))
+		for i := 0; i < n; i++ {
+			r := s.replicas[(start+i)%n]
+			if r.healthy.Load() {
+				return r.db
+			}
+		}
+		return nil
+	}
+}
+
+// checkHealth : 全てのレプリカに疎通確認し、応答しないレプリカを読み取りから外す
+func (s *replicaSet) checkHealth(timeout time.Duration) {
+	var wg sync.WaitGroup
+	for _, r := range s.replicas {
+		wg.Add(1)
+		go func(r *replica) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			healthy := r.db.PingContext(ctx) == nil
+			if previous := r.healthy.Swap(healthy); previous != healthy {
+				if healthy {
+					log.Printf("replica %s is back in service", r.host)
+				} else {
+					log.Printf("replica %s is out of service", r.host)
+				}
+			}
+		}(r)
+	}
+	wg.Wait()
+}
+
+// close : 全てのレプリカの接続のクローズ
+func (s *replicaSet) close() {
+	for _, r := range s.replicas {
+		r.db.Close()
+	}
+}
@@ -0,0 +1,82 @@
+package rdb
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// session : 読み取りの振り分けに使用するリクエストの情報
+type session struct {
+	key   string      // 書き込みを記録するキー（ユーザーIDなど。空の場合はリクエスト内のみ）
+	wrote atomic.Bool // リクエスト内で書き込んだかどうか
+}
+
+// sessionKey : sessionをコンテキストに格納するためのキー
+type sessionKey struct{}
+
+// WithSession : 書き込み後の読み取りをプライマリに振り分けるためのセッションをコンテキストに設定
+// 同じリクエスト内で書き込んだ後の読み取りと、同じkeyで一定期間内に書き込んだ後の読み取りはプライマリで行う
+func WithSession(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, &session{key: key})
+}
+
+// writeTracker : キーごとの直近の書き込み日時の記録
+type writeTracker struct {
+	window time.Duration
+	mu     sync.Mutex
+	writes map[string]time.Time
+}
+
+// newWriteTracker : writeTrackerの生成
+func newWriteTracker(window time.Duration) *writeTracker {
+	return &writeTracker{window: window, writes: make(map[string]time.Time)}
+}
+
+// markWrite : コンテキストのセッションでの書き込みの記録
+func (t *writeTracker) markWrite(ctx context.Context) {
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	if !ok {
+		return
+	}
+	s.wrote.Store(true)
+
+	if s.key == "" || t.window <= 0 {
+		return
+	}
+	t.mu.Lock()
+	t.writes[s.key] = time.Now()
+	t.mu.Unlock()
+}
+
+// recentlyWrote : コンテキストのセッションで直近に書き込んだかどうか
+func (t *writeTracker) recentlyWrote(ctx context.Context) bool {
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	if !ok {
+		return false
+	}
+	if s.wrote.Load() {
+		return true
+	}
+
+	if s.key == "" || t.window <= 0 {
+		return false
+	}
+	t.mu.Lock()
+	wroteAt, ok := t.writes[s.key]
+	t.mu.Unlock()
+	return ok && time.Since(wroteAt) < t.window
+}
+
+// prune : 期間を過ぎた書き込みの記録の削除
+func (t *writeTracker) prune() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, wroteAt := range t.writes {
+		if time.Since(wroteAt) >= t.window {
+			delete(t.writes, key)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package rdb
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/jmoiron/sqlx"
+)
+
+// Driver : データベースの種類
+type Driver string
+
+const (
+	// DriverMySQL : MySQL
+	DriverMySQL Driver = "mysql"
+	// DriverSQLite : SQLite（単一ノードでの運用とテスト用。レプリカは使用できない）
+	DriverSQLite Driver = "sqlite"
+)
+
+// SQLiteBusyTimeout : 他の接続が書き込みロックを保持している場合に解放を待つ時間
+const SQLiteBusyTimeout = 5 * time.Second
+
+// memoryPath : メモリ上のSQLiteデータベースを示すパス
+const memoryPath = ":memory:"
+
+func init() {
+	// sqlxが名前付きパラメータを「?」に置き換えるようにする
+	sqlx.BindDriver(string(DriverSQLite), sqlx.QUESTION)
+}
+
+// sqliteDSN : SQLiteのDSNの構築
+// 外部キー制約を有効にし、書き込みが競合した場合はロックの解放を待つ
+// トランザクションは開始時に書き込みロックを取得し、途中でのロックの昇格による失敗を防ぐ
+func (c Config) sqliteDSN() string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", SQLiteBusyTimeout.Milliseconds()))
+	if c.Path != memoryPath {
+		params.Add("_pragma", "journal_mode(WAL)")
+	}
+	params.Set("_txlock", "immediate")
+	params.Set("_time_format", "sqlite")
+	return fmt.Sprintf("file:%s?%s", c.Path, params.Encode())
+}
+
+// openSQLite : SQLiteのDBの生成
+func openSQLite(config Config) (*DB, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("sqlite database path is required")
+	}
+	if len(config.ReplicaHosts) > 0 {
+		return nil, fmt.Errorf("sqlite does not support replicas")
+	}
+
+	location, err := time.LoadLocation(config.Location)
+	if err != nil {
+		return nil, fmt.Errorf("unknown location: %s: %w", config.Location, err)
+	}
+
+	// メモリ上のデータベースは接続ごとに別のデータベースになるため、1つの接続を使い続ける
+	if config.Path == memoryPath {
+		config.MaxOpenConns = 1
+		config.MaxIdleConns = 1
+		config.ConnMaxLifetime = 0
+	}
+
+	db, err := openPool(string(DriverSQLite), config, config.sqliteDSN())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	return &DB{
+		driver:   DriverSQLite,
+		location: location,
+		db:       db,
+		replicas: &replicaSet{policy: ReadPolicyRoundRobin},
+		writes:   newWriteTracker(0),
+		done:     make(chan struct{}),
+	}, nil
+}
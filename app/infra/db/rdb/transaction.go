@@ -0,0 +1,223 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"expvar"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TransactionManager : トランザクション管理インターフェース
+type TransactionManager interface {
+	// Transaction : fnをトランザクション内で実行する（fnがエラーを返した場合はロールバック）
+	// コンテキストに既にトランザクションがある場合はセーブポイントを作成し、エラー時はセーブポイントまで戻す
+	// デッドロックとロック待ちのタイムアウトで失敗した場合はロールバックしてfnを最初から再実行するため、
+	// fnはトランザクション外への副作用（外部への通知、メモリ上の状態の変更など）を持たないこと。副作用はTransactionの戻り後に行う
+	Transaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error
+}
+
+// TxRetryPolicy : デッドロック等で失敗したトランザクションの再実行方法
+type TxRetryPolicy struct {
+	MaxRetries     int           // 最大再実行回数（0の場合は再実行しない）
+	InitialBackoff time.Duration // 初回の再実行までの待機時間の上限（再実行のたびに2倍にする）
+	MaxBackoff     time.Duration // 再実行までの待機時間の上限
+}
+
+// DefaultTxRetryPolicy : デフォルトの再実行方法
+var DefaultTxRetryPolicy = TxRetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 10 * time.Millisecond,
+	MaxBackoff:     200 * time.Millisecond,
+}
+
+// backoff : retry回目の再実行までの待機時間を返す
+// 同時に失敗したトランザクションが同時に再実行して再び衝突しないよう、0から上限までの乱数とする
+func (p TxRetryPolicy) backoff(retry int) time.Duration {
+	limit := p.InitialBackoff
+	for i := 1; i < retry && limit < p.MaxBackoff; i++ {
+		limit *= 2
+	}
+	if limit > p.MaxBackoff {
+		limit = p.MaxBackoff
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(limit) + 1))
+}
+
+// txMetrics : トランザクションの再実行の統計（/debug/vars の rdb_transactions で参照できる）
+// retries: 再実行した回数, retried_commits: 再実行後にコミットできた回数, retries_exhausted: 再実行の上限に達して失敗した回数,
+// deadlock / lock_wait_timeout: 原因ごとの再実行した回数
+var txMetrics = expvar.NewMap("rdb_transactions")
+
+// txOptions : トランザクションの設定
+type txOptions struct {
+	isolation   sql.IsolationLevel
+	readOnly    bool
+	retryPolicy *TxRetryPolicy
+}
+
+// TxOption : トランザクションの設定（最も外側のトランザクションにのみ適用される）
+type TxOption func(*txOptions)
+
+// WithIsolation : トランザクション分離レベルの指定（省略時はデータベースの既定値）
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *txOptions) {
+		o.isolation = level
+	}
+}
+
+// ReadOnly : 読み取り専用トランザクションの指定（レプリカがあればレプリカで実行する）
+func ReadOnly() TxOption {
+	return func(o *txOptions) {
+		o.readOnly = true
+	}
+}
+
+// WithRetryPolicy : デッドロック等で失敗した場合の再実行方法の指定（省略時はTransactionManagerの設定）
+func WithRetryPolicy(policy TxRetryPolicy) TxOption {
+	return func(o *txOptions) {
+		o.retryPolicy = &policy
+	}
+}
+
+// txState : 実行中のトランザクション
+type txState struct {
+	tx    *sqlx.Tx
+	depth int // 入れ子の深さ（最も外側のトランザクションは0）
+}
+
+// トランザクションをコンテキストに格納するためのキー
+type txKey struct{}
+
+// GetTx : コンテキストからトランザクションを取得
+func GetTx(ctx context.Context) (*sqlx.Tx, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return nil, false
+	}
+	return state.tx, true
+}
+
+// DefaultTransactionManager : デフォルトのトランザクション管理実装
+type DefaultTransactionManager struct {
+	db          *DB
+	retryPolicy TxRetryPolicy
+}
+
+// NewDefaultTransactionManager : DefaultTransactionManagerの生成（DefaultTxRetryPolicyで再実行する）
+func NewDefaultTransactionManager(db *DB) TransactionManager {
+	return &DefaultTransactionManager{db: db, retryPolicy: DefaultTxRetryPolicy}
+}
+
+// Transaction : トランザクションを実行
+// 最も外側のトランザクションのみ再実行する（入れ子のトランザクションのエラーは外側に返し、外側で最初から再実行する）
+func (tm *DefaultTransactionManager) Transaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	if parent, ok := ctx.Value(txKey{}).(*txState); ok {
+		return tm.savepoint(ctx, parent, fn)
+	}
+
+	options := txOptions{retryPolicy: &tm.retryPolicy}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	policy := *options.retryPolicy
+
+	for retry := 0; ; retry++ {
+		err := tm.run(ctx, options, fn)
+		if err == nil {
+			if retry > 0 {
+				txMetrics.Add("retried_commits", 1)
+			}
+			return nil
+		}
+
+		reason, ok := retryableTxReason(err)
+		if !ok {
+			return err
+		}
+		if retry >= policy.MaxRetries {
+			if policy.MaxRetries > 0 {
+				txMetrics.Add("retries_exhausted", 1)
+			}
+			return err
+		}
+
+		txMetrics.Add("retries", 1)
+		txMetrics.Add(reason, 1)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(policy.backoff(retry + 1)):
+		}
+	}
+}
+
+// run : トランザクションを1回実行する
+func (tm *DefaultTransactionManager) run(ctx context.Context, options txOptions, fn func(ctx context.Context) error) error {
+	// 読み取り専用でなければプライマリで実行する
+	var conn *sqlx.DB
+	if options.readOnly {
+		conn = tm.db.Read(ctx)
+	} else {
+		conn = tm.db.Write(ctx)
+	}
+
+	// コンテキストがキャンセルされた場合はデータベース側でロールバックされる
+	tx, err := conn.BeginTxx(ctx, &sql.TxOptions{Isolation: options.isolation, ReadOnly: options.readOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// トランザクションをコンテキストに設定
+	txCtx := context.WithValue(ctx, txKey{}, &txState{tx: tx})
+
+	// 関数実行
+	if err := fn(txCtx); err != nil {
+		// エラー発生時はロールバック
+		if rbErr := tx.Rollback(); rbErr != nil && rbErr != sql.ErrTxDone {
+			return fmt.Errorf("failed to rollback transaction: %w (original error: %v)", rbErr, err)
+		}
+		return err
+	}
+
+	// コミット
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// savepoint : 実行中のトランザクション内でセーブポイントを作成してfnを実行する
+func (tm *DefaultTransactionManager) savepoint(ctx context.Context, parent *txState, fn func(ctx context.Context) error) error {
+	state := &txState{tx: parent.tx, depth: parent.depth + 1}
+	name := fmt.Sprintf("sp_%d", state.depth)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		// デッドロック等ではトランザクション全体がロールバックされるため、外側で最初から再実行する
+		if IsRetryableTx(err) {
+			return err
+		}
+		// エラー発生時はセーブポイントまで戻す（外側のトランザクションは継続できる）
+		if _, rbErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return fmt.Errorf("failed to rollback to savepoint: %w (original error: %v)", rbErr, err)
+		}
+		return err
+	}
+
+	if _, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+	return nil
+}
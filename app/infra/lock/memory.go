@@ -0,0 +1,71 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryLock はメモリ上に保持するロック
+type memoryLock struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// MemoryStore はメモリ上にロックを保持するロックの保存先（単一プロセスでの実行やテスト用）
+type MemoryStore struct {
+	mu    sync.Mutex
+	locks map[string]memoryLock
+	now   func() time.Time
+}
+
+// NewMemoryStore はMemoryStoreのコンストラクタ
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		locks: make(map[string]memoryLock),
+		now:   time.Now,
+	}
+}
+
+// Acquire はidのロックをownerとしてttlの間取得する（他の所有者が保持している場合はfalse）
+func (s *MemoryStore) Acquire(ctx context.Context, id string, owner string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if current, ok := s.locks[id]; ok && current.owner != owner && !current.expiresAt.Before(now) {
+		return false, nil
+	}
+
+	s.locks[id] = memoryLock{owner: owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// Renew はownerが保持しているロックの期限を現在からttl後に延長する（保持していない場合はfalse）
+func (s *MemoryStore) Renew(ctx context.Context, id string, owner string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	current, ok := s.locks[id]
+	if !ok || current.owner != owner || current.expiresAt.Before(now) {
+		return false, nil
+	}
+
+	s.locks[id] = memoryLock{owner: owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// Release はownerが保持しているロックを解放する（保持していない場合はfalse）
+func (s *MemoryStore) Release(ctx context.Context, id string, owner string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.locks[id]
+	if !ok || current.owner != owner {
+		return false, nil
+	}
+
+	delete(s.locks, id)
+	return true, nil
+}
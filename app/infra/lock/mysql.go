@@ -0,0 +1,86 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"myblog/app/infra/db/rdb"
+)
+
+// MySQLStore はlocksテーブルを使用するロックの保存先
+// 期限の判定はデータベースの時刻で行うため、プロセス間の時刻のずれの影響を受けない
+type MySQLStore struct {
+	db *rdb.DB
+}
+
+// NewMySQLStore はMySQLStoreのコンストラクタ
+func NewMySQLStore(db *rdb.DB) *MySQLStore {
+	return &MySQLStore{
+		db: db,
+	}
+}
+
+// Acquire はidのロックをownerとしてttlの間取得する（他の所有者が保持している場合はfalse）
+func (s *MySQLStore) Acquire(ctx context.Context, id string, owner string, ttl time.Duration) (bool, error) {
+	// 期限切れのロックのみ所有者を置き換える
+	// ON DUPLICATE KEY UPDATEの代入は左から順に評価されるため、expires_atの判定には置き換え後のownerを使用する
+	query := `
+		INSERT INTO locks (id, owner, expires_at)
+		VALUES (?, ?, NOW(6) + INTERVAL ? MICROSECOND)
+		ON DUPLICATE KEY UPDATE
+			owner = IF(expires_at < NOW(6), VALUES(owner), owner),
+			expires_at = IF(owner = VALUES(owner), VALUES(expires_at), expires_at)
+	`
+
+	db := s.db.Write(ctx)
+	if _, err := db.ExecContext(ctx, query, id, owner, ttl.Microseconds()); err != nil {
+		return false, err
+	}
+
+	var current string
+	err := db.GetContext(ctx, &current, "SELECT owner FROM locks WHERE id = ?", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return current == owner, nil
+}
+
+// Renew はownerが保持しているロックの期限を現在からttl後に延長する（保持していない場合はfalse）
+func (s *MySQLStore) Renew(ctx context.Context, id string, owner string, ttl time.Duration) (bool, error) {
+	query := `
+		UPDATE locks
+		SET expires_at = NOW(6) + INTERVAL ? MICROSECOND
+		WHERE id = ? AND owner = ? AND expires_at >= NOW(6)
+	`
+
+	result, err := s.db.Write(ctx).ExecContext(ctx, query, ttl.Microseconds(), id, owner)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Release はownerが保持しているロックを解放する（保持していない場合はfalse）
+func (s *MySQLStore) Release(ctx context.Context, id string, owner string) (bool, error) {
+	result, err := s.db.Write(ctx).ExecContext(ctx, "DELETE FROM locks WHERE id = ? AND owner = ?", id, owner)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
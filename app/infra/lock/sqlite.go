@@ -0,0 +1,88 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"myblog/app/infra/db/rdb"
+)
+
+// SQLiteStore はSQLiteのlocksテーブルを使用するロックの保存先
+// SQLiteは単一ノードで使用するため、期限の判定はプロセスの時刻で行う
+type SQLiteStore struct {
+	db *rdb.DB
+}
+
+// NewSQLiteStore はSQLiteStoreのコンストラクタ
+func NewSQLiteStore(db *rdb.DB) *SQLiteStore {
+	return &SQLiteStore{
+		db: db,
+	}
+}
+
+// Acquire はidのロックをownerとしてttlの間取得する（他の所有者が保持している場合はfalse）
+func (s *SQLiteStore) Acquire(ctx context.Context, id string, owner string, ttl time.Duration) (bool, error) {
+	// 期限切れのロックと自身が保持しているロックのみ置き換える
+	query := `
+		INSERT INTO locks (id, owner, expires_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			owner = excluded.owner,
+			expires_at = excluded.expires_at
+		WHERE locks.expires_at < ? OR locks.owner = excluded.owner
+	`
+
+	now := time.Now()
+	db := s.db.Write(ctx)
+	if _, err := db.ExecContext(ctx, query, id, owner, now.Add(ttl), now); err != nil {
+		return false, err
+	}
+
+	var current string
+	err := db.GetContext(ctx, &current, "SELECT owner FROM locks WHERE id = ?", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return current == owner, nil
+}
+
+// Renew はownerが保持しているロックの期限を現在からttl後に延長する（保持していない場合はfalse）
+func (s *SQLiteStore) Renew(ctx context.Context, id string, owner string, ttl time.Duration) (bool, error) {
+	query := `
+		UPDATE locks
+		SET expires_at = ?
+		WHERE id = ? AND owner = ? AND expires_at >= ?
+	`
+
+	now := time.Now()
+	result, err := s.db.Write(ctx).ExecContext(ctx, query, now.Add(ttl), id, owner, now)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Release はownerが保持しているロックを解放する（保持していない場合はfalse）
+func (s *SQLiteStore) Release(ctx context.Context, id string, owner string) (bool, error) {
+	result, err := s.db.Write(ctx).ExecContext(ctx, "DELETE FROM locks WHERE id = ? AND owner = ?", id, owner)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
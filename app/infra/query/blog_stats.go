@@ -2,6 +2,7 @@ package query
 
 import (
 	"context"
+	"time"
 
 	"myblog/app/infra/db/rdb"
 )
@@ -21,47 +22,86 @@ func NewBlogStats(db *rdb.DB) *BlogStats {
 // BlogRankingData はブログのランキングデータ
 type BlogRankingData struct {
 	BlogID       string
+	UserID       string
+	ViewCount    int
 	CommentCount int
-	TotalScore   int
+	LikeCount    int
+	CreatedAt    time.Time
 }
 
-// GetBlogRankingData は指定期間内のブログランキングデータを取得する
-func (b *BlogStats) GetBlogRankingData(ctx context.Context, days int) ([]BlogRankingData, error) {
+// ForEachBlogRankingData は指定日時以降に閲覧・いいね・コメントのいずれかがあったブログのランキングデータを
+// 1件ずつfnに渡す（sinceがゼロ値の場合は全期間）
+// 全件をメモリ上に保持しないよう、行を読み込みながら処理する。fnがエラーを返した場合は中断する
+// スコアの算出は呼び出し側で行う
+func (b *BlogStats) ForEachBlogRankingData(ctx context.Context, since time.Time, fn func(data BlogRankingData) error) error {
+	// ゼロ値の日時はMySQLで扱えないため、全期間の場合はUNIXエポックを起点とする
+	if since.IsZero() {
+		since = time.Unix(0, 0)
+	}
+	// 日付の列は日付の文字列と比較する（SQLiteのDATE関数はタイムゾーンをUTCに変換するため使用しない）
+	// 日付の境界はプロセスではなくデータベースのタイムゾーンで決める
+	sinceDate := since.In(b.db.Location()).Format("2006-01-02")
+
 	query := `
 		SELECT
 			b.id as blog_id,
+			b.user_id,
+			COALESCE(v.view_count, 0) as view_count,
 			COALESCE(c.comment_count, 0) as comment_count,
-			COALESCE(c.comment_count, 0) as total_score
+			COALESCE(l.like_count, 0) as like_count,
+			b.created_at
 		FROM
-			blogs b
+			(
+				SELECT blog_id FROM comments WHERE created_at >= ?
+				UNION
+				SELECT blog_id FROM blog_likes WHERE created_at >= ?
+				UNION
+				SELECT blog_id FROM blog_view_counts WHERE view_date >= ?
+			) a
+		INNER JOIN blogs b ON b.id = a.blog_id
 		LEFT JOIN (
 			SELECT blog_id, COUNT(*) as comment_count
 			FROM comments
-			WHERE created_at >= DATE_SUB(NOW(), INTERVAL ? DAY)
+			WHERE created_at >= ?
 			GROUP BY blog_id
 		) c ON b.id = c.blog_id
-		ORDER BY total_score DESC
+		LEFT JOIN (
+			SELECT blog_id, COUNT(*) as like_count
+			FROM blog_likes
+			WHERE created_at >= ?
+			GROUP BY blog_id
+		) l ON b.id = l.blog_id
+		LEFT JOIN (
+			SELECT blog_id, SUM(views) as view_count
+			FROM blog_view_counts
+			WHERE view_date >= ?
+			GROUP BY blog_id
+		) v ON b.id = v.blog_id
 	`
 
-	rows, err := b.db.Read(ctx).Query(query, days)
+	rows, err := b.db.Reader(ctx).QueryContext(ctx, query, since, since, sinceDate, since, since, sinceDate)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	var result []BlogRankingData
 	for rows.Next() {
 		var data BlogRankingData
 		err := rows.Scan(
 			&data.BlogID,
+			&data.UserID,
+			&data.ViewCount,
 			&data.CommentCount,
-			&data.TotalScore,
+			&data.LikeCount,
+			&data.CreatedAt,
 		)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
 		}
-		result = append(result, data)
 	}
 
-	return result, nil
+	return rows.Err()
 }
@@ -0,0 +1,306 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"myblog/app/domain/model/ranking"
+	"myblog/app/infra/db/rdb"
+)
+
+// RankingList は公開用のランキング一覧を取得するクエリ
+type RankingList struct {
+	db *rdb.DB
+}
+
+// NewRankingList はRankingListのコンストラクタ
+func NewRankingList(db *rdb.DB) *RankingList {
+	return &RankingList{
+		db: db,
+	}
+}
+
+// excerptLength はランキングに含めるブログ本文の抜粋の文字数
+const excerptLength = 200
+
+// RankedBlog はランキング上のブログの概要
+type RankedBlog struct {
+	Position         int
+	PreviousPosition *int // 前回集計時の順位（前回ランク外の場合はnil）
+	Score            float64
+	BlogID           string
+	Title            string
+	Excerpt          string
+	UserID           string
+	AuthorName       string
+	CreatedAt        time.Time
+}
+
+// Movement は前回集計時からの順位の変動を返す（上昇は正、下降は負。前回ランク外の場合はnil）
+func (r RankedBlog) Movement() *int {
+	if r.PreviousPosition == nil {
+		return nil
+	}
+	movement := *r.PreviousPosition - r.Position
+	return &movement
+}
+
+// PopularRanking は人気記事ランキング
+type PopularRanking struct {
+	Type         ranking.Type
+	CalculatedAt *time.Time // ランキングが集計された日時（未集計の場合はnil）
+	Blogs        []RankedBlog
+}
+
+// GetPopularRanking は指定した種別の最新の公開済み人気記事ランキングを上位limit件、ブログと著者の情報付きで取得する
+// 前回のスナップショットでの順位も合わせて取得する
+func (q *RankingList) GetPopularRanking(ctx context.Context, rankingType ranking.Type, limit int) (*PopularRanking, error) {
+	query := `
+		WITH snapshots AS (
+			SELECT
+				id,
+				calculated_at,
+				ROW_NUMBER() OVER (ORDER BY calculated_at DESC) AS generation
+			FROM ranking_snapshots
+			WHERE ranking_type = ? AND published_at IS NOT NULL
+		)
+		SELECT
+			e.ranking_position,
+			pe.ranking_position,
+			e.score,
+			b.id,
+			b.title,
+			SUBSTR(b.content, 1, ?) as excerpt,
+			u.id,
+			u.username,
+			b.created_at,
+			s.calculated_at
+		FROM
+			snapshots s
+		INNER JOIN ranking_entries e ON e.snapshot_id = s.id
+		INNER JOIN blogs b ON b.id = e.blog_id
+		INNER JOIN users u ON u.id = b.user_id
+		LEFT JOIN snapshots ps ON ps.generation = 2
+		LEFT JOIN ranking_entries pe ON pe.snapshot_id = ps.id AND pe.blog_id = e.blog_id
+		WHERE s.generation = 1
+		ORDER BY e.ranking_position ASC
+		LIMIT ?
+	`
+
+	rows, err := q.db.Reader(ctx).QueryContext(ctx, query, string(rankingType), excerptLength, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPopularRanking(rows, rankingType)
+}
+
+// GetAuthorBlogRanking は指定した種別の最新の公開済みランキングから、著者の人気記事ランキングを上位limit件取得する
+// 順位は著者の記事の中での順位で、前回のスナップショットでの順位も合わせて取得する
+func (q *RankingList) GetAuthorBlogRanking(ctx context.Context, userID string, rankingType ranking.Type, limit int) (*PopularRanking, error) {
+	query := `
+		WITH snapshots AS (
+			SELECT
+				id,
+				calculated_at,
+				ROW_NUMBER() OVER (ORDER BY calculated_at DESC) AS generation
+			FROM ranking_snapshots
+			WHERE ranking_type = ? AND published_at IS NOT NULL
+		)
+		SELECT
+			e.ranking_position,
+			pe.ranking_position,
+			e.score,
+			b.id,
+			b.title,
+			SUBSTR(b.content, 1, ?) as excerpt,
+			u.id,
+			u.username,
+			b.created_at,
+			s.calculated_at
+		FROM
+			snapshots s
+		INNER JOIN ranking_author_blog_entries e ON e.snapshot_id = s.id
+		INNER JOIN blogs b ON b.id = e.blog_id
+		INNER JOIN users u ON u.id = e.user_id
+		LEFT JOIN snapshots ps ON ps.generation = 2
+		LEFT JOIN ranking_author_blog_entries pe ON pe.snapshot_id = ps.id AND pe.blog_id = e.blog_id
+		WHERE s.generation = 1 AND e.user_id = ?
+		ORDER BY e.ranking_position ASC
+		LIMIT ?
+	`
+
+	rows, err := q.db.Reader(ctx).QueryContext(ctx, query, string(rankingType), excerptLength, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPopularRanking(rows, rankingType)
+}
+
+// scanPopularRanking はランキングのクエリ結果を読み込む
+func scanPopularRanking(rows *sql.Rows, rankingType ranking.Type) (*PopularRanking, error) {
+	result := &PopularRanking{Type: rankingType}
+	for rows.Next() {
+		var data RankedBlog
+		var previousPosition sql.NullInt64
+		var calculatedAt time.Time
+		err := rows.Scan(
+			&data.Position,
+			&previousPosition,
+			&data.Score,
+			&data.BlogID,
+			&data.Title,
+			&data.Excerpt,
+			&data.UserID,
+			&data.AuthorName,
+			&data.CreatedAt,
+			&calculatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if previousPosition.Valid {
+			position := int(previousPosition.Int64)
+			data.PreviousPosition = &position
+		}
+		result.CalculatedAt = &calculatedAt
+		result.Blogs = append(result.Blogs, data)
+	}
+
+	return result, rows.Err()
+}
+
+// RankedAuthor は著者ランキング上の著者の概要
+type RankedAuthor struct {
+	Position         int
+	PreviousPosition *int // 前回集計時の順位（前回ランク外の場合はnil）
+	Score            float64
+	BlogCount        int
+	UserID           string
+	Username         string
+}
+
+// Movement は前回集計時からの順位の変動を返す（上昇は正、下降は負。前回ランク外の場合はnil）
+func (r RankedAuthor) Movement() *int {
+	if r.PreviousPosition == nil {
+		return nil
+	}
+	movement := *r.PreviousPosition - r.Position
+	return &movement
+}
+
+// AuthorRanking は著者ランキング
+type AuthorRanking struct {
+	Type         ranking.Type
+	CalculatedAt *time.Time // ランキングが集計された日時（未集計の場合はnil）
+	Authors      []RankedAuthor
+}
+
+// GetAuthorRanking は指定した種別の最新の公開済み著者ランキングを上位limit件、著者の情報付きで取得する
+// 前回のスナップショットでの順位も合わせて取得する
+func (q *RankingList) GetAuthorRanking(ctx context.Context, rankingType ranking.Type, limit int) (*AuthorRanking, error) {
+	query := `
+		WITH snapshots AS (
+			SELECT
+				id,
+				calculated_at,
+				ROW_NUMBER() OVER (ORDER BY calculated_at DESC) AS generation
+			FROM ranking_snapshots
+			WHERE ranking_type = ? AND published_at IS NOT NULL
+		)
+		SELECT
+			e.ranking_position,
+			pe.ranking_position,
+			e.score,
+			e.blog_count,
+			u.id,
+			u.username,
+			s.calculated_at
+		FROM
+			snapshots s
+		INNER JOIN ranking_author_entries e ON e.snapshot_id = s.id
+		INNER JOIN users u ON u.id = e.user_id
+		LEFT JOIN snapshots ps ON ps.generation = 2
+		LEFT JOIN ranking_author_entries pe ON pe.snapshot_id = ps.id AND pe.user_id = e.user_id
+		WHERE s.generation = 1
+		ORDER BY e.ranking_position ASC
+		LIMIT ?
+	`
+
+	rows, err := q.db.Reader(ctx).QueryContext(ctx, query, string(rankingType), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := &AuthorRanking{Type: rankingType}
+	for rows.Next() {
+		var data RankedAuthor
+		var previousPosition sql.NullInt64
+		var calculatedAt time.Time
+		err := rows.Scan(
+			&data.Position,
+			&previousPosition,
+			&data.Score,
+			&data.BlogCount,
+			&data.UserID,
+			&data.Username,
+			&calculatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if previousPosition.Valid {
+			position := int(previousPosition.Int64)
+			data.PreviousPosition = &position
+		}
+		result.CalculatedAt = &calculatedAt
+		result.Authors = append(result.Authors, data)
+	}
+
+	return result, rows.Err()
+}
+
+// RankingHistoryPoint はあるスナップショットでのブログの順位
+type RankingHistoryPoint struct {
+	CalculatedAt time.Time
+	Position     int
+	Score        float64
+}
+
+// GetBlogRankingHistory は指定日時以降のスナップショットにおけるブログの順位の推移を取得する（集計日時の昇順）
+func (q *RankingList) GetBlogRankingHistory(ctx context.Context, blogID string, rankingType ranking.Type, since time.Time) ([]RankingHistoryPoint, error) {
+	query := `
+		SELECT
+			s.calculated_at,
+			e.ranking_position,
+			e.score
+		FROM
+			ranking_snapshots s
+		INNER JOIN ranking_entries e ON e.snapshot_id = s.id
+		WHERE
+			s.ranking_type = ? AND e.blog_id = ? AND s.calculated_at >= ? AND s.published_at IS NOT NULL
+		ORDER BY s.calculated_at ASC
+	`
+
+	rows, err := q.db.Reader(ctx).QueryContext(ctx, query, string(rankingType), blogID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []RankingHistoryPoint
+	for rows.Next() {
+		var data RankingHistoryPoint
+		if err := rows.Scan(&data.CalculatedAt, &data.Position, &data.Score); err != nil {
+			return nil, err
+		}
+		result = append(result, data)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,302 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"myblog/app/domain/model/ranking"
+	"myblog/app/infra/dao"
+	"myblog/app/infra/db/rdb"
+	"myblog/app/infra/query"
+	"myblog/app/testing/e2e"
+	"myblog/app/usecase"
+)
+
+// newTestHarness : マイグレーション済みの空のデータベースに接続したサーバーのハーネスの生成
+func newTestHarness(t *testing.T) (*e2e.Harness, *rdb.DB) {
+	t.Helper()
+
+	db := newTestDB(t)
+	return e2e.New(t, newTestServer(t, newTestConfig(t), db)), db
+}
+
+// createBlog : ブログの作成（IDはスナップショットで<blog:label>と表記する）
+func createBlog(h *e2e.Harness, author *e2e.User, label, title, content string) string {
+	res := h.Expect(h.Do(http.MethodPost, "/api/blogs", author.Token, map[string]string{
+		"title":   title,
+		"content": content,
+	}), http.StatusCreated)
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	h.Decode(res, &created)
+	h.Label(created.ID, "blog:"+label)
+	return created.ID
+}
+
+// createComment : コメントの作成（IDはスナップショットで<comment:label>と表記する）
+func createComment(h *e2e.Harness, author *e2e.User, blogID, label, content string) string {
+	res := h.Expect(h.Do(http.MethodPost, "/api/blogs/"+blogID+"/comments", author.Token, map[string]string{
+		"content": content,
+	}), http.StatusCreated)
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	h.Decode(res, &created)
+	h.Label(created.ID, "comment:"+label)
+	return created.ID
+}
+
+// disconnected : 切断済みのリクエスト（ストリーミング配信は再送される履歴を書き込んだ後に終了する）
+func disconnected(req *http.Request) *http.Request {
+	ctx, cancel := context.WithCancel(req.Context())
+	cancel()
+	return req.WithContext(ctx)
+}
+
+// lastStreamEventID : ストリーミング配信のレスポンスの最後のイベントID
+func lastStreamEventID(t *testing.T, res *e2e.Response) string {
+	t.Helper()
+
+	var id string
+	for _, line := range strings.Split(string(res.Body), "\n") {
+		if v, ok := strings.CutPrefix(line, "id: "); ok {
+			id = v
+		}
+	}
+	if id == "" {
+		t.Fatalf("イベントが含まれていません:\n%s", res.Body)
+	}
+	return id
+}
+
+func TestE2E_Users(t *testing.T) {
+	h, _ := newTestHarness(t)
+
+	h.Snapshot("health", h.Do(http.MethodGet, "/health", "", nil))
+
+	h.Snapshot("register", h.Do(http.MethodPost, "/api/users/register", "", map[string]string{
+		"username": "alice",
+		"email":    "alice@example.com",
+		"password": e2e.Password,
+	}))
+	h.Snapshot("register_duplicate_email", h.Do(http.MethodPost, "/api/users/register", "", map[string]string{
+		"username": "alice2",
+		"email":    "alice@example.com",
+		"password": e2e.Password,
+	}))
+	h.Snapshot("register_invalid_body", h.Do(http.MethodPost, "/api/users/register", "", "not an object"))
+
+	h.Snapshot("login", h.Do(http.MethodPost, "/api/users/login", "", map[string]string{
+		"email":    "alice@example.com",
+		"password": e2e.Password,
+	}))
+	h.Snapshot("login_wrong_password", h.Do(http.MethodPost, "/api/users/login", "", map[string]string{
+		"email":    "alice@example.com",
+		"password": "wrong-password",
+	}))
+
+	alice := &e2e.User{Username: "alice", Email: "alice@example.com", Token: h.Login("alice@example.com", e2e.Password)}
+	bob := h.Register("bob")
+
+	h.Snapshot("get_user_unauthorized", h.Do(http.MethodGet, "/api/users/"+bob.ID, "", nil))
+	h.Snapshot("get_user", h.Do(http.MethodGet, "/api/users/"+bob.ID, bob.Token, nil))
+	h.Snapshot("get_other_user", h.Do(http.MethodGet, "/api/users/"+bob.ID, alice.Token, nil))
+
+	h.Snapshot("update_user", h.Do(http.MethodPut, "/api/users/"+bob.ID, bob.Token, map[string]string{
+		"username": "robert",
+		"email":    "robert@example.com",
+		"password": "new-password123",
+	}))
+	h.Snapshot("login_after_update", h.Do(http.MethodPost, "/api/users/login", "", map[string]string{
+		"email":    "robert@example.com",
+		"password": "new-password123",
+	}))
+
+	h.Snapshot("delete_user", h.Do(http.MethodDelete, "/api/users/"+bob.ID, bob.Token, nil))
+	h.Snapshot("login_after_delete", h.Do(http.MethodPost, "/api/users/login", "", map[string]string{
+		"email":    "robert@example.com",
+		"password": "new-password123",
+	}))
+}
+
+func TestE2E_Blogs(t *testing.T) {
+	h, _ := newTestHarness(t)
+	alice := h.Register("alice")
+	bob := h.Register("bob")
+
+	h.Snapshot("create_blog", h.Do(http.MethodPost, "/api/blogs", alice.Token, map[string]string{
+		"title":   "First post",
+		"content": "Hello, world",
+	}))
+	h.Snapshot("create_blog_without_title", h.Do(http.MethodPost, "/api/blogs", alice.Token, map[string]string{
+		"title":   "",
+		"content": "no title",
+	}))
+
+	first := createBlog(h, alice, "first", "Second post", "Thanks for reading")
+	createBlog(h, bob, "bob", "Bob's post", "Hi from bob")
+
+	h.Snapshot("get_all_blogs", h.Do(http.MethodGet, "/api/blogs", alice.Token, nil))
+	h.Snapshot("get_all_blogs_second_page", h.Do(http.MethodGet, "/api/blogs?page=1&per_page=2", alice.Token, nil))
+	h.Snapshot("get_user_blogs", h.Do(http.MethodGet, "/api/users/"+alice.ID+"/blogs", bob.Token, nil))
+	h.Snapshot("get_blog", h.Do(http.MethodGet, "/api/blogs/"+first, bob.Token, nil))
+	h.Snapshot("get_blog_not_found", h.Do(http.MethodGet, "/api/blogs/00000000-0000-0000-0000-000000000000", bob.Token, nil))
+
+	h.Snapshot("update_blog", h.Do(http.MethodPut, "/api/blogs/"+first, alice.Token, map[string]string{
+		"title":   "Second post (edited)",
+		"content": "Thanks for reading, @bob",
+	}))
+	h.Snapshot("update_blog_by_other_user", h.Do(http.MethodPut, "/api/blogs/"+first, bob.Token, map[string]string{
+		"title":   "Hijacked",
+		"content": "Hijacked",
+	}))
+
+	h.Snapshot("delete_blog_by_other_user", h.Do(http.MethodDelete, "/api/blogs/"+first, bob.Token, nil))
+	h.Snapshot("delete_blog", h.Do(http.MethodDelete, "/api/blogs/"+first, alice.Token, nil))
+	h.Snapshot("get_deleted_blog", h.Do(http.MethodGet, "/api/blogs/"+first, alice.Token, nil))
+}
+
+func TestE2E_Comments(t *testing.T) {
+	h, db := newTestHarness(t)
+	alice := h.Register("alice")
+	bob := h.Register("bob")
+	blogID := createBlog(h, alice, "alice", "Alice's post", "Comments welcome")
+
+	h.Snapshot("create_comment", h.Do(http.MethodPost, "/api/blogs/"+blogID+"/comments", bob.Token, map[string]string{
+		"content": "Nice post, @alice",
+	}))
+	h.Snapshot("create_comment_empty", h.Do(http.MethodPost, "/api/blogs/"+blogID+"/comments", bob.Token, map[string]string{
+		"content": "",
+	}))
+	commentID := createComment(h, alice, blogID, "reply", "Thanks!")
+
+	h.Snapshot("get_blog_comments", h.Do(http.MethodGet, "/api/blogs/"+blogID+"/comments", bob.Token, nil))
+
+	h.Snapshot("update_comment", h.Do(http.MethodPut, "/api/comments/"+commentID, alice.Token, map[string]string{
+		"content": "Thanks, @bob!",
+	}))
+	h.Snapshot("update_comment_by_other_user", h.Do(http.MethodPut, "/api/comments/"+commentID, bob.Token, map[string]string{
+		"content": "Hijacked",
+	}))
+
+	// 接続用チケットを発行し、切断済みのリクエストで接続して再送される履歴のみを記録する（イベントIDは起動時刻から採番されるため1以降の全て）
+	res := h.Do(http.MethodPost, "/api/stream/tickets", bob.Token, nil)
+	h.Snapshot("issue_stream_ticket", res)
+	var issued struct {
+		Ticket string `json:"ticket"`
+	}
+	h.Decode(h.Expect(res, http.StatusCreated), &issued)
+
+	target := "/api/stream?blogs=" + blogID + "&last_event_id=1&ticket=" + issued.Ticket
+	res = h.DoRequest(disconnected(h.NewRequest(http.MethodGet, target, "", nil)))
+	h.Snapshot("stream_replay", res)
+	lastEventID := lastStreamEventID(t, res)
+
+	// 切断中に投稿されたコメントは、同じチケットとLast-Event-IDで再接続すると受信できる（EventSourceの自動再接続と同じ）
+	createComment(h, alice, blogID, "missed", "Posted while disconnected")
+	req := h.NewRequest(http.MethodGet, "/api/stream?blogs="+blogID+"&ticket="+issued.Ticket, "", nil)
+	req.Header.Set("Last-Event-ID", lastEventID)
+	res = h.DoRequest(disconnected(req))
+	h.Snapshot("stream_reconnect", res)
+	if !strings.Contains(string(res.Body), "Posted while disconnected") || strings.Contains(string(res.Body), "Thanks!") {
+		t.Errorf("再接続時は切断中のイベントのみを受信するはずです:\n%s", res.Body)
+	}
+
+	// チケットはサーバーに保持しないため、発行していない別のAPIプロセス（レプリカ）でも使用できる
+	replica := e2e.New(t, newTestServer(t, newTestConfig(t), db))
+	replica.Expect(replica.DoRequest(disconnected(replica.NewRequest(http.MethodGet, target, "", nil))), http.StatusOK)
+
+	h.Snapshot("stream_token_as_ticket", h.Do(http.MethodGet, "/api/stream?ticket="+bob.Token, "", nil))
+	h.Snapshot("stream_unauthorized", h.Do(http.MethodGet, "/api/stream", "", nil))
+
+	h.Snapshot("delete_comment_by_other_user", h.Do(http.MethodDelete, "/api/comments/"+commentID, bob.Token, nil))
+	h.Snapshot("delete_comment", h.Do(http.MethodDelete, "/api/comments/"+commentID, alice.Token, nil))
+	h.Snapshot("get_blog_comments_after_delete", h.Do(http.MethodGet, "/api/blogs/"+blogID+"/comments", bob.Token, nil))
+}
+
+func TestE2E_Reactions(t *testing.T) {
+	h, _ := newTestHarness(t)
+	alice := h.Register("alice")
+	bob := h.Register("bob")
+	blogID := createBlog(h, alice, "alice", "Alice's post", "React to this")
+	commentID := createComment(h, bob, blogID, "bob", "First!")
+
+	h.Snapshot("get_emojis", h.Do(http.MethodGet, "/api/reactions/emojis", bob.Token, nil))
+
+	h.Snapshot("add_blog_reaction", h.Do(http.MethodPut, "/api/blogs/"+blogID+"/reactions", bob.Token, map[string]string{"emoji": "🎉"}))
+	h.Snapshot("add_blog_reaction_unknown_emoji", h.Do(http.MethodPut, "/api/blogs/"+blogID+"/reactions", bob.Token, map[string]string{"emoji": "🦄"}))
+	h.Snapshot("remove_blog_reaction", h.Do(http.MethodDelete, "/api/blogs/"+blogID+"/reactions", bob.Token, nil))
+
+	h.Snapshot("add_comment_reaction", h.Do(http.MethodPut, "/api/comments/"+commentID+"/reactions", alice.Token, map[string]string{"emoji": "👍"}))
+	h.Snapshot("remove_comment_reaction", h.Do(http.MethodDelete, "/api/comments/"+commentID+"/reactions", alice.Token, nil))
+
+	h.Snapshot("like_blog", h.Do(http.MethodPost, "/api/blogs/"+blogID+"/like", bob.Token, nil))
+	h.Snapshot("like_blog_again", h.Do(http.MethodPost, "/api/blogs/"+blogID+"/like", bob.Token, nil))
+	h.Snapshot("get_blog_liked", h.Do(http.MethodGet, "/api/blogs/"+blogID, bob.Token, nil))
+	h.Snapshot("unlike_blog", h.Do(http.MethodDelete, "/api/blogs/"+blogID+"/like", bob.Token, nil))
+}
+
+func TestE2E_Notifications(t *testing.T) {
+	h, _ := newTestHarness(t)
+	alice := h.Register("alice")
+	bob := h.Register("bob")
+	blogID := createBlog(h, alice, "alice", "Alice's post", "Hello @bob")
+	createComment(h, bob, blogID, "mention", "Hi @alice")
+	createComment(h, bob, blogID, "plain", "Nice post")
+
+	h.Snapshot("get_notifications", h.Do(http.MethodGet, "/api/notifications", alice.Token, nil))
+	h.Snapshot("get_notifications_first_page", h.Do(http.MethodGet, "/api/notifications?limit=1", alice.Token, nil))
+	h.Snapshot("get_notifications_invalid_cursor", h.Do(http.MethodGet, "/api/notifications?cursor=!!!", alice.Token, nil))
+	h.Snapshot("get_unread_count", h.Do(http.MethodGet, "/api/notifications/unread-count", alice.Token, nil))
+
+	var list struct {
+		Notifications []struct {
+			ID string `json:"id"`
+		} `json:"notifications"`
+	}
+	h.Decode(h.Expect(h.Do(http.MethodGet, "/api/notifications", alice.Token, nil), http.StatusOK), &list)
+	h.Snapshot("mark_read", h.Do(http.MethodPost, "/api/notifications/read", alice.Token, map[string][]string{
+		"ids": {list.Notifications[0].ID},
+	}))
+	h.Snapshot("get_unread_notifications", h.Do(http.MethodGet, "/api/notifications?unread=true", alice.Token, nil))
+	h.Snapshot("mark_all_read", h.Do(http.MethodPost, "/api/notifications/read-all", bob.Token, nil))
+	h.Snapshot("get_unread_count_after_read_all", h.Do(http.MethodGet, "/api/notifications/unread-count", bob.Token, nil))
+
+	h.Snapshot("get_preferences", h.Do(http.MethodGet, "/api/notifications/preferences", alice.Token, nil))
+	h.Snapshot("update_preferences", h.Do(http.MethodPut, "/api/notifications/preferences", alice.Token, map[string]bool{"comment": false}))
+	h.Snapshot("update_preferences_unknown_type", h.Do(http.MethodPut, "/api/notifications/preferences", alice.Token, map[string]bool{"unknown": false}))
+}
+
+func TestE2E_Rankings(t *testing.T) {
+	h, db := newTestHarness(t)
+	alice := h.Register("alice")
+	bob := h.Register("bob")
+	popular := createBlog(h, alice, "popular", "Popular post", "Everyone likes this")
+	createBlog(h, bob, "quiet", "Quiet post", "Nobody likes this")
+
+	h.Snapshot("get_rankings_before_calculation", h.Do(http.MethodGet, "/api/rankings", "", nil))
+
+	h.Expect(h.Do(http.MethodPost, "/api/blogs/"+popular+"/like", alice.Token, nil), http.StatusOK)
+	h.Expect(h.Do(http.MethodPost, "/api/blogs/"+popular+"/like", bob.Token, nil), http.StatusOK)
+
+	// ランキングはバッチで集計するため、バッチと同じユースケースで集計する
+	scorer, err := ranking.NewCountScorer(ranking.DefaultWeights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rankingUseCase := usecase.NewRankingUseCase(dao.NewRankingRepository(db), query.NewBlogStats(db), query.NewRankingList(db))
+	if _, err := rankingUseCase.CalculatePopularRanking(context.Background(), ranking.TypeWeekly, scorer, 10, 10, 3); err != nil {
+		t.Fatalf("ランキングの集計に失敗しました: %v", err)
+	}
+
+	h.Snapshot("get_rankings", h.Do(http.MethodGet, "/api/rankings", "", nil))
+	h.Snapshot("get_rankings_invalid_type", h.Do(http.MethodGet, "/api/rankings?type=hourly", "", nil))
+	h.Snapshot("get_author_rankings", h.Do(http.MethodGet, "/api/rankings/authors", "", nil))
+	h.Snapshot("get_user_rankings", h.Do(http.MethodGet, "/api/users/"+alice.ID+"/rankings", "", nil))
+	h.Snapshot("get_blog_ranking_history", h.Do(http.MethodGet, "/api/blogs/"+popular+"/ranking-history", "", nil))
+}
@@ -0,0 +1,14 @@
+package server
+
+import (
+	"expvar"
+	"net/http"
+)
+
+// newMetricsHandler : メトリクス（トランザクションの再実行回数など）を公開するハンドラーの生成
+// 認証がないため、公開APIのルーターには登録せず内部向けのアドレスでのみ公開する
+func newMetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// options : Serverの設定
+type options struct {
+	middlewares []func(http.Handler) http.Handler
+	routes      []func(r chi.Router)
+}
+
+// Option : Serverの設定
+type Option func(*options)
+
+// WithMiddleware : 全てのルートに適用するミドルウェアの追加（標準のミドルウェアの後に指定した順に適用する）
+func WithMiddleware(middlewares ...func(http.Handler) http.Handler) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// WithRoutes : ルートの追加（fnはルーターの最上位で呼び出され、標準のルートの後に登録する）
+func WithRoutes(fn func(r chi.Router)) Option {
+	return func(o *options) {
+		o.routes = append(o.routes, fn)
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"myblog/app/domain/repository"
+	"myblog/app/infra/dao"
+	"myblog/app/infra/db/rdb"
+	"myblog/app/infra/query"
+)
+
+// Repositories : APIサーバーが使用するリポジトリ・クエリ・トランザクション管理
+type Repositories struct {
+	User                   repository.User
+	Blog                   repository.Blog
+	Comment                repository.Comment
+	Mention                repository.Mention
+	Notification           repository.Notification
+	NotificationPreference repository.NotificationPreference
+	Reaction               repository.Reaction
+	Like                   repository.Like
+	BlogView               repository.BlogView
+	Ranking                repository.RankingRepository
+	Outbox                 repository.Outbox
+
+	BlogStats   *query.BlogStats
+	RankingList *query.RankingList
+
+	Transaction rdb.TransactionManager
+}
+
+// NewRDBRepositories : データベースに接続したRepositoriesの生成
+func NewRDBRepositories(db *rdb.DB) Repositories {
+	return Repositories{
+		User:                   dao.NewUserRepository(db),
+		Blog:                   dao.NewBlogRepository(db),
+		Comment:                dao.NewCommentRepository(db),
+		Mention:                dao.NewMentionRepository(db),
+		Notification:           dao.NewNotificationRepository(db),
+		NotificationPreference: dao.NewNotificationPreferenceRepository(db),
+		Reaction:               dao.NewReactionRepository(db),
+		Like:                   dao.NewLikeRepository(db),
+		BlogView:               dao.NewBlogViewRepository(db),
+		Ranking:                dao.NewRankingRepository(db),
+		Outbox:                 dao.NewOutboxRepository(db),
+		BlogStats:              query.NewBlogStats(db),
+		RankingList:            query.NewRankingList(db),
+		Transaction:            rdb.NewDefaultTransactionManager(db),
+	}
+}
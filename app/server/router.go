@@ -0,0 +1,140 @@
+package server
+
+import (
+	"net/http"
+
+	"myblog/app/config"
+	"myblog/app/ui/http/handler"
+	"myblog/app/ui/http/middleware/auth"
+	"myblog/app/ui/http/middleware/dbsession"
+	"myblog/app/ui/http/middleware/logging"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+)
+
+// handlers : ルートに登録するハンドラー
+type handlers struct {
+	user         *handler.UserHandler
+	blog         *handler.BlogHandler
+	comment      *handler.CommentHandler
+	reaction     *handler.ReactionHandler
+	like         *handler.LikeHandler
+	ranking      *handler.RankingHandler
+	notification *handler.NotificationHandler
+	stream       *handler.StreamHandler
+}
+
+// newRouter : ミドルウェアとルートを登録したルーターの生成
+func newRouter(cfg *config.Config, h *handlers, tickets *auth.Tickets, o *options) http.Handler {
+	jwtSecret := cfg.Auth.JWTSecret
+
+	r := chi.NewRouter()
+
+	// ミドルウェア（追加のミドルウェアは標準のミドルウェアの後に適用する）
+	// 認証情報をアクセスログに出力しないようにクエリパラメータの値を伏せる
+	r.Use(logging.RedactQuery("ticket", "access_token"))
+	r.Use(middleware.Logger)
+	r.Use(middleware.Recoverer)
+	r.Use(cors.Handler(cors.Options{
+		AllowedOrigins:   cfg.Server.CORSAllowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"Link"},
+		AllowCredentials: true,
+		MaxAge:           300,
+	}))
+	for _, mw := range o.middlewares {
+		r.Use(mw)
+	}
+
+	// ヘルスチェック
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	})
+
+	// API ルート
+	r.Route("/api", func(r chi.Router) {
+		// ストリーミング配信（接続を維持するためタイムアウトを適用しない）
+		r.Group(func(r chi.Router) {
+			// EventSourceはヘッダーを設定できないため、JWTの代わりにクエリパラメータのチケットで認証する
+			r.Use(auth.TicketOrJWT(tickets, "ticket", jwtSecret))
+			r.Use(auth.RequireAuth)
+			r.Get("/stream", h.stream.Stream)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Timeout(cfg.Server.RequestTimeout))
+
+			// 認証不要のエンドポイント
+			r.Group(func(r chi.Router) {
+				r.Use(dbsession.ReadYourWrites)
+
+				r.Post("/users/register", h.user.Register)
+				r.Post("/users/login", h.user.Login)
+				r.Get("/rankings", h.ranking.GetRankings)
+				r.Get("/rankings/authors", h.ranking.GetAuthorRankings)
+				r.Get("/users/{id}/rankings", h.ranking.GetUserRankings)
+				r.Get("/blogs/{id}/ranking-history", h.ranking.GetBlogRankingHistory)
+			})
+
+			// 認証が必要なエンドポイント
+			r.Group(func(r chi.Router) {
+				r.Use(auth.JWTMiddleware(jwtSecret))
+				r.Use(auth.RequireAuth)
+				// 認証後に適用し、ユーザーごとに書き込み後の読み取りをプライマリに振り分ける
+				r.Use(dbsession.ReadYourWrites)
+
+				// ユーザー関連
+				r.Get("/users/{id}", h.user.GetUser)
+				r.Put("/users/{id}", h.user.UpdateUser)
+				r.Delete("/users/{id}", h.user.DeleteUser)
+
+				// ブログ関連
+				r.Post("/blogs", h.blog.CreateBlog)
+				r.Get("/blogs", h.blog.GetAllBlogs)
+				r.Get("/blogs/{id}", h.blog.GetBlog)
+				r.Get("/users/{id}/blogs", h.blog.GetUserBlogs)
+				r.Put("/blogs/{id}", h.blog.UpdateBlog)
+				r.Delete("/blogs/{id}", h.blog.DeleteBlog)
+
+				// コメント関連
+				r.Post("/blogs/{id}/comments", h.comment.CreateComment)
+				r.Get("/blogs/{id}/comments", h.comment.GetBlogComments)
+				r.Put("/comments/{id}", h.comment.UpdateComment)
+				r.Delete("/comments/{id}", h.comment.DeleteComment)
+
+				// いいね関連
+				r.Post("/blogs/{id}/like", h.like.LikeBlog)
+				r.Delete("/blogs/{id}/like", h.like.UnlikeBlog)
+
+				// リアクション関連
+				r.Get("/reactions/emojis", h.reaction.GetEmojis)
+				r.Put("/blogs/{id}/reactions", h.reaction.AddBlogReaction)
+				r.Delete("/blogs/{id}/reactions", h.reaction.RemoveBlogReaction)
+				r.Put("/comments/{id}/reactions", h.reaction.AddCommentReaction)
+				r.Delete("/comments/{id}/reactions", h.reaction.RemoveCommentReaction)
+
+				// ストリーミング配信の接続用チケット
+				r.Post("/stream/tickets", h.stream.IssueTicket)
+
+				// 通知関連
+				r.Get("/notifications", h.notification.GetNotifications)
+				r.Get("/notifications/unread-count", h.notification.GetUnreadCount)
+				r.Post("/notifications/read", h.notification.MarkRead)
+				r.Post("/notifications/read-all", h.notification.MarkAllRead)
+				r.Get("/notifications/preferences", h.notification.GetPreferences)
+				r.Put("/notifications/preferences", h.notification.UpdatePreferences)
+			})
+		})
+	})
+
+	// 追加のルート
+	for _, fn := range o.routes {
+		fn(r)
+	}
+
+	return r
+}
@@ -0,0 +1,138 @@
+// Package server : APIサーバー（依存関係の組み立て、ルーティング、起動と停止）
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+
+	"myblog/app/config"
+	"myblog/app/domain/model/reaction"
+	"myblog/app/ui/http/handler"
+	"myblog/app/ui/http/middleware/auth"
+	"myblog/app/usecase"
+)
+
+// Server : APIサーバー
+// Newで閲覧数の記録を開始するため、Startで起動しない場合もStopを呼び出すこと
+type Server struct {
+	handler       http.Handler
+	httpServer    *http.Server
+	metricsServer *http.Server
+	viewRecorder  *usecase.BufferedViewRecorder
+}
+
+// New : 設定とリポジトリからServerを生成
+func New(cfg *config.Config, repos Repositories, opts ...Option) (*Server, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// リアクションに利用できる絵文字
+	emojiSet, err := reaction.NewEmojiSet(cfg.Reaction.Emojis)
+	if err != nil {
+		return nil, fmt.Errorf("REACTION_EMOJIS: %w", err)
+	}
+
+	// 閲覧数の記録（バッファしてまとめて書き込む）
+	viewRecorder := usecase.NewBufferedViewRecorder(repos.BlogView, usecase.DefaultViewRecorderConfig)
+
+	// コメントのストリーミング配信
+	streamHub := usecase.NewStreamHub(usecase.DefaultStreamHubConfig)
+	// ストリーミング配信の接続用チケット（JWTをURLに含めないために使用する）
+	tickets := auth.NewTickets(cfg.Auth.JWTSecret, cfg.Server.StreamTicketTTL)
+
+	// ユースケース
+	userUsecase := usecase.NewUserUsecase(repos.User, repos.Outbox, repos.Transaction, cfg.Auth.JWTSecret)
+	blogUsecase := usecase.NewBlogUsecase(repos.Blog, repos.User, repos.Mention, repos.Notification, repos.NotificationPreference, repos.Outbox, viewRecorder, repos.Transaction)
+	commentUsecase := usecase.NewCommentUsecase(repos.Comment, repos.Blog, repos.User, repos.Mention, repos.Notification, repos.NotificationPreference, repos.Outbox, streamHub, repos.Transaction)
+	mentionUsecase := usecase.NewMentionUsecase(repos.Mention)
+	notificationUsecase := usecase.NewNotificationUsecase(repos.Notification, repos.NotificationPreference)
+	reactionUsecase := usecase.NewReactionUsecase(repos.Reaction, repos.Blog, repos.Comment, emojiSet)
+	likeUsecase := usecase.NewLikeUsecase(repos.Like, repos.Blog)
+	rankingUseCase := usecase.NewRankingUseCase(repos.Ranking, repos.BlogStats, repos.RankingList)
+
+	// ハンドラー
+	h := &handlers{
+		user:         handler.NewUserHandler(userUsecase),
+		blog:         handler.NewBlogHandler(blogUsecase, mentionUsecase, reactionUsecase, likeUsecase),
+		comment:      handler.NewCommentHandler(commentUsecase, mentionUsecase, reactionUsecase),
+		reaction:     handler.NewReactionHandler(reactionUsecase),
+		like:         handler.NewLikeHandler(likeUsecase),
+		ranking:      handler.NewRankingHandler(rankingUseCase),
+		notification: handler.NewNotificationHandler(notificationUsecase),
+		stream:       handler.NewStreamHandler(streamHub, tickets, cfg.Server.StreamHeartbeat),
+	}
+	router := newRouter(cfg, h, tickets, o)
+
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler: router,
+	}
+	// 停止時はストリーミング配信の接続を切断する（切断しないとShutdownが接続の終了を待ち続ける）
+	httpServer.RegisterOnShutdown(streamHub.Close)
+
+	// メトリクス（METRICS_ADDRが空の場合は公開しない）
+	var metricsServer *http.Server
+	if cfg.Server.MetricsAddr != "" {
+		metricsServer = &http.Server{
+			Addr:    cfg.Server.MetricsAddr,
+			Handler: newMetricsHandler(),
+		}
+	}
+
+	go viewRecorder.Run(context.Background())
+
+	return &Server{
+		handler:       router,
+		httpServer:    httpServer,
+		metricsServer: metricsServer,
+		viewRecorder:  viewRecorder,
+	}, nil
+}
+
+// ServeHTTP : リクエストの処理（Startで起動せずにhttp.Handlerとして組み込む場合に使用する）
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// Start : HTTPサーバーの起動（Stopが呼ばれるまでブロックする）
+// メトリクスのアドレスが指定されている場合は、メトリクスも別のリスナーで公開する
+func (s *Server) Start() error {
+	if s.metricsServer != nil {
+		listener, err := net.Listen("tcp", s.metricsServer.Addr)
+		if err != nil {
+			return fmt.Errorf("メトリクスサーバーの起動エラー: %w", err)
+		}
+		go func() {
+			if err := s.metricsServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("メトリクスサーバーのエラー: %v", err)
+			}
+		}()
+	}
+
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("HTTPサーバーの起動エラー: %w", err)
+	}
+	return nil
+}
+
+// Stop : グレースフルシャットダウン（処理中のリクエストの完了とバッファに残っている閲覧数の書き込みを待つ）
+func (s *Server) Stop(ctx context.Context) error {
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("HTTPサーバーの停止エラー: %w", err)
+	}
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Shutdown(ctx); err != nil {
+			return fmt.Errorf("メトリクスサーバーの停止エラー: %w", err)
+		}
+	}
+	if err := s.viewRecorder.Close(ctx); err != nil {
+		return fmt.Errorf("閲覧数の書き込みエラー: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package server_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"myblog/app/config"
+	"myblog/app/infra/db/migration"
+	"myblog/app/infra/db/rdb"
+	"myblog/app/server"
+	"myblog/db/migrations"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// newTestConfig : テスト環境の設定の読み込み
+func newTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	t.Setenv("APP_ENV", string(config.ProfileTest))
+	cfg, err := config.Load(config.AppAPI)
+	if err != nil {
+		t.Fatalf("設定の読み込みに失敗しました: %v", err)
+	}
+	return cfg
+}
+
+// newTestDB : マイグレーション済みの空のデータベース（メモリ上のSQLite）の生成
+func newTestDB(t *testing.T) *rdb.DB {
+	t.Helper()
+
+	db, err := rdb.Open(rdb.Config{Driver: rdb.DriverSQLite, Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("データベースの接続に失敗しました: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	files, err := migration.Load(migrations.SQLiteFS())
+	if err != nil {
+		t.Fatalf("マイグレーションファイルの読み込みに失敗しました: %v", err)
+	}
+	if _, err := migration.NewMigrator(db, files).Up(context.Background(), 0); err != nil {
+		t.Fatalf("マイグレーションの適用に失敗しました: %v", err)
+	}
+	return db
+}
+
+// newTestServer : サーバーの生成（テストの終了時に停止する）
+func newTestServer(t *testing.T, cfg *config.Config, db *rdb.DB, opts ...server.Option) *server.Server {
+	t.Helper()
+
+	srv, err := server.New(cfg, server.NewRDBRepositories(db), opts...)
+	if err != nil {
+		t.Fatalf("サーバーの生成に失敗しました: %v", err)
+	}
+	t.Cleanup(func() { srv.Stop(context.Background()) })
+	return srv
+}
+
+func TestNew(t *testing.T) {
+	t.Run("利用できない絵文字の設定はエラー", func(t *testing.T) {
+		cfg := newTestConfig(t)
+		cfg.Reaction.Emojis = nil
+
+		_, err := server.New(cfg, server.NewRDBRepositories(newTestDB(t)))
+		if err == nil {
+			t.Fatal("エラーが発生しませんでした")
+		}
+	})
+
+	t.Run("追加のミドルウェアとルート", func(t *testing.T) {
+		var order []string
+		record := func(name string) func(http.Handler) http.Handler {
+			return func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					order = append(order, name)
+					next.ServeHTTP(w, r)
+				})
+			}
+		}
+
+		srv := newTestServer(t, newTestConfig(t), newTestDB(t),
+			server.WithMiddleware(record("first"), record("second")),
+			server.WithRoutes(func(r chi.Router) {
+				r.Get("/version", func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte("v1"))
+				})
+			}),
+		)
+
+		tests := []struct {
+			name       string
+			path       string
+			wantStatus int
+			wantBody   string
+		}{
+			{name: "追加のルート", path: "/version", wantStatus: http.StatusOK, wantBody: "v1"},
+			{name: "標準のルート", path: "/health", wantStatus: http.StatusOK, wantBody: "OK"},
+			{name: "存在しないルート", path: "/unknown", wantStatus: http.StatusNotFound, wantBody: "404 page not found\n"},
+			{name: "メトリクスは公開しない", path: "/debug/vars", wantStatus: http.StatusNotFound, wantBody: "404 page not found\n"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				order = nil
+				rec := httptest.NewRecorder()
+				srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+				if rec.Code != tt.wantStatus || rec.Body.String() != tt.wantBody {
+					t.Errorf("レスポンスが異なります: got (%d, %q), want (%d, %q)", rec.Code, rec.Body.String(), tt.wantStatus, tt.wantBody)
+				}
+				if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+					t.Errorf("ミドルウェアの適用順が異なります: %v", order)
+				}
+			})
+		}
+	})
+}
+
+// freePort : 空いているポートの取得
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// get : 起動するまで待ってからGETリクエストを送信
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	var res *http.Response
+	var err error
+	for i := 0; i < 50; i++ {
+		res, err = http.Get(url)
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("サーバーに接続できませんでした: %v", err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	return res.StatusCode, string(body)
+}
+
+func TestServer_StartStop(t *testing.T) {
+	// 空いているポートで起動する
+	port := freePort(t)
+	metricsAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(freePort(t)))
+
+	cfg := newTestConfig(t)
+	cfg.Server.Port = port
+	cfg.Server.MetricsAddr = metricsAddr
+	srv, err := server.New(cfg, server.NewRDBRepositories(newTestDB(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan error, 1)
+	go func() { started <- srv.Start() }()
+
+	url := "http://" + net.JoinHostPort("127.0.0.1", strconv.Itoa(port)) + "/health"
+	if status, body := get(t, url); status != http.StatusOK || body != "OK" {
+		t.Errorf("レスポンスが異なります: %d, %q", status, body)
+	}
+
+	// メトリクスは内部向けのアドレスでのみ公開する
+	metricsURL := "http://" + metricsAddr + "/debug/vars"
+	if status, body := get(t, metricsURL); status != http.StatusOK || !strings.Contains(body, `"rdb_transactions"`) {
+		t.Errorf("メトリクスのレスポンスが異なります: %d, %q", status, body)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Stop(ctx); err != nil {
+		t.Fatalf("停止に失敗しました: %v", err)
+	}
+	if err := <-started; err != nil {
+		t.Errorf("Startがエラーを返しました: %v", err)
+	}
+	if _, err := http.Get(url); err == nil {
+		t.Error("停止後も接続できました")
+	}
+	if _, err := http.Get(metricsURL); err == nil {
+		t.Error("停止後もメトリクスに接続できました")
+	}
+
+	// 停止済みのサーバーを再度停止してもエラーにならない
+	if err := srv.Stop(ctx); err != nil {
+		t.Errorf("2回目の停止に失敗しました: %v", err)
+	}
+}
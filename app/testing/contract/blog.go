@@ -0,0 +1,226 @@
+package contract
+
+import (
+	"testing"
+
+	"myblog/app/domain/model/blog"
+
+	"github.com/google/uuid"
+)
+
+// RunBlog : repository.Blogのスイートの実行
+func RunBlog(t *testing.T, factory Factory) {
+	t.Run("保存したブログをIDで取得できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+
+		got, err := f.repos.Blog.FindByID(f.ctx, b.ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertBlog(t, got, b)
+	})
+
+	t.Run("存在しないブログはエラー", func(t *testing.T) {
+		f := newFixture(t, factory)
+		f.blog(f.user("alice"), "title")
+
+		_, err := f.repos.Blog.FindByID(f.ctx, "unknown")
+		assertNotFound(t, err, "blog not found with id: unknown")
+	})
+
+	t.Run("存在しないユーザーのブログは保存できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		if err := f.repos.User.Delete(f.ctx, alice.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+
+		orphan, err := blog.Reconstruct(uuid.New().String(), alice.ID(), "orphan", "content", f.tick(), f.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.Blog.Save(f.ctx, orphan); err == nil {
+			t.Fatal("存在しないユーザーのブログを保存できました")
+		}
+		if _, err := f.repos.Blog.FindByID(f.ctx, b.ID().String()); err == nil {
+			t.Error("削除したユーザーのブログを取得できました")
+		}
+	})
+
+	t.Run("ユーザーのブログを作成日時の降順に取得できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		carol := f.user("carol")
+		first := f.blog(alice, "first")
+		f.blog(bob, "bob's")
+		second := f.blog(alice, "second")
+		third := f.blog(alice, "third")
+
+		tests := []struct {
+			name   string
+			userID string
+			want   []string
+		}{
+			{name: "新しい順", userID: alice.ID().String(), want: []string{third.ID().String(), second.ID().String(), first.ID().String()}},
+			{name: "ブログのないユーザー", userID: carol.ID().String(), want: nil},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				u, err := f.repos.User.FindByID(f.ctx, tt.userID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				blogs, err := f.repos.Blog.FindByUserID(f.ctx, u.ID())
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertIDs(t, blogIDs(blogs), tt.want)
+			})
+		}
+	})
+
+	t.Run("全てのブログを作成日時の降順にページ単位で取得できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		// 新しい順にb4, b3, b2, b1, b0
+		var created []string
+		for i := 0; i < 5; i++ {
+			author := alice
+			if i%2 == 1 {
+				author = bob
+			}
+			created = append([]string{f.blog(author, "blog").ID().String()}, created...)
+		}
+
+		tests := []struct {
+			name   string
+			offset int
+			limit  int
+			want   []string
+		}{
+			{name: "最初のページ", offset: 0, limit: 2, want: created[0:2]},
+			{name: "途中のページ", offset: 2, limit: 2, want: created[2:4]},
+			{name: "最後のページは件数に満たない", offset: 4, limit: 2, want: created[4:]},
+			{name: "全件と同じ位置から", offset: 5, limit: 2, want: nil},
+			{name: "全件を超える位置から", offset: 10, limit: 2, want: nil},
+			{name: "全件より多い件数", offset: 0, limit: 10, want: created},
+			{name: "件数が0", offset: 0, limit: 0, want: nil},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				blogs, err := f.repos.Blog.FindAll(f.ctx, tt.offset, tt.limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertIDs(t, blogIDs(blogs), tt.want)
+			})
+		}
+	})
+
+	t.Run("ブログを更新できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		other := f.blog(alice, "other")
+
+		if err := b.UpdateTitle("new title"); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.UpdateContent("new content"); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.Blog.Update(f.ctx, b); err != nil {
+			t.Fatal(err)
+		}
+
+		saved, err := f.repos.Blog.FindByID(f.ctx, b.ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saved.Title() != "new title" || saved.Content() != "new content" {
+			t.Errorf("更新されていません: %s, %s", saved.Title(), saved.Content())
+		}
+		assertTime(t, "作成日時", saved.CreatedAt(), b.CreatedAt())
+
+		unchanged, err := f.repos.Blog.FindByID(f.ctx, other.ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertBlog(t, unchanged, other)
+	})
+
+	t.Run("存在しないブログは更新できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		unknown, err := blog.Reconstruct("unknown", alice.ID(), "title", "content", f.tick(), f.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = f.repos.Blog.Update(f.ctx, unknown)
+		assertNotFound(t, err, "blog not found with id: unknown")
+	})
+
+	t.Run("ブログを削除するとコメントも削除される", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		b := f.blog(alice, "deleted")
+		other := f.blog(alice, "kept")
+		deleted := f.comment(b, bob, "deleted")
+		kept := f.comment(other, bob, "kept")
+
+		if err := f.repos.Blog.Delete(f.ctx, b.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := f.repos.Blog.FindByID(f.ctx, b.ID().String())
+		assertNotFound(t, err, "blog not found with id: "+b.ID().String())
+		_, err = f.repos.Comment.FindByID(f.ctx, deleted.ID().String())
+		assertNotFound(t, err, "comment not found with id: "+deleted.ID().String())
+
+		comments, err := f.repos.Comment.FindByUserID(f.ctx, bob.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, commentIDs(comments), []string{kept.ID().String()})
+		if _, err := f.repos.User.FindByID(f.ctx, alice.ID().String()); err != nil {
+			t.Errorf("ブログの著者が削除されました: %v", err)
+		}
+	})
+
+	t.Run("存在しないブログは削除できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		f.blog(f.user("alice"), "title")
+
+		err := f.repos.Blog.Delete(f.ctx, "unknown")
+		assertNotFound(t, err, "blog not found with id: unknown")
+	})
+}
+
+// assertBlog : 取得したブログの検証
+func assertBlog(t *testing.T, got, want *blog.Blog) {
+	t.Helper()
+
+	if got.ID() != want.ID() || got.UserID() != want.UserID() || got.Title() != want.Title() || got.Content() != want.Content() {
+		t.Errorf("ブログが異なります: got (%s, %s, %s), want (%s, %s, %s)",
+			got.ID().String(), got.UserID().String(), got.Title(), want.ID().String(), want.UserID().String(), want.Title())
+	}
+	assertTime(t, "作成日時", got.CreatedAt(), want.CreatedAt())
+}
+
+// blogIDs : ブログのIDの一覧
+func blogIDs(blogs []*blog.Blog) []string {
+	var ids []string
+	for _, b := range blogs {
+		ids = append(ids, b.ID().String())
+	}
+	return ids
+}
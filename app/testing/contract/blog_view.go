@@ -0,0 +1,77 @@
+package contract
+
+import (
+	"testing"
+	"time"
+
+	"myblog/app/domain/model/view"
+	"myblog/app/infra/query"
+)
+
+// RunBlogView : repository.BlogViewのスイートの実行（加算した閲覧数はusecase.BlogStatsQueryで参照する）
+func RunBlogView(t *testing.T, factory Factory) {
+	t.Run("同じブログの同じ日の閲覧数は加算され、指定日以降の閲覧数を集計できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		other := f.blog(alice, "other")
+		f.blog(alice, "no views")
+		today := f.now
+		yesterday := today.AddDate(0, 0, -1)
+
+		for _, counts := range [][]*view.DailyCount{
+			{{BlogID: b.ID(), Date: yesterday, Views: 10}, {BlogID: b.ID(), Date: today, Views: 2}},
+			{{BlogID: b.ID(), Date: today, Views: 3}, {BlogID: other.ID(), Date: today, Views: 1}},
+		} {
+			if err := f.repos.BlogView.IncrementDailyCounts(f.ctx, counts); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		all := f.viewCounts(today.AddDate(0, 0, -2))
+		if len(all) != 2 || all[b.ID().String()] != 15 || all[other.ID().String()] != 1 {
+			t.Errorf("全期間の閲覧数が異なります: %v", all)
+		}
+		recent := f.viewCounts(today)
+		if len(recent) != 2 || recent[b.ID().String()] != 5 || recent[other.ID().String()] != 1 {
+			t.Errorf("指定日以降の閲覧数が異なります: %v", recent)
+		}
+	})
+
+	t.Run("ブログの削除は閲覧数に連鎖し、存在しないブログの閲覧数は保存できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		counts := []*view.DailyCount{{BlogID: b.ID(), Date: f.now, Views: 1}}
+		if err := f.repos.BlogView.IncrementDailyCounts(f.ctx, counts); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := f.repos.Blog.Delete(f.ctx, b.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+		if got := f.viewCounts(f.now.AddDate(0, 0, -1)); len(got) != 0 {
+			t.Errorf("削除したブログの閲覧数が残っています: %v", got)
+		}
+		if err := f.repos.BlogView.IncrementDailyCounts(f.ctx, counts); err == nil {
+			t.Error("存在しないブログの閲覧数を保存できました")
+		}
+	})
+}
+
+// viewCounts : 指定日時以降に閲覧のあったブログごとの閲覧数
+func (f *fixture) viewCounts(since time.Time) map[string]int {
+	f.t.Helper()
+
+	counts := make(map[string]int)
+	err := f.repos.BlogStats.ForEachBlogRankingData(f.ctx, since, func(data query.BlogRankingData) error {
+		if data.ViewCount > 0 {
+			counts[data.BlogID] = data.ViewCount
+		}
+		return nil
+	})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return counts
+}
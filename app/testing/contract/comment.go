@@ -0,0 +1,148 @@
+package contract
+
+import (
+	"testing"
+
+	"myblog/app/domain/model/comment"
+
+	"github.com/google/uuid"
+)
+
+// RunComment : repository.Commentのスイートの実行
+func RunComment(t *testing.T, factory Factory) {
+	t.Run("保存したコメントをIDで取得できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		c := f.comment(f.blog(alice, "title"), alice, "content")
+
+		got, err := f.repos.Comment.FindByID(f.ctx, c.ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ID() != c.ID() || got.BlogID() != c.BlogID() || got.UserID() != c.UserID() || got.Content() != c.Content() {
+			t.Errorf("コメントが異なります: got (%s, %s), want (%s, %s)", got.ID().String(), got.Content(), c.ID().String(), c.Content())
+		}
+		assertTime(t, "作成日時", got.CreatedAt(), c.CreatedAt())
+	})
+
+	t.Run("存在しないコメントはエラー", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		f.comment(f.blog(alice, "title"), alice, "content")
+
+		_, err := f.repos.Comment.FindByID(f.ctx, "unknown")
+		assertNotFound(t, err, "comment not found with id: unknown")
+	})
+
+	t.Run("存在しないブログへのコメントは保存できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		if err := f.repos.Blog.Delete(f.ctx, b.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+
+		orphan, err := comment.Reconstruct(uuid.New().String(), b.ID(), alice.ID(), "orphan", f.tick(), f.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.Comment.Save(f.ctx, orphan); err == nil {
+			t.Fatal("存在しないブログへのコメントを保存できました")
+		}
+	})
+
+	t.Run("ブログのコメントを作成日時の昇順、ユーザーのコメントを降順に取得できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		b := f.blog(alice, "title")
+		other := f.blog(alice, "other")
+		empty := f.blog(alice, "empty")
+		first := f.comment(b, bob, "first")
+		onOther := f.comment(other, bob, "on other")
+		second := f.comment(b, alice, "second")
+		third := f.comment(b, bob, "third")
+
+		byBlog, err := f.repos.Comment.FindByBlogID(f.ctx, b.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, commentIDs(byBlog), []string{first.ID().String(), second.ID().String(), third.ID().String()})
+
+		byUser, err := f.repos.Comment.FindByUserID(f.ctx, bob.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, commentIDs(byUser), []string{third.ID().String(), onOther.ID().String(), first.ID().String()})
+
+		none, err := f.repos.Comment.FindByBlogID(f.ctx, empty.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, commentIDs(none), nil)
+	})
+
+	t.Run("コメントを更新できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		c := f.comment(f.blog(alice, "title"), alice, "content")
+
+		if err := c.UpdateContent("edited"); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.Comment.Update(f.ctx, c); err != nil {
+			t.Fatal(err)
+		}
+
+		saved, err := f.repos.Comment.FindByID(f.ctx, c.ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saved.Content() != "edited" {
+			t.Errorf("更新されていません: %s", saved.Content())
+		}
+	})
+
+	t.Run("存在しないコメントは更新・削除できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		unknown, err := comment.Reconstruct("unknown", b.ID(), alice.ID(), "content", f.tick(), f.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = f.repos.Comment.Update(f.ctx, unknown)
+		assertNotFound(t, err, "comment not found with id: unknown")
+
+		err = f.repos.Comment.Delete(f.ctx, "unknown")
+		assertNotFound(t, err, "comment not found with id: unknown")
+	})
+
+	t.Run("コメントを削除できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		deleted := f.comment(b, alice, "deleted")
+		kept := f.comment(b, alice, "kept")
+
+		if err := f.repos.Comment.Delete(f.ctx, deleted.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+
+		comments, err := f.repos.Comment.FindByBlogID(f.ctx, b.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, commentIDs(comments), []string{kept.ID().String()})
+	})
+}
+
+// commentIDs : コメントのIDの一覧
+func commentIDs(comments []*comment.Comment) []string {
+	var ids []string
+	for _, c := range comments {
+		ids = append(ids, c.ID().String())
+	}
+	return ids
+}
@@ -0,0 +1,168 @@
+// Package contract : リポジトリのインターフェースの実装が満たすべき振る舞いを検証する共通のテストスイート
+// 実装ごとのテストから、空のデータストアを参照するリポジトリを生成するFactoryを渡して実行する
+//
+//	func TestContract(t *testing.T) {
+//		contract.Run(t, func(t *testing.T) contract.Repositories { ... })
+//	}
+package contract
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+	"myblog/app/usecase"
+
+	"github.com/google/uuid"
+)
+
+// Repositories : スイートで検証するリポジトリ（全て同じデータストアを参照する）
+// BlogStatsとRankingListはリポジトリではないが、BlogViewとRankingの書き込みの結果を参照するために使用する
+type Repositories struct {
+	User                   repository.User
+	Blog                   repository.Blog
+	Comment                repository.Comment
+	Mention                repository.Mention
+	Notification           repository.Notification
+	NotificationPreference repository.NotificationPreference
+	Reaction               repository.Reaction
+	Like                   repository.Like
+	BlogView               repository.BlogView
+	Ranking                repository.RankingRepository
+	Outbox                 repository.Outbox
+	JobRun                 repository.JobRun
+	BlogStats              usecase.BlogStatsQuery
+	RankingList            usecase.RankingListQuery
+}
+
+// Factory : 空のデータストアを参照するリポジトリの生成（テストケースごとに呼び出す）
+// 後始末が必要な場合はt.Cleanupで登録する
+type Factory func(t *testing.T) Repositories
+
+// Run : 全てのリポジトリのスイートの実行
+func Run(t *testing.T, factory Factory) {
+	t.Run("User", func(t *testing.T) { RunUser(t, factory) })
+	t.Run("Blog", func(t *testing.T) { RunBlog(t, factory) })
+	t.Run("Comment", func(t *testing.T) { RunComment(t, factory) })
+	t.Run("Mention", func(t *testing.T) { RunMention(t, factory) })
+	t.Run("Notification", func(t *testing.T) { RunNotification(t, factory) })
+	t.Run("NotificationPreference", func(t *testing.T) { RunNotificationPreference(t, factory) })
+	t.Run("Reaction", func(t *testing.T) { RunReaction(t, factory) })
+	t.Run("Like", func(t *testing.T) { RunLike(t, factory) })
+	t.Run("BlogView", func(t *testing.T) { RunBlogView(t, factory) })
+	t.Run("Ranking", func(t *testing.T) { RunRanking(t, factory) })
+	t.Run("Outbox", func(t *testing.T) { RunOutbox(t, factory) })
+	t.Run("JobRun", func(t *testing.T) { RunJobRun(t, factory) })
+}
+
+// fixture : テストケースごとのリポジトリと前提データの作成
+type fixture struct {
+	t     *testing.T
+	ctx   context.Context
+	repos Repositories
+	now   time.Time
+}
+
+// newFixture : 空のデータストアを参照するfixtureの生成
+func newFixture(t *testing.T, factory Factory) *fixture {
+	t.Helper()
+
+	return &fixture{
+		t:     t,
+		ctx:   context.Background(),
+		repos: factory(t),
+		// 秒未満を保持しないデータベースでも同じ日時になるよう秒単位にする
+		now: time.Now().Truncate(time.Second).Add(-24 * time.Hour),
+	}
+}
+
+// tick : 前回より1秒後の日時（作成日時による並び順を一意にする）
+func (f *fixture) tick() time.Time {
+	f.now = f.now.Add(time.Second)
+	return f.now
+}
+
+// user : ユーザーの作成
+func (f *fixture) user(username string) *user.User {
+	f.t.Helper()
+
+	createdAt := f.tick()
+	u, err := user.Reconstruct(uuid.New().String(), username, username+"@example.com", []byte("hashed-"+username), createdAt, createdAt)
+	if err != nil {
+		f.t.Fatalf("ユーザーの生成に失敗しました: %v", err)
+	}
+	if err := f.repos.User.Save(f.ctx, u); err != nil {
+		f.t.Fatalf("ユーザーの保存に失敗しました: %v", err)
+	}
+	return u
+}
+
+// blog : ブログの作成
+func (f *fixture) blog(author *user.User, title string) *blog.Blog {
+	f.t.Helper()
+
+	createdAt := f.tick()
+	b, err := blog.Reconstruct(uuid.New().String(), author.ID(), title, "content of "+title, createdAt, createdAt)
+	if err != nil {
+		f.t.Fatalf("ブログの生成に失敗しました: %v", err)
+	}
+	if err := f.repos.Blog.Save(f.ctx, b); err != nil {
+		f.t.Fatalf("ブログの保存に失敗しました: %v", err)
+	}
+	return b
+}
+
+// comment : コメントの作成
+func (f *fixture) comment(b *blog.Blog, author *user.User, content string) *comment.Comment {
+	f.t.Helper()
+
+	createdAt := f.tick()
+	c, err := comment.Reconstruct(uuid.New().String(), b.ID(), author.ID(), content, createdAt, createdAt)
+	if err != nil {
+		f.t.Fatalf("コメントの生成に失敗しました: %v", err)
+	}
+	if err := f.repos.Comment.Save(f.ctx, c); err != nil {
+		f.t.Fatalf("コメントの保存に失敗しました: %v", err)
+	}
+	return c
+}
+
+// assertNotFound : 見つからない場合のエラーの検証（メッセージはwantを含む）
+func assertNotFound(t *testing.T, err error, want string) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("エラーが発生しませんでした（期待するエラー: %q）", want)
+	}
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("エラーが一致しません: got %q, want %q を含む", err.Error(), want)
+	}
+}
+
+// assertTime : 日時の検証（タイムゾーンの違いは無視する）
+func assertTime(t *testing.T, name string, got, want time.Time) {
+	t.Helper()
+
+	if !got.Equal(want) {
+		t.Errorf("%sが異なります: got %s, want %s", name, got, want)
+	}
+}
+
+// assertIDs : IDの並びの検証
+func assertIDs(t *testing.T, got, want []string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("件数が異なります: got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("並び順が異なります: got %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+package contract
+
+import "testing"
+
+func TestMemory(t *testing.T) {
+	Run(t, Memory)
+}
@@ -0,0 +1,118 @@
+package contract
+
+import (
+	"testing"
+	"time"
+
+	"myblog/app/domain/model/jobrun"
+
+	"github.com/google/uuid"
+)
+
+// RunJobRun : repository.JobRunのスイートの実行
+func RunJobRun(t *testing.T, factory Factory) {
+	t.Run("同じジョブの同じ予定実行日時の実行は作成しない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		scheduledAt := f.tick()
+
+		for _, tc := range []struct {
+			name        string
+			jobName     string
+			scheduledAt *time.Time
+			want        bool
+		}{
+			{name: "予定実行", jobName: "ranking", scheduledAt: &scheduledAt, want: true},
+			{name: "同じ予定実行", jobName: "ranking", scheduledAt: &scheduledAt, want: false},
+			{name: "他のジョブの同じ予定実行", jobName: "cleanup", scheduledAt: &scheduledAt, want: true},
+			{name: "手動実行", jobName: "ranking", want: true},
+			{name: "2回目の手動実行", jobName: "ranking", want: true},
+		} {
+			created, err := f.repos.JobRun.Create(f.ctx, f.newJobRun(tc.jobName, tc.scheduledAt))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if created != tc.want {
+				t.Errorf("%sの作成結果が異なります: got %t, want %t", tc.name, created, tc.want)
+			}
+		}
+
+		all, err := f.repos.JobRun.FindRecent(f.ctx, "", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(all) != 4 {
+			t.Errorf("ジョブ実行の件数が異なります: got %d, want 4", len(all))
+		}
+	})
+
+	t.Run("ジョブ実行を新しい順に検索し、状態の更新を反映する", func(t *testing.T) {
+		f := newFixture(t, factory)
+		first := f.jobRun("ranking")
+		other := f.jobRun("cleanup")
+		second := f.jobRun("ranking")
+
+		finishedAt := f.tick()
+		failed, err := jobrun.Reconstruct(second.ID().String(), second.JobName(), second.Params(), second.ScheduledAt(), jobrun.StatusFailed, 3, 2, "集計エラー", second.StartedAt(), &finishedAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.JobRun.Update(f.ctx, failed); err != nil {
+			t.Fatal(err)
+		}
+
+		runs, err := f.repos.JobRun.FindRecent(f.ctx, "ranking", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, jobRunIDs(runs), []string{second.ID().String(), first.ID().String()})
+		got := runs[0]
+		if got.Status() != jobrun.StatusFailed || got.RowsProcessed() != 3 || got.Attempts() != 2 || got.ErrorMessage() != "集計エラー" || got.Params() != `{"type":"daily"}` {
+			t.Errorf("更新したジョブ実行が異なります: %+v", got)
+		}
+		if got.FinishedAt() == nil {
+			t.Fatal("終了日時がありません")
+		}
+		assertTime(t, "終了日時", *got.FinishedAt(), finishedAt)
+		if runs[1].Status() != jobrun.StatusRunning || runs[1].FinishedAt() != nil {
+			t.Errorf("更新していないジョブ実行が異なります: %+v", runs[1])
+		}
+
+		all, err := f.repos.JobRun.FindRecent(f.ctx, "", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, jobRunIDs(all), []string{second.ID().String(), other.ID().String()})
+	})
+
+}
+
+// newJobRun : 実行中の未保存のジョブ実行の生成
+func (f *fixture) newJobRun(jobName string, scheduledAt *time.Time) *jobrun.JobRun {
+	f.t.Helper()
+
+	run, err := jobrun.Reconstruct(uuid.New().String(), jobName, `{"type":"daily"}`, scheduledAt, jobrun.StatusRunning, 0, 0, "", f.tick(), nil)
+	if err != nil {
+		f.t.Fatalf("ジョブ実行の生成に失敗しました: %v", err)
+	}
+	return run
+}
+
+// jobRun : 手動実行のジョブ実行の作成
+func (f *fixture) jobRun(jobName string) *jobrun.JobRun {
+	f.t.Helper()
+
+	run := f.newJobRun(jobName, nil)
+	if _, err := f.repos.JobRun.Create(f.ctx, run); err != nil {
+		f.t.Fatalf("ジョブ実行の作成に失敗しました: %v", err)
+	}
+	return run
+}
+
+// jobRunIDs : ジョブ実行のIDの一覧
+func jobRunIDs(runs []*jobrun.JobRun) []string {
+	var ids []string
+	for _, run := range runs {
+		ids = append(ids, run.ID().String())
+	}
+	return ids
+}
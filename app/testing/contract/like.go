@@ -0,0 +1,87 @@
+package contract
+
+import (
+	"testing"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/like"
+)
+
+// RunLike : repository.Likeのスイートの実行
+func RunLike(t *testing.T, factory Factory) {
+	t.Run("ブログごとのいいね数とユーザーのいいね済みのブログを取得でき、保存と削除は冪等", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		b := f.blog(alice, "title")
+		other := f.blog(alice, "other")
+		empty := f.blog(alice, "empty")
+		ids := []blog.ID{b.ID(), other.ID(), empty.ID()}
+
+		for _, l := range []*like.Like{
+			like.NewLike(b.ID(), alice.ID()),
+			like.NewLike(b.ID(), bob.ID()),
+			like.NewLike(b.ID(), bob.ID()), // いいね済み
+			like.NewLike(other.ID(), alice.ID()),
+		} {
+			if err := f.repos.Like.Save(f.ctx, l); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		counts, err := f.repos.Like.CountByBlogIDs(f.ctx, ids)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if counts[b.ID().String()] != 2 || counts[other.ID().String()] != 1 || counts[empty.ID().String()] != 0 {
+			t.Errorf("いいね数が異なります: %v", counts)
+		}
+
+		if err := f.repos.Like.Delete(f.ctx, b.ID(), alice.ID()); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.Like.Delete(f.ctx, b.ID(), alice.ID()); err != nil {
+			t.Fatalf("存在しないいいねの削除に失敗しました: %v", err)
+		}
+
+		liked, err := f.repos.Like.FindLikedBlogIDs(f.ctx, alice.ID(), ids)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(liked) != 1 || !liked[other.ID().String()] {
+			t.Errorf("いいね済みのブログが異なります: %v", liked)
+		}
+
+		none, err := f.repos.Like.CountByBlogIDs(f.ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(none) != 0 {
+			t.Errorf("ブログを指定しない場合のいいね数が空ではありません: %v", none)
+		}
+	})
+
+	t.Run("ブログの削除はいいねに連鎖し、存在しないブログへのいいねは保存できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		if err := f.repos.Like.Save(f.ctx, like.NewLike(b.ID(), alice.ID())); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := f.repos.Blog.Delete(f.ctx, b.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+		liked, err := f.repos.Like.FindLikedBlogIDs(f.ctx, alice.ID(), []blog.ID{b.ID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(liked) != 0 {
+			t.Errorf("削除したブログのいいねが残っています: %v", liked)
+		}
+
+		if err := f.repos.Like.Save(f.ctx, like.NewLike(b.ID(), alice.ID())); err == nil {
+			t.Error("存在しないブログへのいいねを保存できました")
+		}
+	})
+}
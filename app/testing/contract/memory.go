@@ -0,0 +1,28 @@
+package contract
+
+import (
+	"testing"
+
+	"myblog/app/testing/memory"
+)
+
+// Memory : インメモリの実装（参照実装）のリポジトリを生成するFactory
+func Memory(t *testing.T) Repositories {
+	store := memory.NewStore()
+	return Repositories{
+		User:                   memory.NewUserRepository(store),
+		Blog:                   memory.NewBlogRepository(store),
+		Comment:                memory.NewCommentRepository(store),
+		Mention:                memory.NewMentionRepository(store),
+		Notification:           memory.NewNotificationRepository(store),
+		NotificationPreference: memory.NewNotificationPreferenceRepository(store),
+		Reaction:               memory.NewReactionRepository(store),
+		Like:                   memory.NewLikeRepository(store),
+		BlogView:               memory.NewBlogViewRepository(store),
+		Ranking:                memory.NewRankingRepository(store),
+		Outbox:                 memory.NewOutboxRepository(store),
+		JobRun:                 memory.NewJobRunRepository(store),
+		BlogStats:              memory.NewBlogStats(store),
+		RankingList:            memory.NewRankingList(store),
+	}
+}
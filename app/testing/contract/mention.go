@@ -0,0 +1,140 @@
+package contract
+
+import (
+	"testing"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/mention"
+	"myblog/app/domain/model/user"
+
+	"github.com/google/uuid"
+)
+
+// RunMention : repository.Mentionのスイートの実行
+func RunMention(t *testing.T, factory Factory) {
+	t.Run("ブログ本文のメンションを作成日時の昇順、コメントのメンションをコメントごとに取得できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		carol := f.user("carol")
+		b := f.blog(alice, "title")
+		other := f.blog(alice, "other")
+		empty := f.blog(alice, "empty")
+		c := f.comment(b, alice, "comment")
+		commentID := c.ID()
+
+		first := f.mention(b, nil, bob)
+		onOther := f.mention(other, nil, carol)
+		second := f.mention(b, nil, carol)
+		inComment := f.mention(b, &commentID, bob)
+
+		byBlog, err := f.repos.Mention.FindByBlogIDs(f.ctx, []blog.ID{b.ID(), other.ID(), empty.ID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// コメントのメンションはブログ本文のメンションに含めない
+		assertIDs(t, mentionIDs(byBlog), []string{first.ID().String(), onOther.ID().String(), second.ID().String()})
+		if byBlog[0].Username() != "bob" || byBlog[0].UserID() != bob.ID() || byBlog[0].CommentID() != nil {
+			t.Errorf("メンションが異なります: %+v", byBlog[0])
+		}
+		assertTime(t, "作成日時", byBlog[0].CreatedAt(), first.CreatedAt())
+
+		byComment, err := f.repos.Mention.FindByCommentIDs(f.ctx, []comment.ID{commentID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, mentionIDs(byComment), []string{inComment.ID().String()})
+		if got := byComment[0].CommentID(); got == nil || *got != commentID {
+			t.Errorf("メンションのコメントIDが異なります: got %v, want %s", got, commentID.String())
+		}
+
+		none, err := f.repos.Mention.FindByBlogIDs(f.ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, mentionIDs(none), nil)
+	})
+
+	t.Run("ブログ本文とコメントのメンションをそれぞれ削除できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		b := f.blog(alice, "title")
+		c := f.comment(b, alice, "comment")
+		other := f.comment(b, alice, "other")
+		commentID, otherID := c.ID(), other.ID()
+
+		f.mention(b, nil, bob)
+		f.mention(b, &commentID, bob)
+		kept := f.mention(b, &otherID, bob)
+
+		// ブログ本文のメンションの削除はコメントのメンションを削除しない
+		if err := f.repos.Mention.DeleteByBlogID(f.ctx, b.ID()); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.Mention.DeleteByCommentID(f.ctx, commentID); err != nil {
+			t.Fatal(err)
+		}
+
+		byBlog, err := f.repos.Mention.FindByBlogIDs(f.ctx, []blog.ID{b.ID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, mentionIDs(byBlog), nil)
+
+		byComment, err := f.repos.Mention.FindByCommentIDs(f.ctx, []comment.ID{commentID, otherID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, mentionIDs(byComment), []string{kept.ID().String()})
+	})
+
+	t.Run("ブログの削除はメンションに連鎖し、存在しないブログのメンションは保存できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		b := f.blog(alice, "title")
+		f.mention(b, nil, bob)
+
+		if err := f.repos.Blog.Delete(f.ctx, b.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+		byBlog, err := f.repos.Mention.FindByBlogIDs(f.ctx, []blog.ID{b.ID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, mentionIDs(byBlog), nil)
+
+		orphan, err := mention.Reconstruct(uuid.New().String(), b.ID(), nil, bob.ID(), bob.Username(), f.tick())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.Mention.SaveAll(f.ctx, []*mention.Mention{orphan}); err == nil {
+			t.Error("存在しないブログのメンションを保存できました")
+		}
+	})
+}
+
+// mention : メンションの作成（commentIDがnilの場合はブログ本文のメンション）
+func (f *fixture) mention(b *blog.Blog, commentID *comment.ID, to *user.User) *mention.Mention {
+	f.t.Helper()
+
+	m, err := mention.Reconstruct(uuid.New().String(), b.ID(), commentID, to.ID(), to.Username(), f.tick())
+	if err != nil {
+		f.t.Fatalf("メンションの生成に失敗しました: %v", err)
+	}
+	if err := f.repos.Mention.SaveAll(f.ctx, []*mention.Mention{m}); err != nil {
+		f.t.Fatalf("メンションの保存に失敗しました: %v", err)
+	}
+	return m
+}
+
+// mentionIDs : メンションのIDの一覧
+func mentionIDs(mentions []*mention.Mention) []string {
+	var ids []string
+	for _, m := range mentions {
+		ids = append(ids, m.ID().String())
+	}
+	return ids
+}
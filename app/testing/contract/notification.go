@@ -0,0 +1,296 @@
+package contract
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/notification"
+	"myblog/app/domain/model/user"
+
+	"github.com/google/uuid"
+)
+
+// RunNotification : repository.Notificationのスイートの実行
+func RunNotification(t *testing.T, factory Factory) {
+	t.Run("ユーザーの通知をカーソルで新しい順にページングできる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		b := f.blog(alice, "title")
+
+		oldest := f.notification(alice, bob, b, nil, f.tick())
+		// 同じ作成日時の通知はIDの降順に並ぶ
+		tiedAt := f.tick()
+		tied := []*notification.Notification{
+			f.notification(alice, bob, b, nil, tiedAt),
+			f.notification(alice, bob, b, nil, tiedAt),
+			f.notification(alice, bob, b, nil, tiedAt),
+		}
+		newest := f.notification(alice, bob, b, nil, f.tick())
+		f.notification(bob, alice, b, nil, f.tick()) // 他のユーザーの通知
+
+		var want []string
+		want = append(want, newest.ID().String())
+		for _, n := range sortedByIDDesc(tied) {
+			want = append(want, n.ID().String())
+		}
+		want = append(want, oldest.ID().String())
+
+		var got []string
+		var cursor *notification.Cursor
+		for pages := 0; ; pages++ {
+			if pages > len(want) {
+				t.Fatalf("ページングが終了しません: %v", got)
+			}
+			page, err := f.repos.Notification.FindByUserID(f.ctx, alice.ID(), cursor, false, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			got = append(got, notificationIDs(page)...)
+			next := notification.NewCursor(page[len(page)-1])
+			cursor = &next
+		}
+		assertIDs(t, got, want)
+	})
+
+	t.Run("未読の通知の件数と未読の通知のみを取得できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		b := f.blog(alice, "title")
+		readAt := f.tick()
+
+		unread := f.notification(alice, bob, b, nil, f.tick())
+		read := f.saveNotification(alice, bob, b, nil, &readAt, f.tick())
+		f.notification(bob, alice, b, nil, f.tick())
+
+		count, err := f.repos.Notification.CountUnread(f.ctx, alice.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Errorf("未読の件数が異なります: got %d, want 1", count)
+		}
+
+		unreadOnly, err := f.repos.Notification.FindByUserID(f.ctx, alice.ID(), nil, true, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, notificationIDs(unreadOnly), []string{unread.ID().String()})
+
+		all, err := f.repos.Notification.FindByUserID(f.ctx, alice.ID(), nil, false, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, notificationIDs(all), []string{read.ID().String(), unread.ID().String()})
+		if all[0].ReadAt() == nil {
+			t.Fatal("既読日時がありません")
+		}
+		assertTime(t, "既読日時", *all[0].ReadAt(), readAt)
+	})
+
+	t.Run("自分の未読の通知のみ既読にする", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		b := f.blog(alice, "title")
+		firstReadAt := f.tick()
+
+		target := f.notification(alice, bob, b, nil, f.tick())
+		alreadyRead := f.saveNotification(alice, bob, b, nil, &firstReadAt, f.tick())
+		remaining := f.notification(alice, bob, b, nil, f.tick())
+		others := f.notification(bob, alice, b, nil, f.tick())
+
+		readAt := f.tick()
+		if err := f.repos.Notification.MarkRead(f.ctx, alice.ID(), []notification.ID{target.ID(), alreadyRead.ID(), others.ID()}, readAt); err != nil {
+			t.Fatal(err)
+		}
+
+		byID := f.notificationsByID(alice)
+		if got := byID[target.ID().String()].ReadAt(); got == nil {
+			t.Error("指定した通知が既読になっていません")
+		} else {
+			assertTime(t, "既読日時", *got, readAt)
+		}
+		if got := byID[alreadyRead.ID().String()].ReadAt(); got == nil {
+			t.Error("既読の通知が未読になりました")
+		} else {
+			assertTime(t, "既読の通知の既読日時", *got, firstReadAt)
+		}
+		if byID[remaining.ID().String()].IsRead() {
+			t.Error("指定していない通知が既読になりました")
+		}
+		if f.notificationsByID(bob)[others.ID().String()].IsRead() {
+			t.Error("他のユーザーの通知が既読になりました")
+		}
+
+		if err := f.repos.Notification.MarkAllRead(f.ctx, alice.ID(), f.tick()); err != nil {
+			t.Fatal(err)
+		}
+		for _, who := range []*user.User{alice, bob} {
+			count, err := f.repos.Notification.CountUnread(f.ctx, who.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := 0
+			if who == bob {
+				want = 1
+			}
+			if count != want {
+				t.Errorf("%sの未読の件数が異なります: got %d, want %d", who.Username(), count, want)
+			}
+		}
+	})
+
+	t.Run("ブログとコメントの削除は通知に連鎖し、存在しないブログの通知は保存できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		kept := f.blog(alice, "kept")
+		deleted := f.blog(alice, "deleted")
+		c := f.comment(kept, bob, "comment")
+		commentID := c.ID()
+
+		onKept := f.notification(alice, bob, kept, nil, f.tick())
+		f.notification(alice, bob, deleted, nil, f.tick())
+		f.notification(alice, bob, kept, &commentID, f.tick())
+
+		if err := f.repos.Blog.Delete(f.ctx, deleted.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.Comment.Delete(f.ctx, c.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := f.repos.Notification.FindByUserID(f.ctx, alice.ID(), nil, false, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, notificationIDs(got), []string{onKept.ID().String()})
+
+		orphan, err := notification.Reconstruct(uuid.New().String(), alice.ID(), bob.ID(), notification.TypeComment, deleted.ID(), nil, nil, f.tick())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.Notification.SaveAll(f.ctx, []*notification.Notification{orphan}); err == nil {
+			t.Error("存在しないブログの通知を保存できました")
+		}
+	})
+}
+
+// RunNotificationPreference : repository.NotificationPreferenceのスイートの実行
+func RunNotificationPreference(t *testing.T, factory Factory) {
+	t.Run("設定していないユーザーは全ての通知を受け取り、保存した設定は上書きされる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+
+		prefs := notification.NewPreferences(alice.ID())
+		if err := prefs.Set(notification.TypeComment, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.NotificationPreference.Save(f.ctx, prefs); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := f.repos.NotificationPreference.FindByUserIDs(f.ctx, []user.ID{alice.ID(), bob.ID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got[alice.ID().String()].Enabled(notification.TypeComment) || !got[alice.ID().String()].Enabled(notification.TypeMention) {
+			t.Errorf("保存した設定が異なります: %v", got[alice.ID().String()].All())
+		}
+		for _, notificationType := range []notification.Type{notification.TypeMention, notification.TypeComment, notification.TypeReply} {
+			if !got[bob.ID().String()].Enabled(notificationType) {
+				t.Errorf("設定していないユーザーが%sの通知を受け取りません", notificationType)
+			}
+		}
+
+		if err := prefs.Set(notification.TypeComment, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := prefs.Set(notification.TypeReply, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.NotificationPreference.Save(f.ctx, prefs); err != nil {
+			t.Fatal(err)
+		}
+		got, err = f.repos.NotificationPreference.FindByUserIDs(f.ctx, []user.ID{alice.ID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got[alice.ID().String()].Enabled(notification.TypeComment) || got[alice.ID().String()].Enabled(notification.TypeReply) {
+			t.Errorf("上書きした設定が異なります: %v", got[alice.ID().String()].All())
+		}
+
+		none, err := f.repos.NotificationPreference.FindByUserIDs(f.ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(none) != 0 {
+			t.Errorf("ユーザーを指定しない場合の設定が空ではありません: %v", none)
+		}
+	})
+}
+
+// notification : 未読の通知の作成
+func (f *fixture) notification(to, actor *user.User, b *blog.Blog, commentID *comment.ID, createdAt time.Time) *notification.Notification {
+	f.t.Helper()
+
+	return f.saveNotification(to, actor, b, commentID, nil, createdAt)
+}
+
+// saveNotification : 既読日時を指定した通知の作成
+func (f *fixture) saveNotification(to, actor *user.User, b *blog.Blog, commentID *comment.ID, readAt *time.Time, createdAt time.Time) *notification.Notification {
+	f.t.Helper()
+
+	notificationType := notification.TypeMention
+	if commentID != nil {
+		notificationType = notification.TypeComment
+	}
+	n, err := notification.Reconstruct(uuid.New().String(), to.ID(), actor.ID(), notificationType, b.ID(), commentID, readAt, createdAt)
+	if err != nil {
+		f.t.Fatalf("通知の生成に失敗しました: %v", err)
+	}
+	if err := f.repos.Notification.SaveAll(f.ctx, []*notification.Notification{n}); err != nil {
+		f.t.Fatalf("通知の保存に失敗しました: %v", err)
+	}
+	return n
+}
+
+// notificationsByID : ユーザーの全ての通知のIDによる索引
+func (f *fixture) notificationsByID(u *user.User) map[string]*notification.Notification {
+	f.t.Helper()
+
+	notifications, err := f.repos.Notification.FindByUserID(f.ctx, u.ID(), nil, false, 100)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	byID := make(map[string]*notification.Notification, len(notifications))
+	for _, n := range notifications {
+		byID[n.ID().String()] = n
+	}
+	return byID
+}
+
+// notificationIDs : 通知のIDの一覧
+func notificationIDs(notifications []*notification.Notification) []string {
+	var ids []string
+	for _, n := range notifications {
+		ids = append(ids, n.ID().String())
+	}
+	return ids
+}
+
+// sortedByIDDesc : IDの降順に並べた通知
+func sortedByIDDesc(notifications []*notification.Notification) []*notification.Notification {
+	sorted := append([]*notification.Notification(nil), notifications...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID().String() > sorted[j].ID().String() })
+	return sorted
+}
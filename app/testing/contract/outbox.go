@@ -0,0 +1,135 @@
+package contract
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"myblog/app/domain/model/event"
+
+	"github.com/google/uuid"
+)
+
+// RunOutbox : repository.Outboxのスイートの実行
+func RunOutbox(t *testing.T, factory Factory) {
+	t.Run("配信予定日時を過ぎた未配信のイベントを発生順に取得でき、冪等キーが同じイベントは保存しない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		first := f.event("first")
+		second := f.event("second")
+		third := f.event("third")
+		duplicate := f.newEvent("first")
+		if err := f.repos.Outbox.Save(f.ctx, duplicate); err != nil {
+			t.Fatal(err)
+		}
+
+		pending, err := f.repos.Outbox.FindPending(f.ctx, f.tick(), 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, eventIDs(pending), []string{first.ID().String(), second.ID().String(), third.ID().String()})
+		if string(pending[0].Payload()) != string(first.Payload()) || pending[0].IdempotencyKey() != "first" || pending[0].Type() != event.TypeUserRegistered {
+			t.Errorf("イベントが異なります: %+v", pending[0])
+		}
+		assertTime(t, "発生日時", pending[0].OccurredAt(), first.OccurredAt())
+
+		limited, err := f.repos.Outbox.FindPending(f.ctx, f.now, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, eventIDs(limited), []string{first.ID().String()})
+
+		// 発生日時より前の時点では配信予定日時を過ぎていない
+		early, err := f.repos.Outbox.FindPending(f.ctx, second.OccurredAt().Add(-time.Second), 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, eventIDs(early), []string{first.ID().String()})
+	})
+
+	t.Run("配信済みのイベントは取得せず、失敗したイベントは次の配信予定日時以降に取得する", func(t *testing.T) {
+		f := newFixture(t, factory)
+		published := f.event("published")
+		failed := f.event("failed")
+		pending := f.event("pending")
+
+		if err := f.repos.Outbox.MarkPublished(f.ctx, published.ID(), f.tick()); err != nil {
+			t.Fatal(err)
+		}
+		nextAttemptAt := f.now.Add(time.Hour)
+		if err := f.repos.Outbox.MarkFailed(f.ctx, failed.ID(), "配信エラー", nextAttemptAt); err != nil {
+			t.Fatal(err)
+		}
+
+		before, err := f.repos.Outbox.FindPending(f.ctx, f.tick(), 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, eventIDs(before), []string{pending.ID().String()})
+
+		after, err := f.repos.Outbox.FindPending(f.ctx, nextAttemptAt, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, eventIDs(after), []string{failed.ID().String(), pending.ID().String()})
+		if after[0].Attempts() != 1 || after[1].Attempts() != 0 {
+			t.Errorf("試行回数が異なります: got (%d, %d), want (1, 0)", after[0].Attempts(), after[1].Attempts())
+		}
+	})
+
+	t.Run("購読者への配信完了を重複なく記録できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		e := f.event("event")
+		other := f.event("other")
+
+		for _, subscriber := range []string{"notifier", "indexer", "notifier"} {
+			if err := f.repos.Outbox.SaveDelivery(f.ctx, e.ID(), subscriber, f.tick()); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		delivered, err := f.repos.Outbox.FindDeliveredSubscribers(f.ctx, e.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(delivered)
+		assertIDs(t, delivered, []string{"indexer", "notifier"})
+
+		none, err := f.repos.Outbox.FindDeliveredSubscribers(f.ctx, other.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, none, nil)
+	})
+}
+
+// newEvent : 冪等キーを指定した未保存のイベントの生成
+func (f *fixture) newEvent(idempotencyKey string) *event.Event {
+	f.t.Helper()
+
+	payload := []byte(`{"key":"` + idempotencyKey + `"}`)
+	e, err := event.Reconstruct(uuid.New().String(), event.TypeUserRegistered, uuid.New().String(), payload, idempotencyKey, f.tick(), nil, 0)
+	if err != nil {
+		f.t.Fatalf("イベントの生成に失敗しました: %v", err)
+	}
+	return e
+}
+
+// event : 冪等キーを指定したイベントの保存
+func (f *fixture) event(idempotencyKey string) *event.Event {
+	f.t.Helper()
+
+	e := f.newEvent(idempotencyKey)
+	if err := f.repos.Outbox.Save(f.ctx, e); err != nil {
+		f.t.Fatalf("イベントの保存に失敗しました: %v", err)
+	}
+	return e
+}
+
+// eventIDs : イベントのIDの一覧
+func eventIDs(events []*event.Event) []string {
+	var ids []string
+	for _, e := range events {
+		ids = append(ids, e.ID().String())
+	}
+	return ids
+}
@@ -0,0 +1,306 @@
+package contract
+
+import (
+	"strconv"
+	"testing"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/ranking"
+	"myblog/app/domain/model/user"
+
+	"github.com/google/uuid"
+)
+
+// RunRanking : repository.RankingRepositoryとランキングの参照（usecase.RankingListQuery）のスイートの実行
+func RunRanking(t *testing.T, factory Factory) {
+	t.Run("公開済みの最新のスナップショットのランキングを順位の昇順に取得できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		first := f.blog(alice, "first")
+		second := f.blog(alice, "second")
+
+		published := f.snapshot(ranking.TypeDaily, true, first, second)
+		// 後から集計した未公開のスナップショットは参照されない
+		unpublished := f.snapshot(ranking.TypeDaily, false, second)
+
+		rankings, err := f.repos.Ranking.GetRankings(f.ctx, ranking.TypeDaily, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, rankingBlogIDs(rankings), []string{first.ID().String(), second.ID().String()})
+		assertTime(t, "集計日時", rankings[0].CreatedAt, published.CalculatedAt)
+
+		limited, err := f.repos.Ranking.GetRankings(f.ctx, ranking.TypeDaily, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, rankingBlogIDs(limited), []string{first.ID().String()})
+
+		if err := f.repos.Ranking.PublishSnapshot(f.ctx, unpublished); err != nil {
+			t.Fatal(err)
+		}
+		rankings, err = f.repos.Ranking.GetRankings(f.ctx, ranking.TypeDaily, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, rankingBlogIDs(rankings), []string{second.ID().String()})
+
+		other, err := f.repos.Ranking.GetRankings(f.ctx, ranking.TypeWeekly, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, rankingBlogIDs(other), nil)
+	})
+
+	t.Run("存在しないスナップショット・ブログ・ユーザーのランキングと重複したランキングは保存できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		deleted := f.blog(alice, "deleted")
+		if err := f.repos.Blog.Delete(f.ctx, deleted.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+		snapshot := f.snapshot(ranking.TypeDaily, false)
+		missing := ranking.NewSnapshot(ranking.TypeDaily, f.tick())
+		unknownUser, err := user.NewID(uuid.New().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for name, save := range map[string]func() error{
+			"存在しないスナップショット": func() error {
+				return f.repos.Ranking.SaveRankings(f.ctx, missing, []*ranking.Ranking{ranking.NewRanking(b.ID(), 1, 1)})
+			},
+			"存在しないブログ": func() error {
+				return f.repos.Ranking.SaveRankings(f.ctx, snapshot, []*ranking.Ranking{ranking.NewRanking(deleted.ID(), 1, 1)})
+			},
+			"同じブログの重複": func() error {
+				return f.repos.Ranking.SaveRankings(f.ctx, snapshot, []*ranking.Ranking{ranking.NewRanking(b.ID(), 1, 2), ranking.NewRanking(b.ID(), 2, 1)})
+			},
+			"存在しないユーザーの著者ランキング": func() error {
+				return f.repos.Ranking.SaveAuthorRankings(f.ctx, snapshot, []*ranking.AuthorRanking{ranking.NewAuthorRanking(*unknownUser, 1, 1, 1)})
+			},
+			"存在しないブログの著者の人気記事ランキング": func() error {
+				return f.repos.Ranking.SaveAuthorBlogRankings(f.ctx, snapshot, []*ranking.AuthorBlogRanking{ranking.NewAuthorBlogRanking(alice.ID(), deleted.ID(), 1, 1)})
+			},
+		} {
+			if err := save(); err == nil {
+				t.Errorf("%sのランキングを保存できました", name)
+			}
+		}
+	})
+
+	t.Run("スナップショットとブログの削除はランキングに連鎖する", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		kept := f.blog(alice, "kept")
+		deleted := f.blog(alice, "deleted")
+		previous := f.snapshot(ranking.TypeDaily, true, kept)
+		latest := f.snapshot(ranking.TypeDaily, true, deleted, kept)
+
+		if err := f.repos.Blog.Delete(f.ctx, deleted.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+		rankings, err := f.repos.Ranking.GetRankings(f.ctx, ranking.TypeDaily, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, rankingBlogIDs(rankings), []string{kept.ID().String()})
+
+		// 最新のスナップショットを削除すると前回のランキングを参照する
+		if err := f.repos.Ranking.DeleteSnapshot(f.ctx, latest); err != nil {
+			t.Fatal(err)
+		}
+		rankings, err = f.repos.Ranking.GetRankings(f.ctx, ranking.TypeDaily, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, rankingBlogIDs(rankings), []string{kept.ID().String()})
+		assertTime(t, "集計日時", rankings[0].CreatedAt, previous.CalculatedAt)
+	})
+
+	t.Run("指定日時より前のスナップショットを種別ごとの最新の公開済みスナップショットを残して削除する", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+
+		f.snapshot(ranking.TypeDaily, true, b)            // 古い公開済み
+		f.snapshot(ranking.TypeDaily, false, b)           // 失敗した集計
+		latest := f.snapshot(ranking.TypeDaily, true, b)  // 最新の公開済み
+		f.snapshot(ranking.TypeDaily, false, b)           // 最新の公開済みより新しい未公開
+		weekly := f.snapshot(ranking.TypeWeekly, true, b) // 種別の唯一の公開済み
+		before := f.tick()
+		f.snapshot(ranking.TypeMonthly, false, b) // 指定日時以降の未公開
+
+		deleted, err := f.repos.Ranking.DeleteSnapshotsBefore(f.ctx, before)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted != 3 {
+			t.Errorf("削除されたスナップショットの件数が異なります: got %d, want 3", deleted)
+		}
+
+		for rankingType, want := range map[ranking.Type]*ranking.Snapshot{ranking.TypeDaily: latest, ranking.TypeWeekly: weekly} {
+			rankings, err := f.repos.Ranking.GetRankings(f.ctx, rankingType, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rankings) != 1 {
+				t.Fatalf("%sのランキングが削除されました", rankingType)
+			}
+			assertTime(t, string(rankingType)+"の集計日時", rankings[0].CreatedAt, want.CalculatedAt)
+		}
+
+		// 残りは種別ごとの最新の公開済みスナップショットと指定日時以降のスナップショットのみ
+		deleted, err = f.repos.Ranking.DeleteSnapshotsBefore(f.ctx, before)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted != 0 {
+			t.Errorf("2回目に削除されたスナップショットの件数が異なります: got %d, want 0", deleted)
+		}
+	})
+
+	t.Run("ランキングを前回の順位とブログ・著者の情報付きで参照できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		a1 := f.blog(alice, "a1")
+		a2 := f.blog(alice, "a2")
+		b1 := f.blog(bob, "b1")
+
+		empty, err := f.repos.RankingList.GetPopularRanking(f.ctx, ranking.TypeDaily, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if empty.CalculatedAt != nil || len(empty.Blogs) != 0 {
+			t.Errorf("集計前のランキングが空ではありません: %+v", empty)
+		}
+
+		previous := f.snapshot(ranking.TypeDaily, true, a1, a2)
+		f.authorSnapshot(previous, alice)
+		latest := f.snapshot(ranking.TypeDaily, true, b1, a2, a1)
+		f.authorSnapshot(latest, bob, alice)
+		f.snapshot(ranking.TypeDaily, false, a1) // 未公開
+
+		popular, err := f.repos.RankingList.GetPopularRanking(f.ctx, ranking.TypeDaily, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if popular.CalculatedAt == nil {
+			t.Fatal("集計日時がありません")
+		}
+		assertTime(t, "集計日時", *popular.CalculatedAt, latest.CalculatedAt)
+		var gotBlogs []string
+		for _, rb := range popular.Blogs {
+			gotBlogs = append(gotBlogs, rb.Title+"/"+rb.AuthorName+"/"+movement(rb.Movement()))
+		}
+		assertIDs(t, gotBlogs, []string{"b1/bob/new", "a2/alice/+0", "a1/alice/-2"})
+		if popular.Blogs[0].BlogID != b1.ID().String() || popular.Blogs[0].Excerpt != "content of b1" || popular.Blogs[0].Position != 1 {
+			t.Errorf("ブログの概要が異なります: %+v", popular.Blogs[0])
+		}
+
+		authors, err := f.repos.RankingList.GetAuthorRanking(f.ctx, ranking.TypeDaily, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gotAuthors []string
+		for _, ra := range authors.Authors {
+			gotAuthors = append(gotAuthors, ra.Username+"/"+movement(ra.Movement()))
+		}
+		assertIDs(t, gotAuthors, []string{"bob/new", "alice/-1"})
+
+		authorBlogs, err := f.repos.RankingList.GetAuthorBlogRanking(f.ctx, alice.ID().String(), ranking.TypeDaily, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gotAuthorBlogs []string
+		for _, rb := range authorBlogs.Blogs {
+			gotAuthorBlogs = append(gotAuthorBlogs, rb.Title+"/"+movement(rb.Movement()))
+		}
+		assertIDs(t, gotAuthorBlogs, []string{"a2/+1"})
+
+		history, err := f.repos.RankingList.GetBlogRankingHistory(f.ctx, a1.ID().String(), ranking.TypeDaily, previous.CalculatedAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(history) != 2 || history[0].Position != 1 || history[1].Position != 3 {
+			t.Fatalf("順位の推移が異なります: %+v", history)
+		}
+		assertTime(t, "推移の集計日時", history[0].CalculatedAt, previous.CalculatedAt)
+
+		recent, err := f.repos.RankingList.GetBlogRankingHistory(f.ctx, a1.ID().String(), ranking.TypeDaily, latest.CalculatedAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(recent) != 1 {
+			t.Errorf("指定日時以降の順位の推移が異なります: %+v", recent)
+		}
+	})
+}
+
+// snapshot : ランキングのスナップショットの作成（ブログは指定した順に1位からとする）
+func (f *fixture) snapshot(rankingType ranking.Type, publish bool, blogs ...*blog.Blog) *ranking.Snapshot {
+	f.t.Helper()
+
+	snapshot := ranking.NewSnapshot(rankingType, f.tick())
+	if err := f.repos.Ranking.CreateSnapshot(f.ctx, snapshot); err != nil {
+		f.t.Fatalf("スナップショットの作成に失敗しました: %v", err)
+	}
+
+	rankings := make([]*ranking.Ranking, len(blogs))
+	authorBlogs := make([]*ranking.AuthorBlogRanking, len(blogs))
+	positions := make(map[user.ID]int)
+	for i, b := range blogs {
+		score := float64(len(blogs) - i)
+		rankings[i] = ranking.NewRanking(b.ID(), i+1, score)
+		positions[b.UserID()]++
+		authorBlogs[i] = ranking.NewAuthorBlogRanking(b.UserID(), b.ID(), positions[b.UserID()], score)
+	}
+	if err := f.repos.Ranking.SaveRankings(f.ctx, snapshot, rankings); err != nil {
+		f.t.Fatalf("ランキングの保存に失敗しました: %v", err)
+	}
+	if err := f.repos.Ranking.SaveAuthorBlogRankings(f.ctx, snapshot, authorBlogs); err != nil {
+		f.t.Fatalf("著者ごとの人気記事ランキングの保存に失敗しました: %v", err)
+	}
+
+	if publish {
+		if err := f.repos.Ranking.PublishSnapshot(f.ctx, snapshot); err != nil {
+			f.t.Fatalf("スナップショットの公開に失敗しました: %v", err)
+		}
+	}
+	return snapshot
+}
+
+// authorSnapshot : スナップショットへの著者ランキングの追加（著者は指定した順に1位からとする）
+func (f *fixture) authorSnapshot(snapshot *ranking.Snapshot, authors ...*user.User) {
+	f.t.Helper()
+
+	rankings := make([]*ranking.AuthorRanking, len(authors))
+	for i, author := range authors {
+		rankings[i] = ranking.NewAuthorRanking(author.ID(), i+1, float64(len(authors)-i), 1)
+	}
+	if err := f.repos.Ranking.SaveAuthorRankings(f.ctx, snapshot, rankings); err != nil {
+		f.t.Fatalf("著者ランキングの保存に失敗しました: %v", err)
+	}
+}
+
+// rankingBlogIDs : ランキングのブログIDの一覧
+func rankingBlogIDs(rankings []*ranking.Ranking) []string {
+	var ids []string
+	for _, rank := range rankings {
+		ids = append(ids, rank.BlogID.String())
+	}
+	return ids
+}
+
+// movement : 順位の変動の表記（前回ランク外の場合はnew）
+func movement(m *int) string {
+	if m == nil {
+		return "new"
+	}
+	if *m >= 0 {
+		return "+" + strconv.Itoa(*m)
+	}
+	return strconv.Itoa(*m)
+}
@@ -0,0 +1,154 @@
+package contract
+
+import (
+	"testing"
+
+	"myblog/app/domain/model/reaction"
+	"myblog/app/domain/model/user"
+)
+
+// RunReaction : repository.Reactionのスイートの実行
+func RunReaction(t *testing.T, factory Factory) {
+	t.Run("対象ごと・絵文字ごとの件数とユーザーのリアクションを取得でき、保存は置き換えになる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		carol := f.user("carol")
+		b := f.blog(alice, "title")
+		other := f.blog(alice, "other")
+		empty := f.blog(alice, "empty")
+		blogID, otherID := b.ID().String(), other.ID().String()
+
+		f.reaction(alice, reaction.TargetBlog, blogID, "👍")
+		f.reaction(bob, reaction.TargetBlog, blogID, "🎉")
+		f.reaction(carol, reaction.TargetBlog, blogID, "👍")
+		f.reaction(bob, reaction.TargetBlog, otherID, "👍")
+		// 同じユーザーの同じ対象へのリアクションは置き換える
+		f.reaction(bob, reaction.TargetBlog, blogID, "👍")
+
+		counts, err := f.repos.Reaction.CountByTargetIDs(f.ctx, reaction.TargetBlog, []string{blogID, otherID, empty.ID().String()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(counts[blogID]) != 1 || counts[blogID]["👍"] != 3 || counts[otherID]["👍"] != 1 || len(counts[empty.ID().String()]) != 0 {
+			t.Errorf("リアクションの件数が異なります: %v", counts)
+		}
+
+		emojis, err := f.repos.Reaction.FindEmojisByUserID(f.ctx, bob.ID(), reaction.TargetBlog, []string{blogID, empty.ID().String()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(emojis) != 1 || emojis[blogID] != "👍" {
+			t.Errorf("ユーザーのリアクションが異なります: %v", emojis)
+		}
+
+		for name, query := range map[string]func() (int, error){
+			"件数": func() (int, error) {
+				got, err := f.repos.Reaction.CountByTargetIDs(f.ctx, reaction.TargetBlog, nil)
+				return len(got), err
+			},
+			"ユーザーのリアクション": func() (int, error) {
+				got, err := f.repos.Reaction.FindEmojisByUserID(f.ctx, bob.ID(), reaction.TargetBlog, nil)
+				return len(got), err
+			},
+		} {
+			n, err := query()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != 0 {
+				t.Errorf("対象を指定しない場合の%sが空ではありません", name)
+			}
+		}
+	})
+
+	t.Run("ブログとコメントのリアクションは区別され、削除は存在しない場合も成功する", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		c := f.comment(b, alice, "comment")
+		blogID, commentID := b.ID().String(), c.ID().String()
+
+		f.reaction(alice, reaction.TargetBlog, blogID, "👍")
+		f.reaction(alice, reaction.TargetComment, commentID, "🎉")
+
+		if err := f.repos.Reaction.Delete(f.ctx, alice.ID(), reaction.TargetBlog, blogID); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.Reaction.Delete(f.ctx, alice.ID(), reaction.TargetBlog, blogID); err != nil {
+			t.Fatalf("存在しないリアクションの削除に失敗しました: %v", err)
+		}
+
+		blogCounts, err := f.repos.Reaction.CountByTargetIDs(f.ctx, reaction.TargetBlog, []string{blogID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(blogCounts[blogID]) != 0 {
+			t.Errorf("削除したリアクションが残っています: %v", blogCounts)
+		}
+		commentCounts, err := f.repos.Reaction.CountByTargetIDs(f.ctx, reaction.TargetComment, []string{commentID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if commentCounts[commentID]["🎉"] != 1 {
+			t.Errorf("コメントのリアクションの件数が異なります: %v", commentCounts)
+		}
+	})
+
+	t.Run("対象の削除はリアクションに連鎖し、存在しない対象へのリアクションは保存できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		b := f.blog(alice, "title")
+		c := f.comment(b, alice, "comment")
+		commentID := c.ID().String()
+		f.reaction(alice, reaction.TargetComment, commentID, "👍")
+
+		if err := f.repos.Comment.Delete(f.ctx, commentID); err != nil {
+			t.Fatal(err)
+		}
+		counts, err := f.repos.Reaction.CountByTargetIDs(f.ctx, reaction.TargetComment, []string{commentID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(counts[commentID]) != 0 {
+			t.Errorf("削除したコメントのリアクションが残っています: %v", counts)
+		}
+
+		if err := f.repos.Blog.Delete(f.ctx, b.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+		for targetType, targetID := range map[reaction.TargetType]string{reaction.TargetBlog: b.ID().String(), reaction.TargetComment: commentID} {
+			r, err := reaction.NewReaction(alice.ID(), targetType, targetID, "👍", f.emojis())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := f.repos.Reaction.Save(f.ctx, r); err == nil {
+				t.Errorf("存在しない%sへのリアクションを保存できました", targetType)
+			}
+		}
+	})
+}
+
+// reaction : リアクションの保存
+func (f *fixture) reaction(u *user.User, targetType reaction.TargetType, targetID, emoji string) {
+	f.t.Helper()
+
+	r, err := reaction.NewReaction(u.ID(), targetType, targetID, emoji, f.emojis())
+	if err != nil {
+		f.t.Fatalf("リアクションの生成に失敗しました: %v", err)
+	}
+	if err := f.repos.Reaction.Save(f.ctx, r); err != nil {
+		f.t.Fatalf("リアクションの保存に失敗しました: %v", err)
+	}
+}
+
+// emojis : スイートで使用する絵文字のセット
+func (f *fixture) emojis() *reaction.EmojiSet {
+	f.t.Helper()
+
+	set, err := reaction.NewEmojiSet([]string{"👍", "🎉"})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return set
+}
@@ -0,0 +1,190 @@
+package contract
+
+import (
+	"bytes"
+	"testing"
+
+	"myblog/app/domain/model/user"
+
+	"github.com/google/uuid"
+)
+
+// RunUser : repository.Userのスイートの実行
+func RunUser(t *testing.T, factory Factory) {
+	t.Run("保存したユーザーをIDとメールアドレスで取得できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+
+		byID, err := f.repos.User.FindByID(f.ctx, alice.ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertUser(t, byID, alice)
+
+		byEmail, err := f.repos.User.FindByEmail(f.ctx, alice.Email())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertUser(t, byEmail, alice)
+	})
+
+	t.Run("存在しないユーザーはエラー", func(t *testing.T) {
+		f := newFixture(t, factory)
+		f.user("alice")
+
+		_, err := f.repos.User.FindByID(f.ctx, "unknown")
+		assertNotFound(t, err, "user not found with id: unknown")
+
+		_, err = f.repos.User.FindByEmail(f.ctx, "unknown@example.com")
+		assertNotFound(t, err, "user not found with email: unknown@example.com")
+	})
+
+	t.Run("メールアドレスが重複するユーザーは保存できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+
+		duplicate, err := user.Reconstruct(uuid.New().String(), "alice2", alice.Email(), []byte("hashed"), f.tick(), f.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.User.Save(f.ctx, duplicate); err == nil {
+			t.Fatal("メールアドレスが重複するユーザーを保存できました")
+		}
+		if _, err := f.repos.User.FindByID(f.ctx, duplicate.ID().String()); err == nil {
+			t.Error("保存に失敗したユーザーを取得できました")
+		}
+	})
+
+	t.Run("ユーザー名で作成日時の昇順に取得できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		f.user("bob")
+		carol := f.user("carol")
+
+		tests := []struct {
+			name      string
+			usernames []string
+			want      []string
+		}{
+			{name: "指定した順序によらず作成日時の昇順", usernames: []string{"carol", "alice"}, want: []string{alice.ID().String(), carol.ID().String()}},
+			{name: "存在しないユーザー名は無視する", usernames: []string{"carol", "unknown"}, want: []string{carol.ID().String()}},
+			{name: "ユーザー名の指定がない", usernames: nil, want: nil},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				users, err := f.repos.User.FindByUsernames(f.ctx, tt.usernames)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertIDs(t, userIDs(users), tt.want)
+			})
+		}
+	})
+
+	t.Run("ユーザーを更新できる", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+
+		if err := alice.UpdateUsername("alice2"); err != nil {
+			t.Fatal(err)
+		}
+		if err := alice.UpdateEmail("alice2@example.com"); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.repos.User.Update(f.ctx, alice); err != nil {
+			t.Fatal(err)
+		}
+
+		saved, err := f.repos.User.FindByID(f.ctx, alice.ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saved.Username() != "alice2" || saved.Email() != "alice2@example.com" {
+			t.Errorf("更新されていません: %s, %s", saved.Username(), saved.Email())
+		}
+		if _, err := f.repos.User.FindByEmail(f.ctx, "alice@example.com"); err == nil {
+			t.Error("更新前のメールアドレスで取得できました")
+		}
+
+		other, err := f.repos.User.FindByID(f.ctx, bob.ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertUser(t, other, bob)
+	})
+
+	t.Run("存在しないユーザーは更新できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		unknown, err := user.Reconstruct("unknown", "unknown", "unknown@example.com", []byte("hashed"), f.tick(), f.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = f.repos.User.Update(f.ctx, unknown)
+		assertNotFound(t, err, "user not found with id: unknown")
+	})
+
+	t.Run("ユーザーを削除するとブログとコメントも削除される", func(t *testing.T) {
+		f := newFixture(t, factory)
+		alice := f.user("alice")
+		bob := f.user("bob")
+		aliceBlog := f.blog(alice, "alice's")
+		bobBlog := f.blog(bob, "bob's")
+		commentOnOwn := f.comment(aliceBlog, bob, "on alice's blog")
+		commentByAlice := f.comment(bobBlog, alice, "by alice")
+		kept := f.comment(bobBlog, bob, "kept")
+
+		if err := f.repos.User.Delete(f.ctx, alice.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := f.repos.User.FindByID(f.ctx, alice.ID().String())
+		assertNotFound(t, err, "user not found with id: "+alice.ID().String())
+		_, err = f.repos.Blog.FindByID(f.ctx, aliceBlog.ID().String())
+		assertNotFound(t, err, "blog not found with id: "+aliceBlog.ID().String())
+		for _, c := range []string{commentOnOwn.ID().String(), commentByAlice.ID().String()} {
+			_, err = f.repos.Comment.FindByID(f.ctx, c)
+			assertNotFound(t, err, "comment not found with id: "+c)
+		}
+
+		if _, err := f.repos.Blog.FindByID(f.ctx, bobBlog.ID().String()); err != nil {
+			t.Errorf("他のユーザーのブログが削除されました: %v", err)
+		}
+		if _, err := f.repos.Comment.FindByID(f.ctx, kept.ID().String()); err != nil {
+			t.Errorf("他のユーザーのコメントが削除されました: %v", err)
+		}
+	})
+
+	t.Run("存在しないユーザーは削除できない", func(t *testing.T) {
+		f := newFixture(t, factory)
+		f.user("alice")
+
+		err := f.repos.User.Delete(f.ctx, "unknown")
+		assertNotFound(t, err, "user not found with id: unknown")
+	})
+}
+
+// assertUser : 取得したユーザーの検証
+func assertUser(t *testing.T, got, want *user.User) {
+	t.Helper()
+
+	if got.ID() != want.ID() || got.Username() != want.Username() || got.Email() != want.Email() {
+		t.Errorf("ユーザーが異なります: got (%s, %s, %s), want (%s, %s, %s)",
+			got.ID().String(), got.Username(), got.Email(), want.ID().String(), want.Username(), want.Email())
+	}
+	if !bytes.Equal(got.Password(), want.Password()) {
+		t.Error("パスワードが異なります")
+	}
+	assertTime(t, "作成日時", got.CreatedAt(), want.CreatedAt())
+}
+
+// userIDs : ユーザーのIDの一覧
+func userIDs(users []*user.User) []string {
+	var ids []string
+	for _, u := range users {
+		ids = append(ids, u.ID().String())
+	}
+	return ids
+}
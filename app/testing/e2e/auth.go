@@ -0,0 +1,55 @@
+package e2e
+
+import "net/http"
+
+// Password : Registerで登録するユーザーのパスワード
+const Password = "password123"
+
+// User : 登録してログインしたユーザー
+type User struct {
+	ID       string
+	Username string
+	Email    string
+	Token    string
+}
+
+// Register : ユーザーを登録してログイン（IDはスナップショットで<user:ユーザー名>と表記する）
+func (h *Harness) Register(username string) *User {
+	h.t.Helper()
+
+	email := username + "@example.com"
+	res := h.Expect(h.Do(http.MethodPost, "/api/users/register", "", map[string]string{
+		"username": username,
+		"email":    email,
+		"password": Password,
+	}), http.StatusCreated)
+
+	var registered struct {
+		ID string `json:"id"`
+	}
+	h.Decode(res, &registered)
+	h.Label(registered.ID, "user:"+username)
+
+	return &User{
+		ID:       registered.ID,
+		Username: username,
+		Email:    email,
+		Token:    h.Login(email, Password),
+	}
+}
+
+// Login : ログインしてJWTを取得
+func (h *Harness) Login(email, password string) string {
+	h.t.Helper()
+
+	res := h.Expect(h.Do(http.MethodPost, "/api/users/login", "", map[string]string{
+		"email":    email,
+		"password": password,
+	}), http.StatusOK)
+
+	var login struct {
+		Token string `json:"token"`
+	}
+	h.Decode(res, &login)
+	return login.Token
+}
@@ -0,0 +1,120 @@
+// Package e2e : HTTPのエンドツーエンドテストのハーネス
+// 実際のルーターにリクエストを送り、正規化したレスポンスをゴールデンファイル（スナップショット）と比較する
+// 環境変数UPDATE_SNAPSHOTSを指定して実行するとスナップショットを更新する
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Harness : テストごとのリクエストの送信とスナップショットの検証
+// IDの置き換えはテスト全体で共有するため、同じIDは全てのスナップショットで同じ表記になる
+type Harness struct {
+	t          *testing.T
+	handler    http.Handler
+	normalizer *normalizer
+}
+
+// New : Harnessの生成
+func New(t *testing.T, handler http.Handler) *Harness {
+	return &Harness{
+		t:          t,
+		handler:    handler,
+		normalizer: newNormalizer(),
+	}
+}
+
+// Response : レスポンス（スナップショットに記録するためリクエストも保持する）
+type Response struct {
+	Method      string
+	Target      string
+	RequestBody []byte
+	Status      int
+	Header      http.Header
+	Body        []byte
+}
+
+// NewRequest : リクエストの生成（tokenが空の場合は認証しない、bodyがnilの場合は本文なし）
+func (h *Harness) NewRequest(method, target, token string, body any) *http.Request {
+	h.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			h.t.Fatalf("リクエストの本文の変換に失敗しました: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, target, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+// Do : リクエストの送信
+func (h *Harness) Do(method, target, token string, body any) *Response {
+	h.t.Helper()
+	return h.DoRequest(h.NewRequest(method, target, token, body))
+}
+
+// DoRequest : 生成したリクエストの送信（ヘッダーやコンテキストを変更する場合に使用する）
+func (h *Harness) DoRequest(req *http.Request) *Response {
+	h.t.Helper()
+
+	var requestBody []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			h.t.Fatalf("リクエストの本文の読み込みに失敗しました: %v", err)
+		}
+		requestBody = data
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	rec := httptest.NewRecorder()
+	h.handler.ServeHTTP(rec, req)
+
+	return &Response{
+		Method:      req.Method,
+		Target:      req.URL.RequestURI(),
+		RequestBody: requestBody,
+		Status:      rec.Code,
+		Header:      rec.Header(),
+		Body:        rec.Body.Bytes(),
+	}
+}
+
+// Label : IDなどの値をスナップショットで指定した表記に置き換える（例: ブログのIDを<blog:first>にする）
+func (h *Harness) Label(value, label string) {
+	h.normalizer.label(value, label)
+}
+
+// Expect : ステータスコードの検証（一致しない場合はテストを中断する）
+func (h *Harness) Expect(res *Response, status int) *Response {
+	h.t.Helper()
+
+	if res.Status != status {
+		h.t.Fatalf("%s %s: ステータスコードが異なります: got %d, want %d\n%s", res.Method, res.Target, res.Status, status, res.Body)
+	}
+	return res
+}
+
+// Decode : レスポンスの本文をJSONとして変換
+func (h *Harness) Decode(res *Response, v any) {
+	h.t.Helper()
+
+	if err := json.Unmarshal(res.Body, v); err != nil {
+		h.t.Fatalf("%s %s: レスポンスの本文の変換に失敗しました: %v\n%s", res.Method, res.Target, err, res.Body)
+	}
+}
@@ -0,0 +1,225 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SnapshotDir : スナップショットを保存するディレクトリ（テストのパッケージのディレクトリからの相対パス）
+const SnapshotDir = "testdata/snapshots"
+
+var (
+	uuidPattern = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	timePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	// eventIDPattern : Server-Sent EventsのイベントID（起動時刻から採番される）
+	eventIDPattern = regexp.MustCompile(`(?m)^id: \d+$`)
+	// ticketPattern : URLのクエリパラメータに含まれるストリーミング配信の接続用チケット
+	ticketPattern = regexp.MustCompile(`([?&]ticket=)[^&\s]+`)
+)
+
+// maskedKeys : 実行ごとに値が変わるため伏せるJSONのキーと置き換える表記
+var maskedKeys = map[string]string{
+	"token":       "<token>",
+	"ticket":      "<ticket>",
+	"next_cursor": "<cursor>",
+}
+
+// Snapshot : レスポンスをスナップショット（testdata/snapshots/<テスト名>/<name>.golden）と比較
+// 環境変数UPDATE_SNAPSHOTSが真の場合は比較せずにスナップショットを更新する
+func (h *Harness) Snapshot(name string, res *Response) {
+	h.t.Helper()
+
+	got := h.render(res)
+	path := filepath.Join(SnapshotDir, h.t.Name(), name+".golden")
+
+	if updateSnapshots() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			h.t.Fatalf("スナップショットのディレクトリの作成に失敗しました: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			h.t.Fatalf("スナップショットの書き込みに失敗しました: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		h.t.Errorf("スナップショットがありません: %s（UPDATE_SNAPSHOTS=1を指定して実行すると作成します）", path)
+		return
+	}
+	if err != nil {
+		h.t.Fatalf("スナップショットの読み込みに失敗しました: %v", err)
+	}
+	if got != string(want) {
+		h.t.Errorf("スナップショットと一致しません: %s（意図した変更の場合はUPDATE_SNAPSHOTS=1を指定して実行すると更新します）\n%s", path, diff(string(want), got))
+	}
+}
+
+// render : スナップショットの内容（リクエスト、ステータスコード、Content-Type、本文）
+func (h *Harness) render(res *Response) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s\n", res.Method, h.normalizer.text(res.Target))
+	if len(res.RequestBody) > 0 {
+		b.WriteString(h.normalizer.body("application/json", res.RequestBody))
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "%d %s\n", res.Status, http.StatusText(res.Status))
+	contentType := res.Header.Get("Content-Type")
+	if contentType != "" {
+		fmt.Fprintf(&b, "Content-Type: %s\n", contentType)
+	}
+	if len(res.Body) > 0 {
+		b.WriteString("\n")
+		b.WriteString(h.normalizer.body(contentType, res.Body))
+	}
+	return b.String()
+}
+
+// updateSnapshots : スナップショットを更新するかどうか
+func updateSnapshots() bool {
+	update, err := strconv.ParseBool(os.Getenv("UPDATE_SNAPSHOTS"))
+	return err == nil && update
+}
+
+// normalizer : 実行ごとに変わる値（UUID、日時、トークン、イベントID）を固定の表記に置き換える
+type normalizer struct {
+	labels map[string]string
+	next   int
+}
+
+// newNormalizer : normalizerの生成
+func newNormalizer() *normalizer {
+	return &normalizer{labels: make(map[string]string)}
+}
+
+// label : 値の表記の指定
+func (n *normalizer) label(value, label string) {
+	n.labels[value] = "<" + label + ">"
+}
+
+// id : UUIDの表記（指定がない場合は出現順に<id:1>, <id:2>, ...とする）
+func (n *normalizer) id(value string) string {
+	if label, ok := n.labels[value]; ok {
+		return label
+	}
+	n.next++
+	label := fmt.Sprintf("<id:%d>", n.next)
+	n.labels[value] = label
+	return label
+}
+
+// text : 文字列に含まれるUUID、日時、チケットの置き換え
+func (n *normalizer) text(s string) string {
+	s = uuidPattern.ReplaceAllStringFunc(s, n.id)
+	s = ticketPattern.ReplaceAllString(s, "${1}<ticket>")
+	return timePattern.ReplaceAllString(s, "<time>")
+}
+
+// body : 本文の置き換え（JSONの場合はキーの順に整形する）
+func (n *normalizer) body(contentType string, body []byte) string {
+	if strings.HasPrefix(contentType, "application/json") {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var v any
+		if err := decoder.Decode(&v); err == nil {
+			var buf bytes.Buffer
+			encoder := json.NewEncoder(&buf)
+			encoder.SetEscapeHTML(false)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(n.json(v)); err == nil {
+				return buf.String()
+			}
+		}
+	}
+
+	s := n.text(string(body))
+	if strings.HasPrefix(contentType, "text/event-stream") {
+		s = eventIDPattern.ReplaceAllString(s, "id: <event-id>")
+	}
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	return s
+}
+
+// json : JSONの値の置き換え（出現順のIDを決めるためオブジェクトはキーの順に処理する）
+func (n *normalizer) json(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if masked, ok := maskedKeys[key]; ok {
+				if _, isString := v[key].(string); isString {
+					v[key] = masked
+					continue
+				}
+			}
+			v[key] = n.json(v[key])
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = n.json(v[i])
+		}
+		return v
+	case string:
+		return n.text(v)
+	default:
+		return v
+	}
+}
+
+// diff : 行単位の差分（-はスナップショット、+は今回のレスポンス）
+func diff(want, got string) string {
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+
+	// 最長共通部分列の長さ（lcs[i][j]はa[i:]とb[j:]の長さ）
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			fmt.Fprintf(&out, "  %s\n", a[i])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			fmt.Fprintf(&out, "+ %s\n", b[j])
+			j++
+		default:
+			fmt.Fprintf(&out, "- %s\n", a[i])
+			i++
+		}
+	}
+	return out.String()
+}
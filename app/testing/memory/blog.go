@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// blogRow : blogsテーブルの行
+type blogRow struct {
+	id        string
+	userID    string
+	title     string
+	content   string
+	createdAt time.Time
+	updatedAt time.Time
+	seq       int64
+}
+
+// toModel : 行からドメインモデルへの変換
+func (row blogRow) toModel() (*blog.Blog, error) {
+	userID, err := user.NewID(row.userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return blog.Reconstruct(
+		row.id,
+		*userID,
+		row.title,
+		row.content,
+		row.createdAt,
+		row.updatedAt,
+	)
+}
+
+// blogsNewestFirst : ブログを作成日時の降順に並び替えてドメインモデルに変換
+func blogsNewestFirst(rows []blogRow) ([]*blog.Blog, error) {
+	sortByTime(rows, func(row blogRow) (time.Time, int64) { return row.createdAt, row.seq }, true)
+
+	blogs := make([]*blog.Blog, len(rows))
+	for i, row := range rows {
+		b, err := row.toModel()
+		if err != nil {
+			return nil, err
+		}
+		blogs[i] = b
+	}
+	return blogs, nil
+}
+
+// BlogRepository : ブログリポジトリのインメモリ実装
+type BlogRepository struct {
+	store *Store
+}
+
+// NewBlogRepository : BlogRepositoryの生成
+func NewBlogRepository(store *Store) repository.Blog {
+	return &BlogRepository{store: store}
+}
+
+// Save : ブログの保存
+func (r *BlogRepository) Save(ctx context.Context, blog *blog.Blog) error {
+	return r.store.do(ctx, func(t *tables) error {
+		id := blog.ID().String()
+		if _, ok := t.blogs[id]; ok {
+			return fmt.Errorf("%w: blogs.id %s", ErrDuplicateKey, id)
+		}
+		if err := t.requireUser(blog.UserID().String()); err != nil {
+			return err
+		}
+
+		t.blogs[id] = blogRow{
+			id:        id,
+			userID:    blog.UserID().String(),
+			title:     blog.Title(),
+			content:   blog.Content(),
+			createdAt: normalizeTime(blog.CreatedAt()),
+			updatedAt: normalizeTime(blog.UpdatedAt()),
+			seq:       t.nextSeq(),
+		}
+		return nil
+	})
+}
+
+// FindByID : IDによるブログ検索
+func (r *BlogRepository) FindByID(ctx context.Context, id string) (*blog.Blog, error) {
+	var found *blog.Blog
+	err := r.store.do(ctx, func(t *tables) error {
+		row, ok := t.blogs[id]
+		if !ok {
+			return fmt.Errorf("blog not found with id: %s", id)
+		}
+
+		var err error
+		found, err = row.toModel()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// FindByUserID : ユーザーIDによるブログ検索（新しい順）
+func (r *BlogRepository) FindByUserID(ctx context.Context, userID user.ID) ([]*blog.Blog, error) {
+	var blogs []*blog.Blog
+	err := r.store.do(ctx, func(t *tables) error {
+		var rows []blogRow
+		for _, row := range t.blogs {
+			if row.userID == userID.String() {
+				rows = append(rows, row)
+			}
+		}
+
+		var err error
+		blogs, err = blogsNewestFirst(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return blogs, nil
+}
+
+// FindAll : 全ブログ検索（新しい順。offset件目からlimit件）
+func (r *BlogRepository) FindAll(ctx context.Context, offset, limit int) ([]*blog.Blog, error) {
+	var blogs []*blog.Blog
+	err := r.store.do(ctx, func(t *tables) error {
+		rows := make([]blogRow, 0, len(t.blogs))
+		for _, row := range t.blogs {
+			rows = append(rows, row)
+		}
+
+		all, err := blogsNewestFirst(rows)
+		if err != nil {
+			return err
+		}
+		blogs = page(all, offset, limit)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return blogs, nil
+}
+
+// Update : ブログの更新
+func (r *BlogRepository) Update(ctx context.Context, blog *blog.Blog) error {
+	return r.store.do(ctx, func(t *tables) error {
+		id := blog.ID().String()
+		row, ok := t.blogs[id]
+		if !ok {
+			return fmt.Errorf("blog not found with id: %s", id)
+		}
+
+		row.title = blog.Title()
+		row.content = blog.Content()
+		row.updatedAt = normalizeTime(time.Now())
+		t.blogs[id] = row
+		return nil
+	})
+}
+
+// Delete : ブログの削除（コメントやいいね等もカスケード削除する）
+func (r *BlogRepository) Delete(ctx context.Context, id string) error {
+	return r.store.do(ctx, func(t *tables) error {
+		if _, ok := t.blogs[id]; !ok {
+			return fmt.Errorf("blog not found with id: %s", id)
+		}
+		t.deleteBlog(id)
+		return nil
+	})
+}
+
+// page : LIMIT/OFFSETと同じ範囲の切り出し（範囲外の場合は空）
+func page[T any](rows []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(rows) || limit <= 0 {
+		return []T{}
+	}
+	end := offset + limit
+	if end > len(rows) {
+		end = len(rows)
+	}
+	return rows[offset:end]
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+
+	"myblog/app/domain/model/view"
+	"myblog/app/domain/repository"
+)
+
+// blogViewKey : blog_view_countsテーブルの主キー
+type blogViewKey struct {
+	blogID string
+	date   string // YYYY-MM-DD
+}
+
+// BlogViewRepository : ブログ閲覧数リポジトリのインメモリ実装
+type BlogViewRepository struct {
+	store *Store
+}
+
+// NewBlogViewRepository : BlogViewRepositoryの生成
+func NewBlogViewRepository(store *Store) repository.BlogView {
+	return &BlogViewRepository{store: store}
+}
+
+// IncrementDailyCounts : 日別閲覧数の加算
+func (r *BlogViewRepository) IncrementDailyCounts(ctx context.Context, counts []*view.DailyCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	return r.store.do(ctx, func(t *tables) error {
+		for _, count := range counts {
+			if err := t.requireBlog(count.BlogID.String()); err != nil {
+				return err
+			}
+		}
+
+		for _, count := range counts {
+			t.blogViews[blogViewKey{blogID: count.BlogID.String(), date: count.Date.Format("2006-01-02")}] += count.Views
+		}
+		return nil
+	})
+}
+
+// DailyViews : ブログの日別閲覧数の取得（日付はYYYY-MM-DD。テストでの検証用）
+func (s *Store) DailyViews(blogID string) map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	views := make(map[string]int)
+	for key, count := range s.data.blogViews {
+		if key.blogID == blogID {
+			views[key.date] = count
+		}
+	}
+	return views
+}
@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// commentRow : commentsテーブルの行
+type commentRow struct {
+	id        string
+	blogID    string
+	userID    string
+	content   string
+	createdAt time.Time
+	updatedAt time.Time
+	seq       int64
+}
+
+// toModel : 行からドメインモデルへの変換
+func (row commentRow) toModel() (*comment.Comment, error) {
+	blogID, err := blog.NewID(row.blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := user.NewID(row.userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment.Reconstruct(
+		row.id,
+		*blogID,
+		*userID,
+		row.content,
+		row.createdAt,
+		row.updatedAt,
+	)
+}
+
+// CommentRepository : コメントリポジトリのインメモリ実装
+type CommentRepository struct {
+	store *Store
+}
+
+// NewCommentRepository : CommentRepositoryの生成
+func NewCommentRepository(store *Store) repository.Comment {
+	return &CommentRepository{store: store}
+}
+
+// Save : コメントの保存
+func (r *CommentRepository) Save(ctx context.Context, comment *comment.Comment) error {
+	return r.store.do(ctx, func(t *tables) error {
+		id := comment.ID().String()
+		if _, ok := t.comments[id]; ok {
+			return fmt.Errorf("%w: comments.id %s", ErrDuplicateKey, id)
+		}
+		if err := t.requireBlog(comment.BlogID().String()); err != nil {
+			return err
+		}
+		if err := t.requireUser(comment.UserID().String()); err != nil {
+			return err
+		}
+
+		t.comments[id] = commentRow{
+			id:        id,
+			blogID:    comment.BlogID().String(),
+			userID:    comment.UserID().String(),
+			content:   comment.Content(),
+			createdAt: normalizeTime(comment.CreatedAt()),
+			updatedAt: normalizeTime(comment.UpdatedAt()),
+			seq:       t.nextSeq(),
+		}
+		return nil
+	})
+}
+
+// FindByID : IDによるコメント検索
+func (r *CommentRepository) FindByID(ctx context.Context, id string) (*comment.Comment, error) {
+	var found *comment.Comment
+	err := r.store.do(ctx, func(t *tables) error {
+		row, ok := t.comments[id]
+		if !ok {
+			return fmt.Errorf("comment not found with id: %s", id)
+		}
+
+		var err error
+		found, err = row.toModel()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// FindByBlogID : ブログIDによるコメント検索（古い順）
+func (r *CommentRepository) FindByBlogID(ctx context.Context, blogID blog.ID) ([]*comment.Comment, error) {
+	return r.find(ctx, func(row commentRow) bool { return row.blogID == blogID.String() }, false)
+}
+
+// FindByUserID : ユーザーIDによるコメント検索（新しい順）
+func (r *CommentRepository) FindByUserID(ctx context.Context, userID user.ID) ([]*comment.Comment, error) {
+	return r.find(ctx, func(row commentRow) bool { return row.userID == userID.String() }, true)
+}
+
+// find : 条件に一致するコメントを作成日時の順に検索
+func (r *CommentRepository) find(ctx context.Context, match func(row commentRow) bool, desc bool) ([]*comment.Comment, error) {
+	var comments []*comment.Comment
+	err := r.store.do(ctx, func(t *tables) error {
+		var rows []commentRow
+		for _, row := range t.comments {
+			if match(row) {
+				rows = append(rows, row)
+			}
+		}
+		sortByTime(rows, func(row commentRow) (time.Time, int64) { return row.createdAt, row.seq }, desc)
+
+		comments = make([]*comment.Comment, len(rows))
+		for i, row := range rows {
+			c, err := row.toModel()
+			if err != nil {
+				return err
+			}
+			comments[i] = c
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// Update : コメントの更新
+func (r *CommentRepository) Update(ctx context.Context, comment *comment.Comment) error {
+	return r.store.do(ctx, func(t *tables) error {
+		id := comment.ID().String()
+		row, ok := t.comments[id]
+		if !ok {
+			return fmt.Errorf("comment not found with id: %s", id)
+		}
+
+		row.content = comment.Content()
+		row.updatedAt = normalizeTime(time.Now())
+		t.comments[id] = row
+		return nil
+	})
+}
+
+// Delete : コメントの削除（メンションや通知等もカスケード削除する）
+func (r *CommentRepository) Delete(ctx context.Context, id string) error {
+	return r.store.do(ctx, func(t *tables) error {
+		if _, ok := t.comments[id]; !ok {
+			return fmt.Errorf("comment not found with id: %s", id)
+		}
+		t.deleteComment(id)
+		return nil
+	})
+}
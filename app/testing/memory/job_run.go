@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"myblog/app/domain/model/jobrun"
+	"myblog/app/domain/repository"
+)
+
+// jobRunRow : job_runsテーブルの行
+type jobRunRow struct {
+	id            string
+	jobName       string
+	params        string
+	scheduledAt   *time.Time
+	status        string
+	rowsProcessed int64
+	attempts      int
+	errorMessage  string
+	startedAt     time.Time
+	finishedAt    *time.Time
+	seq           int64
+}
+
+// toModel : 行からドメインモデルへの変換
+func (row jobRunRow) toModel() (*jobrun.JobRun, error) {
+	return jobrun.Reconstruct(
+		row.id,
+		row.jobName,
+		row.params,
+		copyTimePtr(row.scheduledAt),
+		jobrun.Status(row.status),
+		row.rowsProcessed,
+		row.attempts,
+		row.errorMessage,
+		row.startedAt,
+		copyTimePtr(row.finishedAt),
+	)
+}
+
+// JobRunRepository : ジョブ実行リポジトリのインメモリ実装
+type JobRunRepository struct {
+	store *Store
+}
+
+// NewJobRunRepository : JobRunRepositoryの生成
+func NewJobRunRepository(store *Store) repository.JobRun {
+	return &JobRunRepository{store: store}
+}
+
+// Create : ジョブ実行の作成（同じジョブの同じ予定実行日時の実行が既にある場合はfalse）
+func (r *JobRunRepository) Create(ctx context.Context, run *jobrun.JobRun) (bool, error) {
+	created := false
+	err := r.store.do(ctx, func(t *tables) error {
+		id := run.ID().String()
+		if _, ok := t.jobRuns[id]; ok {
+			return nil
+		}
+
+		scheduledAt := normalizeTimePtr(run.ScheduledAt())
+		if scheduledAt != nil {
+			for _, row := range t.jobRuns {
+				if row.jobName == run.JobName() && row.scheduledAt != nil && row.scheduledAt.Equal(*scheduledAt) {
+					return nil
+				}
+			}
+		}
+
+		t.jobRuns[id] = jobRunRow{
+			id:            id,
+			jobName:       run.JobName(),
+			params:        run.Params(),
+			scheduledAt:   scheduledAt,
+			status:        string(run.Status()),
+			rowsProcessed: run.RowsProcessed(),
+			attempts:      run.Attempts(),
+			errorMessage:  run.ErrorMessage(),
+			startedAt:     normalizeTime(run.StartedAt()),
+			finishedAt:    normalizeTimePtr(run.FinishedAt()),
+			seq:           t.nextSeq(),
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// Update : ジョブ実行の状態の更新（存在しない場合は何もしない）
+func (r *JobRunRepository) Update(ctx context.Context, run *jobrun.JobRun) error {
+	return r.store.do(ctx, func(t *tables) error {
+		row, ok := t.jobRuns[run.ID().String()]
+		if !ok {
+			return nil
+		}
+
+		row.status = string(run.Status())
+		row.rowsProcessed = run.RowsProcessed()
+		row.attempts = run.Attempts()
+		row.errorMessage = run.ErrorMessage()
+		row.finishedAt = normalizeTimePtr(run.FinishedAt())
+		t.jobRuns[row.id] = row
+		return nil
+	})
+}
+
+// FindRecent : ジョブ実行の検索（新しい順。jobNameが空の場合は全てのジョブ）
+func (r *JobRunRepository) FindRecent(ctx context.Context, jobName string, limit int) ([]*jobrun.JobRun, error) {
+	var runs []*jobrun.JobRun
+	err := r.store.do(ctx, func(t *tables) error {
+		var rows []jobRunRow
+		for _, row := range t.jobRuns {
+			if jobName == "" || row.jobName == jobName {
+				rows = append(rows, row)
+			}
+		}
+		sortByTime(rows, func(row jobRunRow) (time.Time, int64) { return row.startedAt, row.seq }, true)
+		rows = page(rows, 0, limit)
+
+		runs = make([]*jobrun.JobRun, len(rows))
+		for i, row := range rows {
+			run, err := row.toModel()
+			if err != nil {
+				return err
+			}
+			runs[i] = run
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
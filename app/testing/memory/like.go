@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/like"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// likeKey : blog_likesテーブルの主キー
+type likeKey struct {
+	blogID string
+	userID string
+}
+
+// LikeRepository : いいねリポジトリのインメモリ実装
+type LikeRepository struct {
+	store *Store
+}
+
+// NewLikeRepository : LikeRepositoryの生成
+func NewLikeRepository(store *Store) repository.Like {
+	return &LikeRepository{store: store}
+}
+
+// Save : いいねの保存（いいね済みの場合は何もしない）
+func (r *LikeRepository) Save(ctx context.Context, like *like.Like) error {
+	return r.store.do(ctx, func(t *tables) error {
+		key := likeKey{blogID: like.BlogID().String(), userID: like.UserID().String()}
+		if _, ok := t.likes[key]; ok {
+			return nil
+		}
+		if err := t.requireBlog(key.blogID); err != nil {
+			return err
+		}
+		if err := t.requireUser(key.userID); err != nil {
+			return err
+		}
+
+		t.likes[key] = normalizeTime(like.CreatedAt())
+		return nil
+	})
+}
+
+// Delete : いいねの削除（存在しない場合も成功とする）
+func (r *LikeRepository) Delete(ctx context.Context, blogID blog.ID, userID user.ID) error {
+	return r.store.do(ctx, func(t *tables) error {
+		delete(t.likes, likeKey{blogID: blogID.String(), userID: userID.String()})
+		return nil
+	})
+}
+
+// CountByBlogIDs : ブログIDごとのいいね数の取得（いいねのないブログは含めない）
+func (r *LikeRepository) CountByBlogIDs(ctx context.Context, blogIDs []blog.ID) (map[string]int, error) {
+	result := make(map[string]int)
+	if len(blogIDs) == 0 {
+		return result, nil
+	}
+
+	ids := make(map[string]bool, len(blogIDs))
+	for _, id := range blogIDs {
+		ids[id.String()] = true
+	}
+
+	err := r.store.do(ctx, func(t *tables) error {
+		for key := range t.likes {
+			if ids[key.blogID] {
+				result[key.blogID]++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindLikedBlogIDs : 指定したブログのうちユーザーがいいね済みのブログIDの取得
+func (r *LikeRepository) FindLikedBlogIDs(ctx context.Context, userID user.ID, blogIDs []blog.ID) (map[string]bool, error) {
+	result := make(map[string]bool)
+	if len(blogIDs) == 0 {
+		return result, nil
+	}
+
+	err := r.store.do(ctx, func(t *tables) error {
+		for _, id := range blogIDs {
+			if _, ok := t.likes[likeKey{blogID: id.String(), userID: userID.String()}]; ok {
+				result[id.String()] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
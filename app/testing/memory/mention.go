@@ -0,0 +1,192 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/mention"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// mentionRow : mentionsテーブルの行
+type mentionRow struct {
+	id        string
+	blogID    string
+	commentID string // ブログ本文内のメンションの場合は空（NULL）
+	userID    string
+	username  string
+	createdAt time.Time
+	seq       int64
+}
+
+// toModel : 行からドメインモデルへの変換
+func (row mentionRow) toModel() (*mention.Mention, error) {
+	blogID, err := blog.NewID(row.blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	var commentID *comment.ID
+	if row.commentID != "" {
+		commentID, err = comment.NewID(row.commentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	userID, err := user.NewID(row.userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mention.Reconstruct(
+		row.id,
+		*blogID,
+		commentID,
+		*userID,
+		row.username,
+		row.createdAt,
+	)
+}
+
+// MentionRepository : メンションリポジトリのインメモリ実装
+type MentionRepository struct {
+	store *Store
+}
+
+// NewMentionRepository : MentionRepositoryの生成
+func NewMentionRepository(store *Store) repository.Mention {
+	return &MentionRepository{store: store}
+}
+
+// SaveAll : メンションの一括保存
+func (r *MentionRepository) SaveAll(ctx context.Context, mentions []*mention.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	return r.store.do(ctx, func(t *tables) error {
+		rows := make([]mentionRow, len(mentions))
+		ids := make(map[string]bool, len(mentions))
+		for i, m := range mentions {
+			id := m.ID().String()
+			if _, ok := t.mentions[id]; ok || ids[id] {
+				return fmt.Errorf("%w: mentions.id %s", ErrDuplicateKey, id)
+			}
+			ids[id] = true
+
+			if err := t.requireBlog(m.BlogID().String()); err != nil {
+				return err
+			}
+			var commentID string
+			if m.CommentID() != nil {
+				commentID = m.CommentID().String()
+				if err := t.requireComment(commentID); err != nil {
+					return err
+				}
+			}
+			if err := t.requireUser(m.UserID().String()); err != nil {
+				return err
+			}
+
+			rows[i] = mentionRow{
+				id:        id,
+				blogID:    m.BlogID().String(),
+				commentID: commentID,
+				userID:    m.UserID().String(),
+				username:  m.Username(),
+				createdAt: normalizeTime(m.CreatedAt()),
+			}
+		}
+
+		for _, row := range rows {
+			row.seq = t.nextSeq()
+			t.mentions[row.id] = row
+		}
+		return nil
+	})
+}
+
+// FindByBlogIDs : ブログ本文内のメンション検索
+func (r *MentionRepository) FindByBlogIDs(ctx context.Context, blogIDs []blog.ID) ([]*mention.Mention, error) {
+	ids := make(map[string]bool, len(blogIDs))
+	for _, id := range blogIDs {
+		ids[id.String()] = true
+	}
+
+	return r.find(ctx, len(ids), func(row mentionRow) bool {
+		return ids[row.blogID] && row.commentID == ""
+	})
+}
+
+// FindByCommentIDs : コメント内のメンション検索
+func (r *MentionRepository) FindByCommentIDs(ctx context.Context, commentIDs []comment.ID) ([]*mention.Mention, error) {
+	ids := make(map[string]bool, len(commentIDs))
+	for _, id := range commentIDs {
+		ids[id.String()] = true
+	}
+
+	return r.find(ctx, len(ids), func(row mentionRow) bool {
+		return row.commentID != "" && ids[row.commentID]
+	})
+}
+
+// find : 条件に一致するメンションを作成日時の昇順に検索（検索するIDがない場合はnil）
+func (r *MentionRepository) find(ctx context.Context, idCount int, match func(row mentionRow) bool) ([]*mention.Mention, error) {
+	if idCount == 0 {
+		return nil, nil
+	}
+
+	var mentions []*mention.Mention
+	err := r.store.do(ctx, func(t *tables) error {
+		var rows []mentionRow
+		for _, row := range t.mentions {
+			if match(row) {
+				rows = append(rows, row)
+			}
+		}
+		sortByTime(rows, func(row mentionRow) (time.Time, int64) { return row.createdAt, row.seq }, false)
+
+		mentions = make([]*mention.Mention, len(rows))
+		for i, row := range rows {
+			m, err := row.toModel()
+			if err != nil {
+				return err
+			}
+			mentions[i] = m
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
+
+// DeleteByBlogID : ブログ本文内のメンションの削除（コメント内のメンションは削除しない）
+func (r *MentionRepository) DeleteByBlogID(ctx context.Context, blogID blog.ID) error {
+	return r.store.do(ctx, func(t *tables) error {
+		for id, row := range t.mentions {
+			if row.blogID == blogID.String() && row.commentID == "" {
+				delete(t.mentions, id)
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteByCommentID : コメント内のメンションの削除
+func (r *MentionRepository) DeleteByCommentID(ctx context.Context, commentID comment.ID) error {
+	return r.store.do(ctx, func(t *tables) error {
+		for id, row := range t.mentions {
+			if row.commentID == commentID.String() {
+				delete(t.mentions, id)
+			}
+		}
+		return nil
+	})
+}
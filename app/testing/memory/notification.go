@@ -0,0 +1,282 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/notification"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// notificationRow : notificationsテーブルの行
+type notificationRow struct {
+	id               string
+	userID           string
+	actorID          string
+	notificationType string
+	blogID           string
+	commentID        string // コメントに関する通知でない場合は空（NULL）
+	readAt           *time.Time
+	createdAt        time.Time
+}
+
+// toModel : 行からドメインモデルへの変換
+func (row notificationRow) toModel() (*notification.Notification, error) {
+	userID, err := user.NewID(row.userID)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID, err := user.NewID(row.actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	blogID, err := blog.NewID(row.blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	var commentID *comment.ID
+	if row.commentID != "" {
+		commentID, err = comment.NewID(row.commentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return notification.Reconstruct(
+		row.id,
+		*userID,
+		*actorID,
+		notification.Type(row.notificationType),
+		*blogID,
+		commentID,
+		copyTimePtr(row.readAt),
+		row.createdAt,
+	)
+}
+
+// NotificationRepository : 通知リポジトリのインメモリ実装
+type NotificationRepository struct {
+	store *Store
+}
+
+// NewNotificationRepository : NotificationRepositoryの生成
+func NewNotificationRepository(store *Store) repository.Notification {
+	return &NotificationRepository{store: store}
+}
+
+// SaveAll : 通知の一括保存
+func (r *NotificationRepository) SaveAll(ctx context.Context, notifications []*notification.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return r.store.do(ctx, func(t *tables) error {
+		rows := make([]notificationRow, len(notifications))
+		ids := make(map[string]bool, len(notifications))
+		for i, n := range notifications {
+			id := n.ID().String()
+			if _, ok := t.notifications[id]; ok || ids[id] {
+				return fmt.Errorf("%w: notifications.id %s", ErrDuplicateKey, id)
+			}
+			ids[id] = true
+
+			if err := t.requireUser(n.UserID().String()); err != nil {
+				return err
+			}
+			if err := t.requireUser(n.ActorID().String()); err != nil {
+				return err
+			}
+			if err := t.requireBlog(n.BlogID().String()); err != nil {
+				return err
+			}
+			var commentID string
+			if n.CommentID() != nil {
+				commentID = n.CommentID().String()
+				if err := t.requireComment(commentID); err != nil {
+					return err
+				}
+			}
+
+			rows[i] = notificationRow{
+				id:               id,
+				userID:           n.UserID().String(),
+				actorID:          n.ActorID().String(),
+				notificationType: string(n.Type()),
+				blogID:           n.BlogID().String(),
+				commentID:        commentID,
+				readAt:           normalizeTimePtr(n.ReadAt()),
+				createdAt:        normalizeTime(n.CreatedAt()),
+			}
+		}
+
+		for _, row := range rows {
+			t.notifications[row.id] = row
+		}
+		return nil
+	})
+}
+
+// FindByUserID : ユーザーIDによる通知検索（新しい順。cursorが指定された場合はその通知より古い通知）
+func (r *NotificationRepository) FindByUserID(ctx context.Context, userID user.ID, cursor *notification.Cursor, unreadOnly bool, limit int) ([]*notification.Notification, error) {
+	var notifications []*notification.Notification
+	err := r.store.do(ctx, func(t *tables) error {
+		var rows []notificationRow
+		for _, row := range t.notifications {
+			if row.userID != userID.String() {
+				continue
+			}
+			if cursor != nil && !row.createdAt.Before(cursor.CreatedAt) &&
+				!(row.createdAt.Equal(cursor.CreatedAt) && row.id < cursor.ID) {
+				continue
+			}
+			if unreadOnly && row.readAt != nil {
+				continue
+			}
+			rows = append(rows, row)
+		}
+
+		// 作成日時とIDの降順（データベースと同じく作成日時が同じ通知はIDで並べる）
+		sort.Slice(rows, func(i, j int) bool {
+			if !rows[i].createdAt.Equal(rows[j].createdAt) {
+				return rows[i].createdAt.After(rows[j].createdAt)
+			}
+			return rows[i].id > rows[j].id
+		})
+		rows = page(rows, 0, limit)
+
+		notifications = make([]*notification.Notification, len(rows))
+		for i, row := range rows {
+			n, err := row.toModel()
+			if err != nil {
+				return err
+			}
+			notifications[i] = n
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// CountUnread : ユーザーの未読の通知の件数
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID user.ID) (int, error) {
+	var count int
+	err := r.store.do(ctx, func(t *tables) error {
+		for _, row := range t.notifications {
+			if row.userID == userID.String() && row.readAt == nil {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// MarkRead : ユーザーの通知の既読化（既読の通知と他のユーザーの通知は変更しない）
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID user.ID, ids []notification.ID, readAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	targets := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		targets[id.String()] = true
+	}
+
+	return r.markRead(ctx, userID, readAt, func(row notificationRow) bool { return targets[row.id] })
+}
+
+// MarkAllRead : ユーザーの全ての未読の通知の既読化
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID user.ID, readAt time.Time) error {
+	return r.markRead(ctx, userID, readAt, func(row notificationRow) bool { return true })
+}
+
+// markRead : 条件に一致するユーザーの未読の通知の既読化
+func (r *NotificationRepository) markRead(ctx context.Context, userID user.ID, readAt time.Time, match func(row notificationRow) bool) error {
+	readAtValue := normalizeTime(readAt)
+	return r.store.do(ctx, func(t *tables) error {
+		for id, row := range t.notifications {
+			if row.userID != userID.String() || row.readAt != nil || !match(row) {
+				continue
+			}
+			row.readAt = &readAtValue
+			t.notifications[id] = row
+		}
+		return nil
+	})
+}
+
+// preferenceKey : notification_preferencesテーブルの主キー
+type preferenceKey struct {
+	userID           string
+	notificationType string
+}
+
+// NotificationPreferenceRepository : 通知設定リポジトリのインメモリ実装
+type NotificationPreferenceRepository struct {
+	store *Store
+}
+
+// NewNotificationPreferenceRepository : NotificationPreferenceRepositoryの生成
+func NewNotificationPreferenceRepository(store *Store) repository.NotificationPreference {
+	return &NotificationPreferenceRepository{store: store}
+}
+
+// FindByUserIDs : ユーザーごとの通知設定の検索（設定していないユーザーは全ての通知を受け取る設定を返す）
+func (r *NotificationPreferenceRepository) FindByUserIDs(ctx context.Context, userIDs []user.ID) (map[string]*notification.Preferences, error) {
+	result := make(map[string]*notification.Preferences, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	for _, id := range userIDs {
+		result[id.String()] = notification.NewPreferences(id)
+	}
+
+	err := r.store.do(ctx, func(t *tables) error {
+		for key, enabled := range t.preferences {
+			preferences, ok := result[key.userID]
+			if !ok {
+				continue
+			}
+			// 廃止された通知種別の設定は無視する
+			nt, err := notification.ParseType(key.notificationType)
+			if err != nil {
+				continue
+			}
+			if err := preferences.Set(nt, enabled); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Save : 通知設定の保存（全ての通知種別の設定を保存する）
+func (r *NotificationPreferenceRepository) Save(ctx context.Context, preferences *notification.Preferences) error {
+	return r.store.do(ctx, func(t *tables) error {
+		userID := preferences.UserID().String()
+		if err := t.requireUser(userID); err != nil {
+			return err
+		}
+
+		for nt, enabled := range preferences.All() {
+			t.preferences[preferenceKey{userID: userID, notificationType: string(nt)}] = enabled
+		}
+		return nil
+	})
+}
@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"myblog/app/domain/model/event"
+	"myblog/app/domain/repository"
+)
+
+// outboxRow : outboxテーブルの行
+type outboxRow struct {
+	id             string
+	eventType      string
+	aggregateID    string
+	payload        []byte
+	idempotencyKey string
+	occurredAt     time.Time
+	publishedAt    *time.Time
+	attempts       int
+	lastError      string
+	nextAttemptAt  time.Time
+	seq            int64
+}
+
+// toModel : 行からドメインモデルへの変換
+func (row outboxRow) toModel() (*event.Event, error) {
+	return event.Reconstruct(
+		row.id,
+		event.Type(row.eventType),
+		row.aggregateID,
+		append([]byte(nil), row.payload...),
+		row.idempotencyKey,
+		row.occurredAt,
+		copyTimePtr(row.publishedAt),
+		row.attempts,
+	)
+}
+
+// deliveryKey : outbox_deliveriesテーブルの主キー
+type deliveryKey struct {
+	eventID    string
+	subscriber string
+}
+
+// OutboxRepository : アウトボックスリポジトリのインメモリ実装
+type OutboxRepository struct {
+	store *Store
+}
+
+// NewOutboxRepository : OutboxRepositoryの生成
+func NewOutboxRepository(store *Store) repository.Outbox {
+	return &OutboxRepository{store: store}
+}
+
+// Save : イベントの保存（同じIDまたは同じ冪等キーのイベントが既にある場合は保存しない）
+func (r *OutboxRepository) Save(ctx context.Context, events ...*event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return r.store.do(ctx, func(t *tables) error {
+		keys := make(map[string]bool, len(t.outbox))
+		for _, row := range t.outbox {
+			keys[row.idempotencyKey] = true
+		}
+
+		for _, e := range events {
+			if _, ok := t.outbox[e.ID().String()]; ok || keys[e.IdempotencyKey()] {
+				continue
+			}
+			keys[e.IdempotencyKey()] = true
+
+			occurredAt := normalizeTime(e.OccurredAt())
+			t.outbox[e.ID().String()] = outboxRow{
+				id:             e.ID().String(),
+				eventType:      string(e.Type()),
+				aggregateID:    e.AggregateID(),
+				payload:        append([]byte(nil), e.Payload()...),
+				idempotencyKey: e.IdempotencyKey(),
+				occurredAt:     occurredAt,
+				attempts:       e.Attempts(),
+				nextAttemptAt:  occurredAt,
+				seq:            t.nextSeq(),
+			}
+		}
+		return nil
+	})
+}
+
+// FindPending : 配信予定日時を過ぎた未配信のイベントの検索（発生順）
+func (r *OutboxRepository) FindPending(ctx context.Context, now time.Time, limit int) ([]*event.Event, error) {
+	var events []*event.Event
+	err := r.store.do(ctx, func(t *tables) error {
+		var rows []outboxRow
+		for _, row := range t.outbox {
+			if row.publishedAt == nil && !row.nextAttemptAt.After(now) {
+				rows = append(rows, row)
+			}
+		}
+		sortByTime(rows, func(row outboxRow) (time.Time, int64) { return row.occurredAt, row.seq }, false)
+		rows = page(rows, 0, limit)
+
+		events = make([]*event.Event, len(rows))
+		for i, row := range rows {
+			e, err := row.toModel()
+			if err != nil {
+				return err
+			}
+			events[i] = e
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// MarkPublished : 全ての購読者への配信が完了したイベントの記録
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id event.ID, publishedAt time.Time) error {
+	return r.update(ctx, id, func(row *outboxRow) {
+		row.publishedAt = normalizeTimePtr(&publishedAt)
+		row.attempts++
+		row.lastError = ""
+	})
+}
+
+// MarkFailed : 配信に失敗したイベントの記録（nextAttemptAt以降に再配信する）
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id event.ID, errorMessage string, nextAttemptAt time.Time) error {
+	return r.update(ctx, id, func(row *outboxRow) {
+		row.attempts++
+		row.lastError = errorMessage
+		row.nextAttemptAt = normalizeTime(nextAttemptAt)
+	})
+}
+
+// update : イベントの更新（存在しない場合は何もしない）
+func (r *OutboxRepository) update(ctx context.Context, id event.ID, fn func(row *outboxRow)) error {
+	return r.store.do(ctx, func(t *tables) error {
+		row, ok := t.outbox[id.String()]
+		if !ok {
+			return nil
+		}
+		fn(&row)
+		t.outbox[id.String()] = row
+		return nil
+	})
+}
+
+// FindDeliveredSubscribers : イベントの配信が完了した購読者名の検索（名前順）
+func (r *OutboxRepository) FindDeliveredSubscribers(ctx context.Context, id event.ID) ([]string, error) {
+	var subscribers []string
+	err := r.store.do(ctx, func(t *tables) error {
+		for key := range t.deliveries {
+			if key.eventID == id.String() {
+				subscribers = append(subscribers, key.subscriber)
+			}
+		}
+		sort.Strings(subscribers)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subscribers, nil
+}
+
+// SaveDelivery : 購読者へのイベントの配信完了の記録（既に記録されている場合は何もしない）
+func (r *OutboxRepository) SaveDelivery(ctx context.Context, id event.ID, subscriber string, deliveredAt time.Time) error {
+	return r.store.do(ctx, func(t *tables) error {
+		key := deliveryKey{eventID: id.String(), subscriber: subscriber}
+		if _, ok := t.deliveries[key]; ok {
+			return nil
+		}
+		if _, ok := t.outbox[key.eventID]; !ok {
+			return fmt.Errorf("%w: outbox %s does not exist", ErrForeignKey, key.eventID)
+		}
+
+		t.deliveries[key] = normalizeTime(deliveredAt)
+		return nil
+	})
+}
@@ -0,0 +1,286 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/ranking"
+	"myblog/app/domain/repository"
+)
+
+// snapshotRow : ranking_snapshotsテーブルの行
+type snapshotRow struct {
+	id           string
+	rankingType  ranking.Type
+	calculatedAt time.Time
+	publishedAt  *time.Time
+	seq          int64
+}
+
+// rankingEntryKey : ranking_entries・ranking_author_blog_entriesテーブルの主キー
+type rankingEntryKey struct {
+	snapshotID string
+	blogID     string
+}
+
+// rankingEntryRow : ranking_entriesテーブルの行
+type rankingEntryRow struct {
+	position int
+	score    float64
+}
+
+// authorEntryKey : ranking_author_entriesテーブルの主キー
+type authorEntryKey struct {
+	snapshotID string
+	userID     string
+}
+
+// authorEntryRow : ranking_author_entriesテーブルの行
+type authorEntryRow struct {
+	position  int
+	score     float64
+	blogCount int
+}
+
+// authorBlogEntryRow : ranking_author_blog_entriesテーブルの行
+type authorBlogEntryRow struct {
+	userID   string
+	position int
+	score    float64
+}
+
+// rankingRepository : ランキングリポジトリのインメモリ実装
+type rankingRepository struct {
+	store *Store
+}
+
+// NewRankingRepository はRankingRepositoryのインメモリ実装を返す
+func NewRankingRepository(store *Store) repository.RankingRepository {
+	return &rankingRepository{store: store}
+}
+
+// CreateSnapshot は未公開のスナップショットを作成する
+func (r *rankingRepository) CreateSnapshot(ctx context.Context, snapshot *ranking.Snapshot) error {
+	return r.store.do(ctx, func(t *tables) error {
+		if _, ok := t.snapshots[snapshot.ID]; ok {
+			return fmt.Errorf("%w: ranking_snapshots.id %s", ErrDuplicateKey, snapshot.ID)
+		}
+
+		t.snapshots[snapshot.ID] = snapshotRow{
+			id:           snapshot.ID,
+			rankingType:  snapshot.Type,
+			calculatedAt: normalizeTime(snapshot.CalculatedAt),
+			seq:          t.nextSeq(),
+		}
+		return nil
+	})
+}
+
+// SaveRankings はスナップショットにランキングを追加する
+func (r *rankingRepository) SaveRankings(ctx context.Context, snapshot *ranking.Snapshot, rankings []*ranking.Ranking) error {
+	return r.store.do(ctx, func(t *tables) error {
+		if err := requireSnapshot(t, snapshot.ID); err != nil {
+			return err
+		}
+
+		keys := make([]rankingEntryKey, len(rankings))
+		seen := make(map[rankingEntryKey]bool, len(rankings))
+		for i, rank := range rankings {
+			key := rankingEntryKey{snapshotID: snapshot.ID, blogID: rank.BlogID.String()}
+			if _, ok := t.rankingEntries[key]; ok || seen[key] {
+				return fmt.Errorf("%w: ranking_entries (%s, %s)", ErrDuplicateKey, key.snapshotID, key.blogID)
+			}
+			if err := t.requireBlog(key.blogID); err != nil {
+				return err
+			}
+			seen[key] = true
+			keys[i] = key
+		}
+
+		for i, rank := range rankings {
+			t.rankingEntries[keys[i]] = rankingEntryRow{position: rank.RankingPosition, score: rank.Score}
+		}
+		return nil
+	})
+}
+
+// SaveAuthorRankings はスナップショットに著者ランキングを追加する
+func (r *rankingRepository) SaveAuthorRankings(ctx context.Context, snapshot *ranking.Snapshot, rankings []*ranking.AuthorRanking) error {
+	return r.store.do(ctx, func(t *tables) error {
+		if err := requireSnapshot(t, snapshot.ID); err != nil {
+			return err
+		}
+
+		keys := make([]authorEntryKey, len(rankings))
+		seen := make(map[authorEntryKey]bool, len(rankings))
+		for i, rank := range rankings {
+			key := authorEntryKey{snapshotID: snapshot.ID, userID: rank.UserID.String()}
+			if _, ok := t.authorEntries[key]; ok || seen[key] {
+				return fmt.Errorf("%w: ranking_author_entries (%s, %s)", ErrDuplicateKey, key.snapshotID, key.userID)
+			}
+			if err := t.requireUser(key.userID); err != nil {
+				return err
+			}
+			seen[key] = true
+			keys[i] = key
+		}
+
+		for i, rank := range rankings {
+			t.authorEntries[keys[i]] = authorEntryRow{position: rank.RankingPosition, score: rank.Score, blogCount: rank.BlogCount}
+		}
+		return nil
+	})
+}
+
+// SaveAuthorBlogRankings はスナップショットに著者ごとの人気記事ランキングを追加する
+func (r *rankingRepository) SaveAuthorBlogRankings(ctx context.Context, snapshot *ranking.Snapshot, rankings []*ranking.AuthorBlogRanking) error {
+	return r.store.do(ctx, func(t *tables) error {
+		if err := requireSnapshot(t, snapshot.ID); err != nil {
+			return err
+		}
+
+		keys := make([]rankingEntryKey, len(rankings))
+		seen := make(map[rankingEntryKey]bool, len(rankings))
+		for i, rank := range rankings {
+			key := rankingEntryKey{snapshotID: snapshot.ID, blogID: rank.BlogID.String()}
+			if _, ok := t.authorBlogEntries[key]; ok || seen[key] {
+				return fmt.Errorf("%w: ranking_author_blog_entries (%s, %s)", ErrDuplicateKey, key.snapshotID, key.blogID)
+			}
+			if err := t.requireUser(rank.UserID.String()); err != nil {
+				return err
+			}
+			if err := t.requireBlog(key.blogID); err != nil {
+				return err
+			}
+			seen[key] = true
+			keys[i] = key
+		}
+
+		for i, rank := range rankings {
+			t.authorBlogEntries[keys[i]] = authorBlogEntryRow{userID: rank.UserID.String(), position: rank.RankingPosition, score: rank.Score}
+		}
+		return nil
+	})
+}
+
+// PublishSnapshot はスナップショットを公開し、最新のランキングとして参照できるようにする
+func (r *rankingRepository) PublishSnapshot(ctx context.Context, snapshot *ranking.Snapshot) error {
+	return r.store.do(ctx, func(t *tables) error {
+		row, ok := t.snapshots[snapshot.ID]
+		if !ok {
+			return nil
+		}
+
+		now := normalizeTime(time.Now())
+		row.publishedAt = &now
+		t.snapshots[row.id] = row
+		return nil
+	})
+}
+
+// DeleteSnapshot はスナップショットを削除する（ランキングはカスケード削除される）
+func (r *rankingRepository) DeleteSnapshot(ctx context.Context, snapshot *ranking.Snapshot) error {
+	return r.store.do(ctx, func(t *tables) error {
+		t.deleteSnapshot(snapshot.ID)
+		return nil
+	})
+}
+
+// GetRankings は指定した種別の最新の公開済みランキングを取得する
+func (r *rankingRepository) GetRankings(ctx context.Context, rankingType ranking.Type, limit int) ([]*ranking.Ranking, error) {
+	var rankings []*ranking.Ranking
+	err := r.store.do(ctx, func(t *tables) error {
+		latest, ok := latestPublished(t, rankingType)
+		if !ok {
+			return nil
+		}
+
+		for key, row := range t.rankingEntries {
+			if key.snapshotID != latest.id {
+				continue
+			}
+			blogID, err := blog.NewID(key.blogID)
+			if err != nil {
+				return err
+			}
+			rankings = append(rankings, &ranking.Ranking{
+				BlogID:          *blogID,
+				RankingPosition: row.position,
+				Score:           row.score,
+				CreatedAt:       latest.calculatedAt,
+				UpdatedAt:       latest.calculatedAt,
+			})
+		}
+
+		sort.SliceStable(rankings, func(i, j int) bool {
+			return rankings[i].RankingPosition < rankings[j].RankingPosition
+		})
+		rankings = page(rankings, 0, limit)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rankings) == 0 {
+		return nil, nil
+	}
+	return rankings, nil
+}
+
+// DeleteSnapshotsBefore は指定日時より前に集計されたスナップショットを削除する
+// 各種別の最新の公開済みスナップショットは削除しない（失敗した集計の未公開のスナップショットは削除する）
+func (r *rankingRepository) DeleteSnapshotsBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := r.store.do(ctx, func(t *tables) error {
+		latest := make(map[ranking.Type]time.Time)
+		for _, row := range t.snapshots {
+			if row.publishedAt == nil {
+				continue
+			}
+			if current, ok := latest[row.rankingType]; !ok || row.calculatedAt.After(current) {
+				latest[row.rankingType] = row.calculatedAt
+			}
+		}
+
+		for id, row := range t.snapshots {
+			if !row.calculatedAt.Before(before) {
+				continue
+			}
+			if current, ok := latest[row.rankingType]; ok && row.publishedAt != nil && row.calculatedAt.Equal(current) {
+				continue
+			}
+			t.deleteSnapshot(id)
+			deleted++
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// latestPublished : 種別ごとの集計日時が最新の公開済みスナップショットの取得
+func latestPublished(t *tables, rankingType ranking.Type) (snapshotRow, bool) {
+	var latest snapshotRow
+	found := false
+	for _, row := range t.snapshots {
+		if row.rankingType != rankingType || row.publishedAt == nil {
+			continue
+		}
+		if !found || row.calculatedAt.After(latest.calculatedAt) ||
+			(row.calculatedAt.Equal(latest.calculatedAt) && row.seq > latest.seq) {
+			latest = row
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// requireSnapshot : 外部キー（スナップショット）の検証
+func requireSnapshot(t *tables, id string) error {
+	if _, ok := t.snapshots[id]; !ok {
+		return fmt.Errorf("%w: ranking snapshot %s does not exist", ErrForeignKey, id)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"myblog/app/domain/model/ranking"
+	"myblog/app/infra/query"
+)
+
+// excerptLength : ランキングに含めるブログ本文の抜粋の文字数（query.RankingListと同じ）
+const excerptLength = 200
+
+// BlogStats : ブログの統計情報のクエリ（query.BlogStats）のインメモリ実装
+type BlogStats struct {
+	store *Store
+}
+
+// NewBlogStats : BlogStatsの生成
+func NewBlogStats(store *Store) *BlogStats {
+	return &BlogStats{store: store}
+}
+
+// ForEachBlogRankingData : 指定日時以降に閲覧・いいね・コメントのいずれかがあったブログのランキングデータを1件ずつfnに渡す
+// sinceがゼロ値の場合は全期間。データベースと異なり並び順を固定するため、ブログの作成順に渡す
+func (q *BlogStats) ForEachBlogRankingData(ctx context.Context, since time.Time, fn func(data query.BlogRankingData) error) error {
+	var rows []query.BlogRankingData
+	err := q.store.do(ctx, func(t *tables) error {
+		sinceDate := since.Format("2006-01-02")
+		stats := make(map[string]*query.BlogRankingData)
+		stat := func(blogID string) *query.BlogRankingData {
+			data, ok := stats[blogID]
+			if !ok {
+				data = &query.BlogRankingData{BlogID: blogID}
+				stats[blogID] = data
+			}
+			return data
+		}
+
+		for _, row := range t.comments {
+			if !row.createdAt.Before(since) {
+				stat(row.blogID).CommentCount++
+			}
+		}
+		for key, createdAt := range t.likes {
+			if !createdAt.Before(since) {
+				stat(key.blogID).LikeCount++
+			}
+		}
+		for key, views := range t.blogViews {
+			if since.IsZero() || key.date >= sinceDate {
+				stat(key.blogID).ViewCount += views
+			}
+		}
+
+		blogs := make([]blogRow, 0, len(stats))
+		for blogID := range stats {
+			if row, ok := t.blogs[blogID]; ok {
+				blogs = append(blogs, row)
+			}
+		}
+		sort.Slice(blogs, func(i, j int) bool { return blogs[i].seq < blogs[j].seq })
+
+		for _, row := range blogs {
+			data := stats[row.id]
+			data.UserID = row.userID
+			data.CreatedAt = row.createdAt
+			rows = append(rows, *data)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// fnがリポジトリを呼び出してもデッドロックしないよう、ロックを解放してから渡す
+	for _, data := range rows {
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RankingList : 公開用のランキング一覧のクエリ（query.RankingList）のインメモリ実装
+type RankingList struct {
+	store *Store
+}
+
+// NewRankingList : RankingListの生成
+func NewRankingList(store *Store) *RankingList {
+	return &RankingList{store: store}
+}
+
+// GetPopularRanking : 指定した種別の最新の公開済み人気記事ランキングを上位limit件、前回の順位付きで取得
+func (q *RankingList) GetPopularRanking(ctx context.Context, rankingType ranking.Type, limit int) (*query.PopularRanking, error) {
+	result := &query.PopularRanking{Type: rankingType}
+	err := q.store.do(ctx, func(t *tables) error {
+		latest, previous := publishedGenerations(t, rankingType)
+		if latest == nil {
+			return nil
+		}
+
+		for key, row := range t.rankingEntries {
+			if key.snapshotID != latest.id {
+				continue
+			}
+			var previousPosition *int
+			if previous != nil {
+				if prev, ok := t.rankingEntries[rankingEntryKey{snapshotID: previous.id, blogID: key.blogID}]; ok {
+					previousPosition = &prev.position
+				}
+			}
+			if ranked, ok := rankedBlog(t, key.blogID, row.position, previousPosition, row.score); ok {
+				result.Blogs = append(result.Blogs, ranked)
+			}
+		}
+		// データベースの実装と同様に、ランキングが空の場合は集計日時も返さない
+		if len(result.Blogs) > 0 {
+			result.CalculatedAt = &latest.calculatedAt
+		}
+		result.Blogs = page(sortedByPosition(result.Blogs, func(b query.RankedBlog) int { return b.Position }), 0, limit)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAuthorBlogRanking : 指定した種別の最新の公開済みランキングから、著者の人気記事ランキングを上位limit件取得
+func (q *RankingList) GetAuthorBlogRanking(ctx context.Context, userID string, rankingType ranking.Type, limit int) (*query.PopularRanking, error) {
+	result := &query.PopularRanking{Type: rankingType}
+	err := q.store.do(ctx, func(t *tables) error {
+		latest, previous := publishedGenerations(t, rankingType)
+		if latest == nil {
+			return nil
+		}
+
+		for key, row := range t.authorBlogEntries {
+			if key.snapshotID != latest.id || row.userID != userID {
+				continue
+			}
+			var previousPosition *int
+			if previous != nil {
+				if prev, ok := t.authorBlogEntries[rankingEntryKey{snapshotID: previous.id, blogID: key.blogID}]; ok {
+					previousPosition = &prev.position
+				}
+			}
+			if ranked, ok := rankedBlog(t, key.blogID, row.position, previousPosition, row.score); ok {
+				result.Blogs = append(result.Blogs, ranked)
+			}
+		}
+		// 著者の記事が1件もない場合は集計日時も返さない
+		if len(result.Blogs) > 0 {
+			result.CalculatedAt = &latest.calculatedAt
+		}
+		result.Blogs = page(sortedByPosition(result.Blogs, func(b query.RankedBlog) int { return b.Position }), 0, limit)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAuthorRanking : 指定した種別の最新の公開済み著者ランキングを上位limit件、前回の順位付きで取得
+func (q *RankingList) GetAuthorRanking(ctx context.Context, rankingType ranking.Type, limit int) (*query.AuthorRanking, error) {
+	result := &query.AuthorRanking{Type: rankingType}
+	err := q.store.do(ctx, func(t *tables) error {
+		latest, previous := publishedGenerations(t, rankingType)
+		if latest == nil {
+			return nil
+		}
+
+		for key, row := range t.authorEntries {
+			if key.snapshotID != latest.id {
+				continue
+			}
+			author, ok := t.users[key.userID]
+			if !ok {
+				continue
+			}
+			ranked := query.RankedAuthor{
+				Position:  row.position,
+				Score:     row.score,
+				BlogCount: row.blogCount,
+				UserID:    author.id,
+				Username:  author.username,
+			}
+			if previous != nil {
+				if prev, ok := t.authorEntries[authorEntryKey{snapshotID: previous.id, userID: key.userID}]; ok {
+					ranked.PreviousPosition = &prev.position
+				}
+			}
+			result.Authors = append(result.Authors, ranked)
+		}
+		if len(result.Authors) > 0 {
+			result.CalculatedAt = &latest.calculatedAt
+		}
+		result.Authors = page(sortedByPosition(result.Authors, func(a query.RankedAuthor) int { return a.Position }), 0, limit)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBlogRankingHistory : 指定日時以降の公開済みスナップショットにおけるブログの順位の推移を取得（集計日時の昇順）
+func (q *RankingList) GetBlogRankingHistory(ctx context.Context, blogID string, rankingType ranking.Type, since time.Time) ([]query.RankingHistoryPoint, error) {
+	var result []query.RankingHistoryPoint
+	err := q.store.do(ctx, func(t *tables) error {
+		for _, snapshot := range t.snapshots {
+			if snapshot.rankingType != rankingType || snapshot.publishedAt == nil || snapshot.calculatedAt.Before(since) {
+				continue
+			}
+			row, ok := t.rankingEntries[rankingEntryKey{snapshotID: snapshot.id, blogID: blogID}]
+			if !ok {
+				continue
+			}
+			result = append(result, query.RankingHistoryPoint{
+				CalculatedAt: snapshot.calculatedAt,
+				Position:     row.position,
+				Score:        row.score,
+			})
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i].CalculatedAt.Before(result[j].CalculatedAt) })
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// publishedGenerations : 種別ごとの最新と1つ前の公開済みスナップショットの取得（存在しない場合はnil）
+func publishedGenerations(t *tables, rankingType ranking.Type) (*snapshotRow, *snapshotRow) {
+	var published []snapshotRow
+	for _, row := range t.snapshots {
+		if row.rankingType == rankingType && row.publishedAt != nil {
+			published = append(published, row)
+		}
+	}
+	sortByTime(published, func(row snapshotRow) (time.Time, int64) { return row.calculatedAt, row.seq }, true)
+
+	switch len(published) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &published[0], nil
+	default:
+		return &published[0], &published[1]
+	}
+}
+
+// rankedBlog : ランキング上のブログの概要（ブログまたは著者が存在しない場合はfalse）
+func rankedBlog(t *tables, blogID string, position int, previousPosition *int, score float64) (query.RankedBlog, bool) {
+	b, ok := t.blogs[blogID]
+	if !ok {
+		return query.RankedBlog{}, false
+	}
+	author, ok := t.users[b.userID]
+	if !ok {
+		return query.RankedBlog{}, false
+	}
+
+	excerpt := []rune(b.content)
+	if len(excerpt) > excerptLength {
+		excerpt = excerpt[:excerptLength]
+	}
+	return query.RankedBlog{
+		Position:         position,
+		PreviousPosition: previousPosition,
+		Score:            score,
+		BlogID:           b.id,
+		Title:            b.title,
+		Excerpt:          string(excerpt),
+		UserID:           author.id,
+		AuthorName:       author.username,
+		CreatedAt:        b.createdAt,
+	}, true
+}
+
+// sortedByPosition : 順位の昇順による並び替え
+func sortedByPosition[T any](rows []T, position func(T) int) []T {
+	sort.SliceStable(rows, func(i, j int) bool { return position(rows[i]) < position(rows[j]) })
+	return rows
+}
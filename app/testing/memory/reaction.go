@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/reaction"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// reactionKey : blog_reactions・comment_reactionsテーブルの主キー
+type reactionKey struct {
+	targetType reaction.TargetType
+	targetID   string
+	userID     string
+}
+
+// reactionRow : blog_reactions・comment_reactionsテーブルの行
+type reactionRow struct {
+	emoji     string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// checkTargetType : リアクション対象の種別の検証
+func checkTargetType(targetType reaction.TargetType) error {
+	if targetType != reaction.TargetBlog && targetType != reaction.TargetComment {
+		return fmt.Errorf("unknown reaction target type: %s", targetType)
+	}
+	return nil
+}
+
+// ReactionRepository : リアクションリポジトリのインメモリ実装
+type ReactionRepository struct {
+	store *Store
+}
+
+// NewReactionRepository : ReactionRepositoryの生成
+func NewReactionRepository(store *Store) repository.Reaction {
+	return &ReactionRepository{store: store}
+}
+
+// Save : リアクションの保存（既存のリアクションがあれば絵文字を置き換える）
+func (r *ReactionRepository) Save(ctx context.Context, reaction *reaction.Reaction) error {
+	if err := checkTargetType(reaction.TargetType()); err != nil {
+		return err
+	}
+
+	return r.store.do(ctx, func(t *tables) error {
+		key := reactionKey{targetType: reaction.TargetType(), targetID: reaction.TargetID(), userID: reaction.UserID().String()}
+		now := normalizeTime(time.Now())
+
+		if row, ok := t.reactions[key]; ok {
+			row.emoji = reaction.Emoji()
+			row.updatedAt = now
+			t.reactions[key] = row
+			return nil
+		}
+
+		if err := requireReactionTarget(t, key); err != nil {
+			return err
+		}
+		if err := t.requireUser(key.userID); err != nil {
+			return err
+		}
+
+		t.reactions[key] = reactionRow{
+			emoji:     reaction.Emoji(),
+			createdAt: normalizeTime(reaction.CreatedAt()),
+			updatedAt: now,
+		}
+		return nil
+	})
+}
+
+// Delete : リアクションの削除（存在しない場合も成功とする）
+func (r *ReactionRepository) Delete(ctx context.Context, userID user.ID, targetType reaction.TargetType, targetID string) error {
+	if err := checkTargetType(targetType); err != nil {
+		return err
+	}
+
+	return r.store.do(ctx, func(t *tables) error {
+		delete(t.reactions, reactionKey{targetType: targetType, targetID: targetID, userID: userID.String()})
+		return nil
+	})
+}
+
+// CountByTargetIDs : 対象IDごと・絵文字ごとのリアクション件数の取得
+func (r *ReactionRepository) CountByTargetIDs(ctx context.Context, targetType reaction.TargetType, targetIDs []string) (map[string]map[string]int, error) {
+	result := make(map[string]map[string]int)
+	if len(targetIDs) == 0 {
+		return result, nil
+	}
+	if err := checkTargetType(targetType); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool, len(targetIDs))
+	for _, id := range targetIDs {
+		ids[id] = true
+	}
+
+	err := r.store.do(ctx, func(t *tables) error {
+		for key, row := range t.reactions {
+			if key.targetType != targetType || !ids[key.targetID] {
+				continue
+			}
+			if result[key.targetID] == nil {
+				result[key.targetID] = make(map[string]int)
+			}
+			result[key.targetID][row.emoji]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindEmojisByUserID : ユーザーの対象IDごとのリアクションの取得
+func (r *ReactionRepository) FindEmojisByUserID(ctx context.Context, userID user.ID, targetType reaction.TargetType, targetIDs []string) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(targetIDs) == 0 {
+		return result, nil
+	}
+	if err := checkTargetType(targetType); err != nil {
+		return nil, err
+	}
+
+	err := r.store.do(ctx, func(t *tables) error {
+		for _, id := range targetIDs {
+			if row, ok := t.reactions[reactionKey{targetType: targetType, targetID: id, userID: userID.String()}]; ok {
+				result[id] = row.emoji
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// requireReactionTarget : 外部キー（リアクション対象のブログまたはコメント）の検証
+func requireReactionTarget(t *tables, key reactionKey) error {
+	if key.targetType == reaction.TargetComment {
+		return t.requireComment(key.targetID)
+	}
+	return t.requireBlog(key.targetID)
+}
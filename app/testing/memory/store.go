@@ -0,0 +1,356 @@
+// Package memory : リポジトリとトランザクション管理のインメモリ実装（テスト用）
+//
+// データベースを使わずにユースケースを検証するための実装で、DAOと同じ振る舞い
+// （見つからない場合のエラー、並び順、一意制約、外部キー制約、削除時のカスケード）を再現する。
+// 同じStoreから生成したリポジトリは同じデータを参照し、複数のゴルーチンから同時に使用できる。
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"myblog/app/domain/model/reaction"
+)
+
+var (
+	// ErrDuplicateKey : 主キーまたは一意キーの重複
+	ErrDuplicateKey = errors.New("duplicate key")
+	// ErrForeignKey : 参照先の行が存在しない
+	ErrForeignKey = errors.New("foreign key constraint fails")
+)
+
+// Store : インメモリリポジトリが共有するデータ（データベースに相当する）
+// トランザクション外の操作は1回の呼び出しごとに、トランザクション内の操作はトランザクション全体で排他的に実行する
+type Store struct {
+	mu   sync.Mutex
+	data *tables
+}
+
+// NewStore : 空のStoreの生成
+func NewStore() *Store {
+	return &Store{data: newTables()}
+}
+
+// txState : 実行中のトランザクション
+type txState struct {
+	store *Store
+	depth int // 入れ子の深さ（最も外側のトランザクションは0）
+}
+
+// トランザクションをコンテキストに格納するためのキー
+type txKey struct{}
+
+// txFrom : コンテキストからこのStoreのトランザクションを取得
+func (s *Store) txFrom(ctx context.Context) (*txState, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok || state.store != s {
+		return nil, false
+	}
+	return state, true
+}
+
+// do : データに対する操作の実行
+// トランザクション内（ロックを取得済み）の場合はそのまま、トランザクション外の場合はロックを取得して実行する
+// 操作は途中で失敗した場合に変更が残らないよう、全ての検証を終えてから変更すること
+func (s *Store) do(ctx context.Context, fn func(t *tables) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if _, ok := s.txFrom(ctx); ok {
+		return fn(s.data)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.data)
+}
+
+// tables : Storeが保持する全てのテーブル
+// 行は値で保持し、トランザクションのロールバック用の複製はマップの複製のみで作成できるようにする
+type tables struct {
+	seq int64 // 挿入順（作成日時が同じ行の並び順に使用する）
+
+	users             map[string]userRow
+	blogs             map[string]blogRow
+	comments          map[string]commentRow
+	mentions          map[string]mentionRow
+	notifications     map[string]notificationRow
+	preferences       map[preferenceKey]bool
+	likes             map[likeKey]time.Time
+	reactions         map[reactionKey]reactionRow
+	blogViews         map[blogViewKey]int
+	snapshots         map[string]snapshotRow
+	rankingEntries    map[rankingEntryKey]rankingEntryRow
+	authorEntries     map[authorEntryKey]authorEntryRow
+	authorBlogEntries map[rankingEntryKey]authorBlogEntryRow
+	jobRuns           map[string]jobRunRow
+	outbox            map[string]outboxRow
+	deliveries        map[deliveryKey]time.Time
+}
+
+// newTables : 空のテーブルの生成
+func newTables() *tables {
+	return &tables{
+		users:             make(map[string]userRow),
+		blogs:             make(map[string]blogRow),
+		comments:          make(map[string]commentRow),
+		mentions:          make(map[string]mentionRow),
+		notifications:     make(map[string]notificationRow),
+		preferences:       make(map[preferenceKey]bool),
+		likes:             make(map[likeKey]time.Time),
+		reactions:         make(map[reactionKey]reactionRow),
+		blogViews:         make(map[blogViewKey]int),
+		snapshots:         make(map[string]snapshotRow),
+		rankingEntries:    make(map[rankingEntryKey]rankingEntryRow),
+		authorEntries:     make(map[authorEntryKey]authorEntryRow),
+		authorBlogEntries: make(map[rankingEntryKey]authorBlogEntryRow),
+		jobRuns:           make(map[string]jobRunRow),
+		outbox:            make(map[string]outboxRow),
+		deliveries:        make(map[deliveryKey]time.Time),
+	}
+}
+
+// clone : ロールバック用の複製
+// 行が参照するスライスやポインタの指す値は変更せずに置き換えるため、マップの複製のみ行う
+func (t *tables) clone() *tables {
+	return &tables{
+		seq:               t.seq,
+		users:             maps.Clone(t.users),
+		blogs:             maps.Clone(t.blogs),
+		comments:          maps.Clone(t.comments),
+		mentions:          maps.Clone(t.mentions),
+		notifications:     maps.Clone(t.notifications),
+		preferences:       maps.Clone(t.preferences),
+		likes:             maps.Clone(t.likes),
+		reactions:         maps.Clone(t.reactions),
+		blogViews:         maps.Clone(t.blogViews),
+		snapshots:         maps.Clone(t.snapshots),
+		rankingEntries:    maps.Clone(t.rankingEntries),
+		authorEntries:     maps.Clone(t.authorEntries),
+		authorBlogEntries: maps.Clone(t.authorBlogEntries),
+		jobRuns:           maps.Clone(t.jobRuns),
+		outbox:            maps.Clone(t.outbox),
+		deliveries:        maps.Clone(t.deliveries),
+	}
+}
+
+// nextSeq : 挿入順の採番
+func (t *tables) nextSeq() int64 {
+	t.seq++
+	return t.seq
+}
+
+// requireUser : 外部キー（ユーザー）の検証
+func (t *tables) requireUser(id string) error {
+	if _, ok := t.users[id]; !ok {
+		return fmt.Errorf("%w: user %s does not exist", ErrForeignKey, id)
+	}
+	return nil
+}
+
+// requireBlog : 外部キー（ブログ）の検証
+func (t *tables) requireBlog(id string) error {
+	if _, ok := t.blogs[id]; !ok {
+		return fmt.Errorf("%w: blog %s does not exist", ErrForeignKey, id)
+	}
+	return nil
+}
+
+// requireComment : 外部キー（コメント）の検証
+func (t *tables) requireComment(id string) error {
+	if _, ok := t.comments[id]; !ok {
+		return fmt.Errorf("%w: comment %s does not exist", ErrForeignKey, id)
+	}
+	return nil
+}
+
+// deleteUser : ユーザーの削除（ユーザーを参照する行もカスケード削除する）
+func (t *tables) deleteUser(id string) {
+	delete(t.users, id)
+
+	for blogID, row := range t.blogs {
+		if row.userID == id {
+			t.deleteBlog(blogID)
+		}
+	}
+	for commentID, row := range t.comments {
+		if row.userID == id {
+			t.deleteComment(commentID)
+		}
+	}
+	for mentionID, row := range t.mentions {
+		if row.userID == id {
+			delete(t.mentions, mentionID)
+		}
+	}
+	for notificationID, row := range t.notifications {
+		if row.userID == id || row.actorID == id {
+			delete(t.notifications, notificationID)
+		}
+	}
+	for key := range t.preferences {
+		if key.userID == id {
+			delete(t.preferences, key)
+		}
+	}
+	for key := range t.likes {
+		if key.userID == id {
+			delete(t.likes, key)
+		}
+	}
+	for key := range t.reactions {
+		if key.userID == id {
+			delete(t.reactions, key)
+		}
+	}
+	for key := range t.authorEntries {
+		if key.userID == id {
+			delete(t.authorEntries, key)
+		}
+	}
+	for key, row := range t.authorBlogEntries {
+		if row.userID == id {
+			delete(t.authorBlogEntries, key)
+		}
+	}
+}
+
+// deleteBlog : ブログの削除（ブログを参照する行もカスケード削除する）
+func (t *tables) deleteBlog(id string) {
+	delete(t.blogs, id)
+
+	for commentID, row := range t.comments {
+		if row.blogID == id {
+			t.deleteComment(commentID)
+		}
+	}
+	for mentionID, row := range t.mentions {
+		if row.blogID == id {
+			delete(t.mentions, mentionID)
+		}
+	}
+	for notificationID, row := range t.notifications {
+		if row.blogID == id {
+			delete(t.notifications, notificationID)
+		}
+	}
+	for key := range t.likes {
+		if key.blogID == id {
+			delete(t.likes, key)
+		}
+	}
+	for key := range t.reactions {
+		if key.targetType == reaction.TargetBlog && key.targetID == id {
+			delete(t.reactions, key)
+		}
+	}
+	for key := range t.blogViews {
+		if key.blogID == id {
+			delete(t.blogViews, key)
+		}
+	}
+	for key := range t.rankingEntries {
+		if key.blogID == id {
+			delete(t.rankingEntries, key)
+		}
+	}
+	for key := range t.authorBlogEntries {
+		if key.blogID == id {
+			delete(t.authorBlogEntries, key)
+		}
+	}
+}
+
+// deleteComment : コメントの削除（コメントを参照する行もカスケード削除する）
+func (t *tables) deleteComment(id string) {
+	delete(t.comments, id)
+
+	for mentionID, row := range t.mentions {
+		if row.commentID == id {
+			delete(t.mentions, mentionID)
+		}
+	}
+	for notificationID, row := range t.notifications {
+		if row.commentID == id {
+			delete(t.notifications, notificationID)
+		}
+	}
+	for key := range t.reactions {
+		if key.targetType == reaction.TargetComment && key.targetID == id {
+			delete(t.reactions, key)
+		}
+	}
+}
+
+// deleteSnapshot : スナップショットの削除（ランキングもカスケード削除する）
+func (t *tables) deleteSnapshot(id string) {
+	delete(t.snapshots, id)
+
+	for key := range t.rankingEntries {
+		if key.snapshotID == id {
+			delete(t.rankingEntries, key)
+		}
+	}
+	for key := range t.authorEntries {
+		if key.snapshotID == id {
+			delete(t.authorEntries, key)
+		}
+	}
+	for key := range t.authorBlogEntries {
+		if key.snapshotID == id {
+			delete(t.authorBlogEntries, key)
+		}
+	}
+}
+
+// normalizeTime : データベースに保存した場合と同じ精度（マイクロ秒）の時刻に変換する
+func normalizeTime(t time.Time) time.Time {
+	return t.Round(0).Truncate(time.Microsecond)
+}
+
+// normalizeTimePtr : 時刻のポインタの変換（nilはNULLとして扱う）
+func normalizeTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	normalized := normalizeTime(*t)
+	return &normalized
+}
+
+// copyTimePtr : 呼び出し元が変更しても保存した値に影響しないよう時刻のポインタを複製する
+func copyTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := *t
+	return &value
+}
+
+// equalFold : 大文字と小文字を区別しない比較（MySQLの既定の照合順序と同じ）
+func equalFold(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// sortByTime : 日時（同じ場合は挿入順）による並び替え
+func sortByTime[T any](rows []T, key func(T) (time.Time, int64), desc bool) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		ti, si := key(rows[i])
+		tj, sj := key(rows[j])
+		if !ti.Equal(tj) {
+			if desc {
+				return ti.After(tj)
+			}
+			return ti.Before(tj)
+		}
+		if desc {
+			return si > sj
+		}
+		return si < sj
+	})
+}
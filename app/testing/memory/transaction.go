@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+
+	"myblog/app/infra/db/rdb"
+)
+
+// TransactionManager : トランザクション管理のインメモリ実装
+// トランザクションの間はStoreのロックを保持して他の操作を待たせ（直列化可能）、fnがエラーを返した場合は開始前のデータに戻す
+// トランザクション内の操作にはfnに渡されたコンテキストを使用すること（別のコンテキストで操作するとロックの取得を待ち続ける）
+type TransactionManager struct {
+	store *Store
+}
+
+// NewTransactionManager : TransactionManagerの生成
+func NewTransactionManager(store *Store) rdb.TransactionManager {
+	return &TransactionManager{store: store}
+}
+
+// Transaction : fnをトランザクション内で実行する
+// コンテキストに既にトランザクションがある場合はセーブポイントとして扱い、エラー時はfnの実行前のデータに戻す
+// デッドロックが発生しないため再実行はせず、optsの分離レベル等の指定は無視する
+func (tm *TransactionManager) Transaction(ctx context.Context, fn func(ctx context.Context) error, opts ...rdb.TxOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if parent, ok := tm.store.txFrom(ctx); ok {
+		return tm.run(context.WithValue(ctx, txKey{}, &txState{store: tm.store, depth: parent.depth + 1}), fn)
+	}
+
+	tm.store.mu.Lock()
+	defer tm.store.mu.Unlock()
+
+	return tm.run(context.WithValue(ctx, txKey{}, &txState{store: tm.store}), fn)
+}
+
+// run : 実行前のデータを複製してfnを実行し、エラーまたはパニックの場合は複製に戻す（ロックを取得して呼び出すこと）
+func (tm *TransactionManager) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := tm.store.data.clone()
+	committed := false
+	defer func() {
+		if !committed {
+			tm.store.data = saved
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	committed = true
+	return nil
+}
@@ -0,0 +1,183 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// userRow : usersテーブルの行
+type userRow struct {
+	id        string
+	username  string
+	email     string
+	password  []byte
+	createdAt time.Time
+	updatedAt time.Time
+	seq       int64
+}
+
+// toModel : 行からドメインモデルへの変換
+func (row userRow) toModel() (*user.User, error) {
+	return user.Reconstruct(
+		row.id,
+		row.username,
+		row.email,
+		append([]byte(nil), row.password...),
+		row.createdAt,
+		row.updatedAt,
+	)
+}
+
+// UserRepository : ユーザーリポジトリのインメモリ実装
+// メールアドレスはMySQLと同様に大文字と小文字を区別せずに一意とする
+type UserRepository struct {
+	store *Store
+}
+
+// NewUserRepository : UserRepositoryの生成
+func NewUserRepository(store *Store) repository.User {
+	return &UserRepository{store: store}
+}
+
+// Save : ユーザーの保存
+func (r *UserRepository) Save(ctx context.Context, user *user.User) error {
+	return r.store.do(ctx, func(t *tables) error {
+		id := user.ID().String()
+		if _, ok := t.users[id]; ok {
+			return fmt.Errorf("%w: users.id %s", ErrDuplicateKey, id)
+		}
+		if err := checkEmailUnique(t, id, user.Email()); err != nil {
+			return err
+		}
+
+		t.users[id] = userRow{
+			id:        id,
+			username:  user.Username(),
+			email:     user.Email(),
+			password:  append([]byte(nil), user.Password()...),
+			createdAt: normalizeTime(user.CreatedAt()),
+			updatedAt: normalizeTime(user.UpdatedAt()),
+			seq:       t.nextSeq(),
+		}
+		return nil
+	})
+}
+
+// FindByID : IDによるユーザー検索
+func (r *UserRepository) FindByID(ctx context.Context, id string) (*user.User, error) {
+	var found *user.User
+	err := r.store.do(ctx, func(t *tables) error {
+		row, ok := t.users[id]
+		if !ok {
+			return fmt.Errorf("user not found with id: %s", id)
+		}
+
+		var err error
+		found, err = row.toModel()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// FindByEmail : メールアドレスによるユーザー検索
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	var found *user.User
+	err := r.store.do(ctx, func(t *tables) error {
+		for _, row := range t.users {
+			if equalFold(row.email, email) {
+				var err error
+				found, err = row.toModel()
+				return err
+			}
+		}
+		return fmt.Errorf("user not found with email: %s", email)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// FindByUsernames : ユーザー名による複数ユーザー検索（作成日時の昇順。大文字と小文字は区別しない）
+func (r *UserRepository) FindByUsernames(ctx context.Context, usernames []string) ([]*user.User, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	var users []*user.User
+	err := r.store.do(ctx, func(t *tables) error {
+		var rows []userRow
+		for _, row := range t.users {
+			for _, username := range usernames {
+				if equalFold(row.username, username) {
+					rows = append(rows, row)
+					break
+				}
+			}
+		}
+		sortByTime(rows, func(row userRow) (time.Time, int64) { return row.createdAt, row.seq }, false)
+
+		users = make([]*user.User, len(rows))
+		for i, row := range rows {
+			u, err := row.toModel()
+			if err != nil {
+				return err
+			}
+			users[i] = u
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Update : ユーザーの更新
+func (r *UserRepository) Update(ctx context.Context, user *user.User) error {
+	return r.store.do(ctx, func(t *tables) error {
+		id := user.ID().String()
+		row, ok := t.users[id]
+		if !ok {
+			return fmt.Errorf("user not found with id: %s", id)
+		}
+		if err := checkEmailUnique(t, id, user.Email()); err != nil {
+			return err
+		}
+
+		row.username = user.Username()
+		row.email = user.Email()
+		row.password = append([]byte(nil), user.Password()...)
+		row.updatedAt = normalizeTime(time.Now())
+		t.users[id] = row
+		return nil
+	})
+}
+
+// Delete : ユーザーの削除（ユーザーのブログやコメント等もカスケード削除する）
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	return r.store.do(ctx, func(t *tables) error {
+		if _, ok := t.users[id]; !ok {
+			return fmt.Errorf("user not found with id: %s", id)
+		}
+		t.deleteUser(id)
+		return nil
+	})
+}
+
+// checkEmailUnique : 他のユーザーが同じメールアドレスを使用していないかの検証
+func checkEmailUnique(t *tables, id, email string) error {
+	for _, row := range t.users {
+		if row.id != id && equalFold(row.email, email) {
+			return fmt.Errorf("%w: users.email %s", ErrDuplicateKey, email)
+		}
+	}
+	return nil
+}
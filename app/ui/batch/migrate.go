@@ -0,0 +1,243 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"myblog/app/infra/db/migration"
+
+	"github.com/spf13/cobra"
+)
+
+// NewMigrateCmd はデータベースのマイグレーションコマンドを生成する
+func NewMigrateCmd(migrator *migration.Migrator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "データベースのマイグレーションを実行する",
+		Long: "バイナリに埋め込まれたマイグレーションファイルを適用または取り消し、適用済みのバージョンとチェックサムをschema_migrationsテーブルに記録します\n" +
+			"適用後に変更・削除されたファイルや途中で失敗したマイグレーションがある場合は実行しません\n" +
+			"実行中はロックを保持するため、複数のプロセスで同時に実行しても重複して適用されません",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			lockTimeout, err := cmd.Flags().GetDuration("lock-timeout")
+			if err != nil {
+				return fmt.Errorf("ロックの待機時間の指定が不正です: %w", err)
+			}
+			if lockTimeout < 0 {
+				return errors.New("ロックの待機時間には0以上を指定してください")
+			}
+			migrator.SetLockTimeout(lockTimeout)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().Duration("lock-timeout", migration.DefaultLockTimeout, "他のプロセスが実行中の場合にロックの解放を待機する時間")
+
+	cmd.AddCommand(newMigrateUpCmd(migrator))
+	cmd.AddCommand(newMigrateDownCmd(migrator))
+	cmd.AddCommand(newMigrateToCmd(migrator))
+	cmd.AddCommand(newMigrateStatusCmd(migrator))
+	cmd.AddCommand(newMigrateBaselineCmd(migrator))
+	cmd.AddCommand(newMigrateRepairCmd(migrator))
+
+	return cmd
+}
+
+// newMigrateUpCmd は未適用のマイグレーションの適用コマンドを生成する
+func newMigrateUpCmd(migrator *migration.Migrator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "up",
+		Args:  cobra.NoArgs,
+		Short: "未適用のマイグレーションを適用する",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			steps, err := cmd.Flags().GetInt("steps")
+			if err != nil {
+				return fmt.Errorf("件数の指定が不正です: %w", err)
+			}
+			if steps < 0 {
+				return errors.New("件数には0以上を指定してください")
+			}
+
+			executed, err := migrator.Up(cmd.Context(), steps)
+			printSteps(cmd, executed)
+			if err != nil {
+				return err
+			}
+			if len(executed) == 0 {
+				cmd.Println("未適用のマイグレーションはありません")
+			}
+			return nil
+		},
+		Example: "migrate up  # 全ての未適用のマイグレーションを適用\n" +
+			"migrate up --steps 1  # 最も古い未適用のマイグレーションを1件適用",
+	}
+
+	cmd.Flags().Int("steps", 0, "適用する件数（0の場合は全て）")
+
+	return cmd
+}
+
+// newMigrateDownCmd は適用済みのマイグレーションの取り消しコマンドを生成する
+func newMigrateDownCmd(migrator *migration.Migrator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "down",
+		Args:  cobra.NoArgs,
+		Short: "適用済みのマイグレーションを新しい順に取り消す",
+		Long:  "適用済みのマイグレーションを新しい順に取り消します。取り消すSQL（db/migrations/down以下の同じ名前のファイル）がないマイグレーションは取り消せません",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			steps, err := cmd.Flags().GetInt("steps")
+			if err != nil {
+				return fmt.Errorf("件数の指定が不正です: %w", err)
+			}
+			if steps <= 0 {
+				return errors.New("件数は1以上を指定してください")
+			}
+
+			executed, err := migrator.Down(cmd.Context(), steps)
+			printSteps(cmd, executed)
+			if err != nil {
+				return err
+			}
+			if len(executed) == 0 {
+				cmd.Println("適用済みのマイグレーションはありません")
+			}
+			return nil
+		},
+		Example: "migrate down  # 最も新しいマイグレーションを1件取り消す\n" +
+			"migrate down --steps 3  # 新しい順に3件取り消す",
+	}
+
+	cmd.Flags().Int("steps", 1, "取り消す件数")
+
+	return cmd
+}
+
+// newMigrateToCmd は指定したバージョンまでの適用または取り消しコマンドを生成する
+func newMigrateToCmd(migrator *migration.Migrator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "to <version>",
+		Args:  cobra.ExactArgs(1),
+		Short: "指定したバージョンまで適用または取り消す",
+		Long:  "指定したバージョンより新しい適用済みのマイグレーションを取り消し、指定したバージョンまでの未適用のマイグレーションを適用します。0を指定した場合は全て取り消します",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := parseVersion(args[0])
+			if err != nil {
+				return err
+			}
+
+			executed, err := migrator.To(cmd.Context(), version)
+			printSteps(cmd, executed)
+			if err != nil {
+				return err
+			}
+			if len(executed) == 0 {
+				cmd.Printf("バージョン %d まで適用済みです\n", version)
+			}
+			return nil
+		},
+		Example: "migrate to 20250601000015  # バージョン20250601000015まで適用または取り消す\n" +
+			"migrate to 0  # 全て取り消す",
+	}
+}
+
+// newMigrateStatusCmd はマイグレーションの適用状況の表示コマンドを生成する
+func newMigrateStatusCmd(migrator *migration.Migrator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Args:  cobra.NoArgs,
+		Short: "マイグレーションの適用状況を表示する",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			statuses, err := migrator.Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("%-14s  %-50s  %-8s  %-25s  %-10s  %s\n", "VERSION", "NAME", "STATUS", "APPLIED AT", "REVERSIBLE", "PROBLEM")
+			for _, status := range statuses {
+				state := "pending"
+				if status.Applied {
+					state = "applied"
+				}
+				if status.Dirty {
+					state = "dirty"
+				}
+				appliedAt := "-"
+				if status.AppliedAt != nil {
+					appliedAt = status.AppliedAt.Format(time.RFC3339)
+				}
+				reversible := "-"
+				if status.Migration != nil {
+					reversible = strconv.FormatBool(status.Migration.Reversible())
+				}
+				cmd.Printf("%-14d  %-50s  %-8s  %-25s  %-10s  %s\n", status.Version, status.Name, state, appliedAt, reversible, status.Drift)
+			}
+			return nil
+		},
+	}
+}
+
+// newMigrateBaselineCmd は既存のデータベースのマイグレーションを適用済みとして記録するコマンドを生成する
+func newMigrateBaselineCmd(migrator *migration.Migrator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "baseline <version>",
+		Args:  cobra.ExactArgs(1),
+		Short: "指定したバージョンまでのマイグレーションをSQLを実行せずに適用済みとして記録する",
+		Long:  "マイグレーションの記録を始める前に作成されたデータベースで、既に適用されているマイグレーションを適用済みとして記録します",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := parseVersion(args[0])
+			if err != nil {
+				return err
+			}
+
+			recorded, err := migrator.Baseline(cmd.Context(), version)
+			if err != nil {
+				return err
+			}
+			for _, m := range recorded {
+				cmd.Printf("recorded  %s\n", m)
+			}
+			cmd.Printf("%d件のマイグレーションを適用済みとして記録しました\n", len(recorded))
+			return nil
+		},
+		Example: "migrate baseline 20250601000017  # 20250601000017までを適用済みとして記録",
+	}
+}
+
+// newMigrateRepairCmd は途中で失敗したマイグレーションの記録の削除コマンドを生成する
+func newMigrateRepairCmd(migrator *migration.Migrator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "repair <version>",
+		Args:  cobra.ExactArgs(1),
+		Short: "途中で失敗したマイグレーションを未適用に戻す",
+		Long:  "途中で失敗したマイグレーションの記録を削除し、未適用の状態に戻します。スキーマを手作業で適用前の状態に戻してから実行してください",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := parseVersion(args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := migrator.Repair(cmd.Context(), version); err != nil {
+				return err
+			}
+			cmd.Printf("バージョン %d を未適用に戻しました\n", version)
+			return nil
+		},
+	}
+}
+
+// parseVersion はマイグレーションのバージョンの指定を解析する
+func parseVersion(s string) (int64, error) {
+	version, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("バージョンの指定が不正です: %s", s)
+	}
+	return version, nil
+}
+
+// printSteps は実行したマイグレーションを表示する
+func printSteps(cmd *cobra.Command, steps []migration.Step) {
+	for _, step := range steps {
+		cmd.Printf("%-4s  %s  (%s)\n", step.Direction, step.Migration, step.Duration.Round(time.Millisecond))
+	}
+}
@@ -0,0 +1,67 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Failure は失敗したバッチ実行の情報
+type Failure struct {
+	JobName    string    `json:"job_name"`
+	Params     string    `json:"params"`
+	Attempts   int       `json:"attempts"`
+	Error      string    `json:"error"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// Notifier はバッチ実行の失敗を通知するインターフェース
+type Notifier interface {
+	NotifyFailure(ctx context.Context, failure Failure) error
+}
+
+// webhookTimeout はWebhookの送信のタイムアウト
+const webhookTimeout = 10 * time.Second
+
+// WebhookNotifier は失敗したバッチ実行の情報をJSONとしてWebhookのURLにPOSTする
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier はWebhookNotifierのコンストラクタ
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// NotifyFailure は失敗したバッチ実行の情報をWebhookに送信する
+func (n *WebhookNotifier) NotifyFailure(ctx context.Context, failure Failure) error {
+	body, err := json.Marshal(failure)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhookが失敗しました: %s", resp.Status)
+	}
+	return nil
+}
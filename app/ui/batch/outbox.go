@@ -0,0 +1,98 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"myblog/app/ui/http"
+	"myblog/app/usecase"
+
+	"github.com/spf13/cobra"
+)
+
+// outboxRelayLockID はイベントの配信中に保持するロックのID
+const outboxRelayLockID = "outbox-relay"
+
+// NewOutboxRelayCmd はアウトボックスのイベント配信コマンドを生成する
+func NewOutboxRelayCmd(relay *usecase.OutboxRelay, mutex http.Mutex) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "outbox-relay",
+		Args:  cobra.NoArgs,
+		Short: "アウトボックスに記録されたドメインイベントを購読者に配信する",
+		Long: "アウトボックスに記録された未配信のドメインイベントを発生順に購読者に配信します。配信に失敗したイベントは待機時間をおいて再配信します\n" +
+			"複数のプロセスで起動した場合はロックを取得した1つのプロセスのみが配信し、他のプロセスは待機します",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			interval, err := cmd.Flags().GetDuration("interval")
+			if err != nil {
+				return fmt.Errorf("配信間隔の指定が不正です: %w", err)
+			}
+			if interval <= 0 {
+				return errors.New("配信間隔には0より大きい値を指定してください")
+			}
+			batchSize, err := cmd.Flags().GetInt("batch-size")
+			if err != nil {
+				return fmt.Errorf("件数の指定が不正です: %w", err)
+			}
+			if batchSize <= 0 {
+				return errors.New("件数は1以上を指定してください")
+			}
+			once, err := cmd.Flags().GetBool("once")
+			if err != nil {
+				return fmt.Errorf("onceの指定が不正です: %w", err)
+			}
+
+			if once {
+				lockCtx, unlock, err := mutex.Lock(ctx, outboxRelayLockID, time.Minute)
+				if err != nil {
+					return fmt.Errorf("ロック取得処理に失敗しました: Mutex.Lock(id: %s): %w", outboxRelayLockID, err)
+				}
+				defer unlock()
+
+				published, err := relay.RelayOnce(lockCtx, batchSize)
+				if err != nil {
+					return fmt.Errorf("イベントの配信に失敗しました: %w", lockLost(lockCtx, err))
+				}
+				addRowsProcessed(ctx, published)
+				cmd.Printf("%d件のイベントを配信しました\n", published)
+				return nil
+			}
+
+			// ロックを取得できるまで待機し、取得後は終了シグナルを受け取るまで配信を続ける
+			for {
+				lockCtx, unlock, err := mutex.Lock(ctx, outboxRelayLockID, time.Minute)
+				if err == nil {
+					defer unlock()
+					if err := relay.Run(lockCtx, interval, batchSize); err != nil {
+						return err
+					}
+					// ロックが失われて停止した場合は、他のプロセスと同時に配信しないようエラーで終了する
+					if cause := context.Cause(lockCtx); errors.Is(cause, http.ErrLockLost) {
+						return fmt.Errorf("イベントの配信を中断しました: %w", cause)
+					}
+					return nil
+				}
+				if !errors.Is(err, http.ErrLocked) {
+					cmd.Printf("ロック取得処理に失敗しました: %v\n", err)
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(interval):
+				}
+			}
+		},
+		Example: "outbox-relay  # 終了シグナルを受け取るまで1秒間隔で配信\n" +
+			"outbox-relay --once  # 未配信のイベントを1回だけ配信して終了",
+	}
+
+	cmd.Flags().Duration("interval", time.Second, "未配信のイベントを確認する間隔")
+	cmd.Flags().Int("batch-size", 100, "1回に配信するイベントの最大件数")
+	cmd.Flags().Bool("once", false, "未配信のイベントを1回だけ配信して終了する")
+
+	return cmd
+}
@@ -1,33 +1,33 @@
 package batch
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log"
 	"strconv"
 	"time"
 
+	"myblog/app/domain/model/ranking"
 	"myblog/app/ui/http"
 	"myblog/app/usecase"
 
 	"github.com/spf13/cobra"
 )
 
-// errLocked はロックが既に取得されていることを示すエラー
-var errLocked = errors.New("locked")
-
 // Ranking はランキングバッチのハンドラー
 type Ranking interface {
 	CalculatePopularRanking(cmd *cobra.Command, args []string) error
 }
 
-type ranking struct {
+type rankingHandler struct {
 	rankingUseCase *usecase.RankingUseCase
 	mutex          http.Mutex
 }
 
 // NewRanking はRankingハンドラーのコンストラクタ
 func NewRanking(rankingUseCase *usecase.RankingUseCase, mutex http.Mutex) Ranking {
-	return &ranking{
+	return &rankingHandler{
 		rankingUseCase: rankingUseCase,
 		mutex:          mutex,
 	}
@@ -36,48 +36,208 @@ func NewRanking(rankingUseCase *usecase.RankingUseCase, mutex http.Mutex) Rankin
 // NewCalculatePopularRankingCmd は人気記事ランキング集計コマンドを生成する
 func NewCalculatePopularRankingCmd(r Ranking) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "calculate-popular-ranking [days]",
-		Args:  cobra.ExactArgs(1),
+		Use:   "calculate-popular-ranking [type]",
+		Args:  cobra.RangeArgs(0, 1),
 		Short: "人気記事ランキングを集計する",
-		Long:  "指定した日数の間のアクセス数とコメント数に基づいて人気記事ランキングを集計します",
+		Long: "指定した種別（daily, weekly, monthly, all_time）の集計期間のアクセス数・いいね数・コメント数から算出したスコアに基づいて人気記事ランキング・著者ランキング・著者ごとの人気記事ランキングを集計し、スナップショットとして保存します\n" +
+			"種別を省略した場合は全ての種別を集計します。集計後、保持期間を過ぎたスナップショットを削除します\n" +
+			"以前の日数による指定（例: 7）は非推奨ですが、最も近い集計期間の種別として受け付けます",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return r.CalculatePopularRanking(cmd, args)
 		},
-		Example: "calculate-popular-ranking 7  # 過去7日間のデータでランキングを集計",
+		Example: "calculate-popular-ranking  # 全ての種別のランキングを集計\n" +
+			"calculate-popular-ranking weekly  # 過去7日間のデータで週間ランキングを集計\n" +
+			"calculate-popular-ranking 7  # 非推奨: 日数は最も近い集計期間の種別（この場合はweekly）として扱う\n" +
+			"calculate-popular-ranking weekly --like-weight 1 --comment-weight 3  # コメントを重視して集計\n" +
+			"calculate-popular-ranking all_time --scoring gravity --gravity 1.5  # 投稿からの経過時間で減衰させて集計\n" +
+			"calculate-popular-ranking --retention-days 30  # 30日より古いスナップショットを削除\n" +
+			"calculate-popular-ranking weekly --size 20 --dry-run  # 保存せずに上位20件のランキングを表示",
 	}
 
+	cmd.Flags().Int("view-weight", ranking.DefaultWeights.View, "アクセス1件あたりのスコア")
+	cmd.Flags().Int("like-weight", ranking.DefaultWeights.Like, "いいね1件あたりのスコア")
+	cmd.Flags().Int("comment-weight", ranking.DefaultWeights.Comment, "コメント1件あたりのスコア")
+	cmd.Flags().String("scoring", ranking.ScoringCount, "スコアの算出方法（count: 重み付き件数, gravity: 経過時間による重力減衰, half-life: 経過時間による半減期減衰）")
+	cmd.Flags().Float64("gravity", ranking.DefaultGravity, "重力減衰の重力（--scoring gravity の場合のみ使用）")
+	cmd.Flags().Duration("half-life", ranking.DefaultHalfLife, "半減期減衰の半減期（--scoring half-life の場合のみ使用）")
+	cmd.Flags().Int("size", ranking.DefaultSize, "ランキングに含めるブログの件数")
+	cmd.Flags().Int("author-size", ranking.DefaultAuthorSize, "著者ランキングに含める著者の件数（著者ごとの人気記事ランキングもこの著者のみ集計する）")
+	cmd.Flags().Int("author-blog-size", ranking.DefaultAuthorBlogSize, "著者ごとの人気記事ランキングに含めるブログの件数")
+	cmd.Flags().Int("retention-days", 90, "スナップショットの保持日数（0の場合は削除しない）")
+	cmd.Flags().Bool("dry-run", false, "ランキングを保存せずに表示する")
+
 	return cmd
 }
 
 // CalculatePopularRanking は人気記事ランキングを集計する
-func (r *ranking) CalculatePopularRanking(cmd *cobra.Command, args []string) error {
+func (r *rankingHandler) CalculatePopularRanking(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
-	// 引数から日数を取得
-	days, err := strconv.Atoi(args[0])
+	// 引数からランキング種別を取得（省略時は全種別）
+	rankingTypes := ranking.Types
+	if len(args) > 0 {
+		rankingType, err := parseRankingTypeArg(args[0])
+		if err != nil {
+			return fmt.Errorf("ランキング種別の指定が不正です: %w", err)
+		}
+		rankingTypes = []ranking.Type{rankingType}
+	}
+
+	// フラグから重み付けを取得
+	viewWeight, err := cmd.Flags().GetInt("view-weight")
+	if err != nil {
+		return fmt.Errorf("アクセスの重みの指定が不正です: %w", err)
+	}
+	likeWeight, err := cmd.Flags().GetInt("like-weight")
+	if err != nil {
+		return fmt.Errorf("いいねの重みの指定が不正です: %w", err)
+	}
+	commentWeight, err := cmd.Flags().GetInt("comment-weight")
+	if err != nil {
+		return fmt.Errorf("コメントの重みの指定が不正です: %w", err)
+	}
+	weights := ranking.Weights{View: viewWeight, Like: likeWeight, Comment: commentWeight}
+
+	// フラグからスコアの算出方法を取得
+	scoring, err := cmd.Flags().GetString("scoring")
+	if err != nil {
+		return fmt.Errorf("スコアの算出方法の指定が不正です: %w", err)
+	}
+	gravity, err := cmd.Flags().GetFloat64("gravity")
+	if err != nil {
+		return fmt.Errorf("重力の指定が不正です: %w", err)
+	}
+	halfLife, err := cmd.Flags().GetDuration("half-life")
+	if err != nil {
+		return fmt.Errorf("半減期の指定が不正です: %w", err)
+	}
+	scorer, err := ranking.NewScorer(scoring, weights, gravity, halfLife)
+	if err != nil {
+		return fmt.Errorf("スコアの算出方法の指定が不正です: %w", err)
+	}
+
+	size, err := cmd.Flags().GetInt("size")
+	if err != nil {
+		return fmt.Errorf("件数の指定が不正です: %w", err)
+	}
+	if size <= 0 {
+		return errors.New("件数は1以上を指定してください")
+	}
+
+	authorSize, err := cmd.Flags().GetInt("author-size")
+	if err != nil {
+		return fmt.Errorf("著者の件数の指定が不正です: %w", err)
+	}
+	if authorSize <= 0 {
+		return errors.New("著者の件数は1以上を指定してください")
+	}
+
+	authorBlogSize, err := cmd.Flags().GetInt("author-blog-size")
 	if err != nil {
-		return fmt.Errorf("日数の指定が不正です: %w", err)
+		return fmt.Errorf("著者ごとの件数の指定が不正です: %w", err)
+	}
+	if authorBlogSize <= 0 {
+		return errors.New("著者ごとの件数は1以上を指定してください")
+	}
+
+	retentionDays, err := cmd.Flags().GetInt("retention-days")
+	if err != nil {
+		return fmt.Errorf("保持日数の指定が不正です: %w", err)
+	}
+	if retentionDays < 0 {
+		return errors.New("保持日数は0以上を指定してください")
 	}
 
-	if days <= 0 {
-		return errors.New("日数は1以上を指定してください")
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("dry-runの指定が不正です: %w", err)
 	}
 
-	// 多重実行を防ぐためロック
+	// dry-runの場合は集計結果を表示するのみで、保存と古いスナップショットの削除は行わない
+	if dryRun {
+		for _, rankingType := range rankingTypes {
+			result, err := r.rankingUseCase.ComputePopularRanking(ctx, rankingType, scorer, size, authorSize, authorBlogSize)
+			if err != nil {
+				return fmt.Errorf("人気記事ランキング集計に失敗しました（種別: %s）: %w", rankingType, err)
+			}
+			addRowsProcessed(ctx, result.BlogCount)
+			printRankingResult(cmd, result)
+		}
+		return nil
+	}
+
+	// 多重実行を防ぐためロック（ロックが失われた場合は集計を中断するため、以降はロックのコンテキストで実行する）
 	lockID := "calculate-popular-ranking"
-	unlock, err := r.mutex.Lock(ctx, lockID, 10*time.Minute)
+	lockCtx, unlock, err := r.mutex.Lock(ctx, lockID, 10*time.Minute)
 	if err != nil {
-		if errors.Is(err, errLocked) {
+		if errors.Is(err, http.ErrLocked) {
 			return fmt.Errorf("人気記事ランキング集計が多重実行されています: Mutex.Lock(id: %s): %w", lockID, err)
 		}
 		return fmt.Errorf("ロック取得処理に失敗しました: Mutex.Lock(id: %s): %w", lockID, err)
 	}
 	defer unlock()
 
-	// ランキング集計を実行
-	if err := r.rankingUseCase.CalculatePopularRanking(ctx, days); err != nil {
-		return fmt.Errorf("人気記事ランキング集計に失敗しました: %w", err)
+	// 種別ごとにランキング集計を実行
+	for _, rankingType := range rankingTypes {
+		result, err := r.rankingUseCase.CalculatePopularRanking(lockCtx, rankingType, scorer, size, authorSize, authorBlogSize)
+		if err != nil {
+			return fmt.Errorf("人気記事ランキング集計に失敗しました（種別: %s）: %w", rankingType, lockLost(lockCtx, err))
+		}
+		addRowsProcessed(ctx, result.BlogCount)
+	}
+
+	// 保持期間を過ぎたスナップショットを削除
+	if retentionDays > 0 {
+		deleted, err := r.rankingUseCase.PruneRankingSnapshots(lockCtx, time.Duration(retentionDays)*24*time.Hour)
+		if err != nil {
+			return fmt.Errorf("古いランキングの削除に失敗しました: %w", lockLost(lockCtx, err))
+		}
+		cmd.Printf("%d件の古いランキングのスナップショットを削除しました\n", deleted)
 	}
 
 	return nil
 }
+
+// lockLost はロックが失われて処理が中断された場合に、エラーにその理由を加える
+func lockLost(lockCtx context.Context, err error) error {
+	if cause := context.Cause(lockCtx); errors.Is(cause, http.ErrLockLost) {
+		return fmt.Errorf("ロックが失われたため中断しました: %w", errors.Join(cause, err))
+	}
+	return err
+}
+
+// parseRankingTypeArg は引数からランキング種別を取得する
+// 以前の「calculate-popular-ranking [days]」の形式で登録されたcronなどを動かし続けるため、
+// 日数は最も近い集計期間の種別として受け付ける（非推奨のため警告を出力する）
+func parseRankingTypeArg(arg string) (ranking.Type, error) {
+	rankingType, err := ranking.ParseType(arg)
+	if err == nil {
+		return rankingType, nil
+	}
+
+	days, convErr := strconv.Atoi(arg)
+	if convErr != nil {
+		return "", err
+	}
+	rankingType, err = ranking.TypeForDays(days)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("警告: 日数による集計期間の指定は非推奨です。種別（daily, weekly, monthly, all_time）を指定してください（%d日 → %s）", days, rankingType)
+	return rankingType, nil
+}
+
+// printRankingResult は集計したランキングを表示する
+func printRankingResult(cmd *cobra.Command, result *ranking.Result) {
+	cmd.Printf("[%s] 集計日時: %s, 集計対象: %d件\n", result.Snapshot.Type, result.Snapshot.CalculatedAt.Format(time.RFC3339), result.BlogCount)
+
+	cmd.Println("人気記事ランキング:")
+	for _, rank := range result.Rankings {
+		cmd.Printf("  %4d  %12.4f  %s\n", rank.RankingPosition, rank.Score, rank.BlogID.String())
+	}
+
+	cmd.Println("著者ランキング:")
+	for _, rank := range result.AuthorRankings {
+		cmd.Printf("  %4d  %12.4f  %s（%d件）\n", rank.RankingPosition, rank.Score, rank.UserID.String(), rank.BlogCount)
+	}
+}
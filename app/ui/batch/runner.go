@@ -0,0 +1,163 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"myblog/app/infra/db/rdb"
+	"myblog/app/usecase"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// RetryPolicy は一時的なデータベースエラーで失敗したバッチのリトライ方法
+type RetryPolicy struct {
+	MaxRetries     int           // 最大リトライ回数（0の場合はリトライしない）
+	InitialBackoff time.Duration // 初回のリトライまでの待機時間（リトライのたびに2倍にする）
+	MaxBackoff     time.Duration // リトライまでの待機時間の上限
+}
+
+// DefaultRetryPolicy はデフォルトのリトライ方法
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     2,
+	InitialBackoff: time.Second,
+	MaxBackoff:     30 * time.Second,
+}
+
+// backoff はattempt回目の失敗後のリトライまでの待機時間を返す
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return backoff
+}
+
+// runStats は実行中のバッチの処理件数
+type runStats struct {
+	rowsProcessed atomic.Int64
+}
+
+// runStatsKey はrunStatsをコンテキストに格納するためのキー
+type runStatsKey struct{}
+
+// addRowsProcessed は実行中のバッチの処理件数を加算する
+func addRowsProcessed(ctx context.Context, rows int) {
+	if stats, ok := ctx.Value(runStatsKey{}).(*runStats); ok {
+		stats.rowsProcessed.Add(int64(rows))
+	}
+}
+
+// Runner はバッチを実行し、実行履歴の記録・一時的なエラーのリトライ・失敗の通知を行う
+type Runner struct {
+	jobRunUsecase usecase.JobRunUsecase
+	notifier      Notifier
+	retryPolicy   RetryPolicy
+}
+
+// NewRunner はRunnerのコンストラクタ（notifierがnilの場合は失敗を通知しない）
+func NewRunner(jobRunUsecase usecase.JobRunUsecase, notifier Notifier, retryPolicy RetryPolicy) *Runner {
+	return &Runner{
+		jobRunUsecase: jobRunUsecase,
+		notifier:      notifier,
+		retryPolicy:   retryPolicy,
+	}
+}
+
+// SetRetryPolicy はリトライ方法を変更する
+func (r *Runner) SetRetryPolicy(retryPolicy RetryPolicy) {
+	r.retryPolicy = retryPolicy
+}
+
+// Wrap はコマンドの実行をRunnerで行うようにする
+// scheduledAtはスケジューラーから実行する場合の予定実行日時（それ以外はnil）
+func (r *Runner) Wrap(cmd *cobra.Command, scheduledAt *time.Time) *cobra.Command {
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return r.Run(cmd.Context(), cmd.Name(), commandParams(cmd, args), scheduledAt, func(ctx context.Context) error {
+			cmd.SetContext(ctx)
+			return runE(cmd, args)
+		})
+	}
+	return cmd
+}
+
+// Run はfnを実行し、実行履歴を記録する
+// 一時的なデータベースエラーで失敗した場合はリトライし、最終的に失敗した場合は通知する
+// 同じ予定実行日時の実行が既に記録されている場合は実行しない
+func (r *Runner) Run(ctx context.Context, jobName string, params string, scheduledAt *time.Time, fn func(ctx context.Context) error) error {
+	run, created, err := r.jobRunUsecase.StartJobRun(ctx, jobName, params, scheduledAt)
+	if err != nil {
+		return fmt.Errorf("バッチ実行の記録に失敗しました: %w", err)
+	}
+	if !created {
+		// 重複として扱うのは予定実行日時がある場合のみ（手動実行は予定実行日時がなく重複しないため、記録できなければエラー）
+		if scheduledAt == nil {
+			return fmt.Errorf("バッチ実行の記録に失敗しました（バッチ: %s）", jobName)
+		}
+		log.Printf("バッチは他のプロセスで実行済みのためスキップしました（バッチ: %s, 予定: %s）", jobName, scheduledAt.Format(time.RFC3339))
+		return nil
+	}
+
+	attempts := 0
+	var stats *runStats
+	var runErr error
+	for {
+		// 処理件数は最後の試行のものを記録する
+		attempts++
+		stats = &runStats{}
+		runErr = fn(context.WithValue(ctx, runStatsKey{}, stats))
+		if runErr == nil || !rdb.IsTransient(runErr) || attempts > r.retryPolicy.MaxRetries {
+			break
+		}
+
+		backoff := r.retryPolicy.backoff(attempts)
+		log.Printf("一時的なエラーのためリトライします（バッチ: %s, 試行回数: %d, 待機時間: %s）: %v", jobName, attempts, backoff, runErr)
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	// 停止によりctxが終了していても実行結果は記録する
+	recordCtx := context.WithoutCancel(ctx)
+	if err := r.jobRunUsecase.FinishJobRun(recordCtx, run, stats.rowsProcessed.Load(), attempts, runErr); err != nil {
+		log.Printf("バッチ実行結果の記録に失敗しました（バッチ: %s）: %v", jobName, err)
+	}
+
+	if runErr != nil && r.notifier != nil {
+		failure := Failure{
+			JobName:    jobName,
+			Params:     params,
+			Attempts:   attempts,
+			Error:      runErr.Error(),
+			StartedAt:  run.StartedAt(),
+			FinishedAt: *run.FinishedAt(),
+		}
+		if err := r.notifier.NotifyFailure(recordCtx, failure); err != nil {
+			log.Printf("バッチ実行の失敗の通知に失敗しました（バッチ: %s）: %v", jobName, err)
+		}
+	}
+
+	return runErr
+}
+
+// commandParams はコマンドの引数と指定されたフラグを文字列にする
+func commandParams(cmd *cobra.Command, args []string) string {
+	params := append([]string{}, args...)
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		params = append(params, fmt.Sprintf("--%s=%s", flag.Name, flag.Value.String()))
+	})
+	return strings.Join(params, " ")
+}
@@ -0,0 +1,218 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"myblog/app/ui/http"
+	"myblog/app/usecase"
+
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/cobra"
+)
+
+// Job はスケジューラーで定期実行するジョブ
+type Job struct {
+	Name       string                // ジョブ名（実行履歴とロックのキーに使用する）
+	Schedule   string                // cron形式の実行スケジュール（分 時 日 月 曜日）
+	NewCommand func() *cobra.Command // 実行するコマンドを生成する関数（実行のたびに生成し、フラグの状態を引き継がない）
+	Args       []string              // コマンドに渡す引数
+}
+
+// jobLockTTL はジョブ実行中に保持するロックのTTL（ロックは実行中に延長される）
+const jobLockTTL = time.Minute
+
+// Scheduler は登録されたジョブをcron形式のスケジュールで実行する
+// 複数のプロセスで起動した場合も、同じ予定のジョブは1つのプロセスでのみ実行する
+type Scheduler struct {
+	mutex  http.Mutex
+	runner *Runner
+	jobs   map[string]Job
+}
+
+// NewScheduler はSchedulerのコンストラクタ
+// ジョブの実行履歴の記録・リトライ・失敗の通知はrunnerで行う
+func NewScheduler(mutex http.Mutex, runner *Runner) *Scheduler {
+	return &Scheduler{
+		mutex:  mutex,
+		runner: runner,
+		jobs:   make(map[string]Job),
+	}
+}
+
+// Register はジョブを登録する
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" {
+		return errors.New("ジョブ名が空です")
+	}
+	if _, ok := s.jobs[job.Name]; ok {
+		return fmt.Errorf("ジョブが重複して登録されています: %s", job.Name)
+	}
+	if _, err := cron.ParseStandard(job.Schedule); err != nil {
+		return fmt.Errorf("ジョブのスケジュールが不正です（ジョブ: %s）: %w", job.Name, err)
+	}
+	s.jobs[job.Name] = job
+	return nil
+}
+
+// Jobs は登録されているジョブをジョブ名の順で返す
+func (s *Scheduler) Jobs() []Job {
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+	return jobs
+}
+
+// SetSchedule は登録済みのジョブのスケジュールを変更する
+func (s *Scheduler) SetSchedule(name string, schedule string) error {
+	job, ok := s.jobs[name]
+	if !ok {
+		return fmt.Errorf("ジョブが登録されていません: %s", name)
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return fmt.Errorf("ジョブのスケジュールが不正です（ジョブ: %s）: %w", name, err)
+	}
+	job.Schedule = schedule
+	s.jobs[name] = job
+	return nil
+}
+
+// Run はctxが終了するまでジョブをスケジュールに従って実行する
+// 終了時は実行中のジョブの完了を待つ
+func (s *Scheduler) Run(ctx context.Context) error {
+	c := cron.New()
+	for _, job := range s.Jobs() {
+		job := job
+		if _, err := c.AddFunc(job.Schedule, func() {
+			s.execute(ctx, job, time.Now().Truncate(time.Minute))
+		}); err != nil {
+			return fmt.Errorf("ジョブの登録に失敗しました（ジョブ: %s）: %w", job.Name, err)
+		}
+		log.Printf("ジョブを登録しました（ジョブ: %s, スケジュール: %s）", job.Name, job.Schedule)
+	}
+
+	c.Start()
+	<-ctx.Done()
+
+	log.Println("スケジューラーを停止しています。実行中のジョブの完了を待ちます")
+	<-c.Stop().Done()
+	return nil
+}
+
+// execute はジョブを1回実行する
+func (s *Scheduler) execute(ctx context.Context, job Job, scheduledAt time.Time) {
+	// 同じジョブが他のプロセスで実行中の場合は実行しない
+	lockID := "job:" + job.Name
+	lockCtx, unlock, err := s.mutex.Lock(ctx, lockID, jobLockTTL)
+	if err != nil {
+		if errors.Is(err, http.ErrLocked) {
+			log.Printf("ジョブが他のプロセスで実行中のためスキップしました（ジョブ: %s）", job.Name)
+			return
+		}
+		log.Printf("ロック取得処理に失敗しました（ジョブ: %s）: %v", job.Name, err)
+		return
+	}
+	defer unlock()
+
+	// 同じ予定の実行が既に記録されている場合は、他のプロセスで実行済みのため実行しない
+	log.Printf("ジョブを開始しました（ジョブ: %s）", job.Name)
+	cmd := s.runner.Wrap(job.NewCommand(), &scheduledAt)
+	cmd.SetArgs(job.Args)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	// ロックが失われた場合はジョブを中断する
+	if err := cmd.ExecuteContext(lockCtx); err != nil {
+		log.Printf("ジョブが失敗しました（ジョブ: %s）: %v", job.Name, lockLost(lockCtx, err))
+		return
+	}
+	log.Printf("ジョブが終了しました（ジョブ: %s）", job.Name)
+}
+
+// NewSchedulerCmd はスケジューラー起動コマンドを生成する
+func NewSchedulerCmd(s *Scheduler) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scheduler",
+		Args:  cobra.NoArgs,
+		Short: "登録されたジョブを定期実行する",
+		Long: "登録されたジョブをcron形式のスケジュールで定期実行する常駐プロセスを起動します\n" +
+			"複数のプロセスで起動した場合も、同じ予定のジョブは1つのプロセスでのみ実行します。実行結果はjob_runsテーブルに記録します",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schedules, err := cmd.Flags().GetStringToString("schedule")
+			if err != nil {
+				return fmt.Errorf("スケジュールの指定が不正です: %w", err)
+			}
+			for name, schedule := range schedules {
+				if err := s.SetSchedule(name, schedule); err != nil {
+					return err
+				}
+			}
+			return s.Run(cmd.Context())
+		},
+		Example: "scheduler  # 登録されたジョブをデフォルトのスケジュールで実行\n" +
+			"scheduler --schedule calculate-popular-ranking=\"*/30 * * * *\"  # 30分ごとにランキングを集計",
+	}
+
+	cmd.Flags().StringToString("schedule", nil, "ジョブのスケジュールの上書き（ジョブ名=cron形式のスケジュール）")
+
+	return cmd
+}
+
+// NewJobRunsCmd はジョブ実行履歴の表示コマンドを生成する
+func NewJobRunsCmd(jobRunUsecase usecase.JobRunUsecase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "job-runs [job]",
+		Args:  cobra.RangeArgs(0, 1),
+		Short: "ジョブの実行履歴を表示する",
+		Long:  "ジョブの実行履歴を新しい順に表示します。ジョブ名を省略した場合は全てのジョブの実行履歴を表示します",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobName := ""
+			if len(args) > 0 {
+				jobName = args[0]
+			}
+
+			limit, err := cmd.Flags().GetInt("limit")
+			if err != nil {
+				return fmt.Errorf("件数の指定が不正です: %w", err)
+			}
+
+			runs, err := jobRunUsecase.GetJobRuns(cmd.Context(), jobName, limit)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("%-36s  %-28s  %-9s  %-25s  %-12s  %-8s  %-8s  %-40s  %s\n", "ID", "JOB", "STATUS", "STARTED AT", "DURATION", "ROWS", "ATTEMPTS", "PARAMS", "ERROR")
+			for _, run := range runs {
+				duration := "-"
+				if run.FinishedAt() != nil {
+					duration = run.Duration().Round(time.Millisecond).String()
+				}
+				cmd.Printf("%-36s  %-28s  %-9s  %-25s  %-12s  %-8d  %-8d  %-40s  %s\n",
+					run.ID().String(),
+					run.JobName(),
+					run.Status(),
+					run.StartedAt().Format(time.RFC3339),
+					duration,
+					run.RowsProcessed(),
+					run.Attempts(),
+					run.Params(),
+					run.ErrorMessage(),
+				)
+			}
+			return nil
+		},
+		Example: "job-runs  # 全てのジョブの実行履歴を表示\n" +
+			"job-runs calculate-popular-ranking --limit 50  # ランキング集計の実行履歴を50件表示",
+	}
+
+	cmd.Flags().Int("limit", 20, "表示する件数")
+
+	return cmd
+}
@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
 
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/reaction"
 	"myblog/app/ui/http/middleware/auth"
 	"myblog/app/usecase"
 
@@ -13,13 +16,19 @@ import (
 
 // BlogHandler : ブログハンドラー
 type BlogHandler struct {
-	blogUsecase usecase.BlogUsecase
+	blogUsecase     usecase.BlogUsecase
+	mentionUsecase  usecase.MentionUsecase
+	reactionUsecase usecase.ReactionUsecase
+	likeUsecase     usecase.LikeUsecase
 }
 
 // NewBlogHandler : BlogHandlerの生成
-func NewBlogHandler(blogUsecase usecase.BlogUsecase) *BlogHandler {
+func NewBlogHandler(blogUsecase usecase.BlogUsecase, mentionUsecase usecase.MentionUsecase, reactionUsecase usecase.ReactionUsecase, likeUsecase usecase.LikeUsecase) *BlogHandler {
 	return &BlogHandler{
-		blogUsecase: blogUsecase,
+		blogUsecase:     blogUsecase,
+		mentionUsecase:  mentionUsecase,
+		reactionUsecase: reactionUsecase,
+		likeUsecase:     likeUsecase,
 	}
 }
 
@@ -37,12 +46,75 @@ type UpdateBlogRequest struct {
 
 // BlogResponse : ブログレスポンス
 type BlogResponse struct {
-	ID        string `json:"id"`
-	UserID    string `json:"user_id"`
-	Title     string `json:"title"`
-	Content   string `json:"content"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID          string            `json:"id"`
+	UserID      string            `json:"user_id"`
+	Title       string            `json:"title"`
+	Content     string            `json:"content"`
+	ContentHTML string            `json:"content_html"`
+	Mentions    []MentionResponse `json:"mentions"`
+	Reactions   ReactionsResponse `json:"reactions"`
+	LikeCount   int               `json:"like_count"`
+	Liked       bool              `json:"liked"`
+	CreatedAt   string            `json:"created_at"`
+	UpdatedAt   string            `json:"updated_at"`
+}
+
+// newBlogResponse : ブログをメンション情報付きのレスポンス形式に変換
+func (h *BlogHandler) newBlogResponse(ctx context.Context, b *blog.Blog) (*BlogResponse, error) {
+	resp, err := h.newBlogResponses(ctx, []*blog.Blog{b})
+	if err != nil {
+		return nil, err
+	}
+	return &resp[0], nil
+}
+
+// newBlogResponses : ブログ一覧をメンション情報付きのレスポンス形式に変換
+func (h *BlogHandler) newBlogResponses(ctx context.Context, blogs []*blog.Blog) ([]BlogResponse, error) {
+	blogIDs := make([]blog.ID, len(blogs))
+	for i, blog := range blogs {
+		blogIDs[i] = blog.ID()
+	}
+
+	mentions, err := h.mentionUsecase.GetBlogMentions(ctx, blogIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	targetIDs := make([]string, len(blogs))
+	for i, blog := range blogs {
+		targetIDs[i] = blog.ID().String()
+	}
+
+	viewerID, _ := auth.ExtractUserID(ctx)
+	reactions, err := h.reactionUsecase.GetSummaries(ctx, reaction.TargetBlog, targetIDs, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	likes, err := h.likeUsecase.GetSummaries(ctx, blogIDs, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []BlogResponse
+	for _, blog := range blogs {
+		blogMentions := mentions[blog.ID().String()]
+		blogLikes := newLikeResponse(likes[blog.ID().String()])
+		resp = append(resp, BlogResponse{
+			ID:          blog.ID().String(),
+			UserID:      blog.UserID().String(),
+			Title:       blog.Title(),
+			Content:     blog.Content(),
+			ContentHTML: renderContent(blog.Content(), blogMentions),
+			Mentions:    newMentionResponses(blogMentions),
+			Reactions:   newReactionsResponse(reactions[blog.ID().String()]),
+			LikeCount:   blogLikes.LikeCount,
+			Liked:       blogLikes.Liked,
+			CreatedAt:   blog.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
+			UpdatedAt:   blog.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+	return resp, nil
 }
 
 // CreateBlog : ブログ作成
@@ -66,13 +138,10 @@ func (h *BlogHandler) CreateBlog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := BlogResponse{
-		ID:        blog.ID().String(),
-		UserID:    blog.UserID().String(),
-		Title:     blog.Title(),
-		Content:   blog.Content(),
-		CreatedAt: blog.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: blog.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+	resp, err := h.newBlogResponse(r.Context(), blog)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -88,19 +157,16 @@ func (h *BlogHandler) GetBlog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	blog, err := h.blogUsecase.GetBlogByID(r.Context(), id)
+	blog, err := h.blogUsecase.ViewBlog(r.Context(), id, newViewer(r))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
 
-	resp := BlogResponse{
-		ID:        blog.ID().String(),
-		UserID:    blog.UserID().String(),
-		Title:     blog.Title(),
-		Content:   blog.Content(),
-		CreatedAt: blog.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: blog.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+	resp, err := h.newBlogResponse(r.Context(), blog)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -137,16 +203,10 @@ func (h *BlogHandler) GetAllBlogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var resp []BlogResponse
-	for _, blog := range blogs {
-		resp = append(resp, BlogResponse{
-			ID:        blog.ID().String(),
-			UserID:    blog.UserID().String(),
-			Title:     blog.Title(),
-			Content:   blog.Content(),
-			CreatedAt: blog.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt: blog.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		})
+	resp, err := h.newBlogResponses(r.Context(), blogs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -168,16 +228,10 @@ func (h *BlogHandler) GetUserBlogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var resp []BlogResponse
-	for _, blog := range blogs {
-		resp = append(resp, BlogResponse{
-			ID:        blog.ID().String(),
-			UserID:    blog.UserID().String(),
-			Title:     blog.Title(),
-			Content:   blog.Content(),
-			CreatedAt: blog.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt: blog.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		})
+	resp, err := h.newBlogResponses(r.Context(), blogs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -212,13 +266,10 @@ func (h *BlogHandler) UpdateBlog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := BlogResponse{
-		ID:        blog.ID().String(),
-		UserID:    blog.UserID().String(),
-		Title:     blog.Title(),
-		Content:   blog.Content(),
-		CreatedAt: blog.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: blog.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+	resp, err := h.newBlogResponse(r.Context(), blog)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
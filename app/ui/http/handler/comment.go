@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/reaction"
 	"myblog/app/ui/http/middleware/auth"
 	"myblog/app/usecase"
 
@@ -12,13 +15,17 @@ import (
 
 // CommentHandler : コメントハンドラー
 type CommentHandler struct {
-	commentUsecase usecase.CommentUsecase
+	commentUsecase  usecase.CommentUsecase
+	mentionUsecase  usecase.MentionUsecase
+	reactionUsecase usecase.ReactionUsecase
 }
 
 // NewCommentHandler : CommentHandlerの生成
-func NewCommentHandler(commentUsecase usecase.CommentUsecase) *CommentHandler {
+func NewCommentHandler(commentUsecase usecase.CommentUsecase, mentionUsecase usecase.MentionUsecase, reactionUsecase usecase.ReactionUsecase) *CommentHandler {
 	return &CommentHandler{
-		commentUsecase: commentUsecase,
+		commentUsecase:  commentUsecase,
+		mentionUsecase:  mentionUsecase,
+		reactionUsecase: reactionUsecase,
 	}
 }
 
@@ -34,12 +41,65 @@ type UpdateCommentRequest struct {
 
 // CommentResponse : コメントレスポンス
 type CommentResponse struct {
-	ID        string `json:"id"`
-	BlogID    string `json:"blog_id"`
-	UserID    string `json:"user_id"`
-	Content   string `json:"content"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID          string            `json:"id"`
+	BlogID      string            `json:"blog_id"`
+	UserID      string            `json:"user_id"`
+	Content     string            `json:"content"`
+	ContentHTML string            `json:"content_html"`
+	Mentions    []MentionResponse `json:"mentions"`
+	Reactions   ReactionsResponse `json:"reactions"`
+	CreatedAt   string            `json:"created_at"`
+	UpdatedAt   string            `json:"updated_at"`
+}
+
+// newCommentResponse : コメントをメンション情報付きのレスポンス形式に変換
+func (h *CommentHandler) newCommentResponse(ctx context.Context, c *comment.Comment) (*CommentResponse, error) {
+	resp, err := h.newCommentResponses(ctx, []*comment.Comment{c})
+	if err != nil {
+		return nil, err
+	}
+	return &resp[0], nil
+}
+
+// newCommentResponses : コメント一覧をメンション情報付きのレスポンス形式に変換
+func (h *CommentHandler) newCommentResponses(ctx context.Context, comments []*comment.Comment) ([]CommentResponse, error) {
+	commentIDs := make([]comment.ID, len(comments))
+	for i, comment := range comments {
+		commentIDs[i] = comment.ID()
+	}
+
+	mentions, err := h.mentionUsecase.GetCommentMentions(ctx, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	targetIDs := make([]string, len(comments))
+	for i, comment := range comments {
+		targetIDs[i] = comment.ID().String()
+	}
+
+	viewerID, _ := auth.ExtractUserID(ctx)
+	reactions, err := h.reactionUsecase.GetSummaries(ctx, reaction.TargetComment, targetIDs, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []CommentResponse
+	for _, comment := range comments {
+		commentMentions := mentions[comment.ID().String()]
+		resp = append(resp, CommentResponse{
+			ID:          comment.ID().String(),
+			BlogID:      comment.BlogID().String(),
+			UserID:      comment.UserID().String(),
+			Content:     comment.Content(),
+			ContentHTML: renderContent(comment.Content(), commentMentions),
+			Mentions:    newMentionResponses(commentMentions),
+			Reactions:   newReactionsResponse(reactions[comment.ID().String()]),
+			CreatedAt:   comment.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
+			UpdatedAt:   comment.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+	return resp, nil
 }
 
 // CreateComment : コメント作成
@@ -69,13 +129,10 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := CommentResponse{
-		ID:        comment.ID().String(),
-		BlogID:    comment.BlogID().String(),
-		UserID:    comment.UserID().String(),
-		Content:   comment.Content(),
-		CreatedAt: comment.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: comment.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+	resp, err := h.newCommentResponse(r.Context(), comment)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -97,16 +154,10 @@ func (h *CommentHandler) GetBlogComments(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var resp []CommentResponse
-	for _, comment := range comments {
-		resp = append(resp, CommentResponse{
-			ID:        comment.ID().String(),
-			BlogID:    comment.BlogID().String(),
-			UserID:    comment.UserID().String(),
-			Content:   comment.Content(),
-			CreatedAt: comment.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt: comment.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		})
+	resp, err := h.newCommentResponses(r.Context(), comments)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -141,13 +192,10 @@ func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := CommentResponse{
-		ID:        comment.ID().String(),
-		BlogID:    comment.BlogID().String(),
-		UserID:    comment.UserID().String(),
-		Content:   comment.Content(),
-		CreatedAt: comment.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: comment.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+	resp, err := h.newCommentResponse(r.Context(), comment)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
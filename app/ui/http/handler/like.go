@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"myblog/app/domain/model/like"
+	"myblog/app/ui/http/middleware/auth"
+	"myblog/app/usecase"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// LikeHandler : いいねハンドラー
+type LikeHandler struct {
+	likeUsecase usecase.LikeUsecase
+}
+
+// NewLikeHandler : LikeHandlerの生成
+func NewLikeHandler(likeUsecase usecase.LikeUsecase) *LikeHandler {
+	return &LikeHandler{
+		likeUsecase: likeUsecase,
+	}
+}
+
+// LikeResponse : いいねレスポンス
+type LikeResponse struct {
+	LikeCount int  `json:"like_count"`
+	Liked     bool `json:"liked"`
+}
+
+// newLikeResponse : いいね集計をレスポンス形式に変換
+func newLikeResponse(summary *like.Summary) LikeResponse {
+	if summary == nil {
+		return LikeResponse{}
+	}
+	return LikeResponse{
+		LikeCount: summary.Count,
+		Liked:     summary.Liked,
+	}
+}
+
+// LikeBlog : ブログへのいいね
+func (h *LikeHandler) LikeBlog(w http.ResponseWriter, r *http.Request) {
+	blogID := chi.URLParam(r, "id")
+	if blogID == "" {
+		http.Error(w, "Blog ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	summary, err := h.likeUsecase.LikeBlog(r.Context(), blogID, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newLikeResponse(summary))
+}
+
+// UnlikeBlog : ブログへのいいねの取り消し
+func (h *LikeHandler) UnlikeBlog(w http.ResponseWriter, r *http.Request) {
+	blogID := chi.URLParam(r, "id")
+	if blogID == "" {
+		http.Error(w, "Blog ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	summary, err := h.likeUsecase.UnlikeBlog(r.Context(), blogID, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newLikeResponse(summary))
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"myblog/app/domain/model/mention"
+)
+
+// profilePathFormat : ユーザープロフィールページのパス
+const profilePathFormat = "/users/%s"
+
+// MentionResponse : メンションレスポンス
+type MentionResponse struct {
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	ProfileURL string `json:"profile_url"`
+}
+
+// newMentionResponses : メンションをレスポンス形式に変換
+func newMentionResponses(mentions []*mention.Mention) []MentionResponse {
+	resp := make([]MentionResponse, 0, len(mentions))
+	for _, m := range mentions {
+		resp = append(resp, MentionResponse{
+			UserID:     m.UserID().String(),
+			Username:   m.Username(),
+			ProfileURL: fmt.Sprintf(profilePathFormat, m.UserID().String()),
+		})
+	}
+	return resp
+}
+
+// renderContent : 本文をHTMLエスケープし、解決済みのメンションをプロフィールへのリンクに置き換える
+func renderContent(content string, mentions []*mention.Mention) string {
+	resolved := make(map[string]*mention.Mention, len(mentions))
+	for _, m := range mentions {
+		resolved[strings.ToLower(m.Username())] = m
+	}
+
+	var b strings.Builder
+	for _, segment := range mention.Split(content) {
+		m, ok := resolved[strings.ToLower(segment.Username)]
+		if segment.Username == "" || !ok {
+			b.WriteString(html.EscapeString(segment.Text))
+			continue
+		}
+		fmt.Fprintf(&b, `<a href="%s">%s</a>`,
+			html.EscapeString(fmt.Sprintf(profilePathFormat, m.UserID().String())),
+			html.EscapeString(segment.Text),
+		)
+	}
+	return b.String()
+}
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"myblog/app/domain/model/notification"
+	"myblog/app/ui/http/middleware/auth"
+	"myblog/app/usecase"
+)
+
+// NotificationHandler : 通知ハンドラー
+type NotificationHandler struct {
+	notificationUsecase usecase.NotificationUsecase
+}
+
+// NewNotificationHandler : NotificationHandlerの生成
+func NewNotificationHandler(notificationUsecase usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUsecase: notificationUsecase,
+	}
+}
+
+// NotificationResponse : 通知レスポンス
+type NotificationResponse struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	ActorID   string  `json:"actor_id"`
+	BlogID    string  `json:"blog_id"`
+	CommentID *string `json:"comment_id"`
+	ReadAt    *string `json:"read_at"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// NotificationListResponse : 通知一覧レスポンス
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	NextCursor    *string                `json:"next_cursor"`
+	UnreadCount   int                    `json:"unread_count"`
+}
+
+// UnreadCountResponse : 未読件数レスポンス
+type UnreadCountResponse struct {
+	UnreadCount int `json:"unread_count"`
+}
+
+// MarkReadRequest : 既読化リクエスト
+type MarkReadRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// NotificationPreferencesResponse : 通知設定レスポンス（通知種別ごとに受け取るかどうか）
+type NotificationPreferencesResponse map[string]bool
+
+// newNotificationResponse : 通知をレスポンス形式に変換
+func newNotificationResponse(n *notification.Notification) NotificationResponse {
+	item := NotificationResponse{
+		ID:        n.ID().String(),
+		Type:      string(n.Type()),
+		ActorID:   n.ActorID().String(),
+		BlogID:    n.BlogID().String(),
+		CreatedAt: n.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if n.CommentID() != nil {
+		commentID := n.CommentID().String()
+		item.CommentID = &commentID
+	}
+	if n.ReadAt() != nil {
+		readAt := n.ReadAt().Format("2006-01-02T15:04:05Z07:00")
+		item.ReadAt = &readAt
+	}
+	return item
+}
+
+// newNotificationPreferencesResponse : 通知設定をレスポンス形式に変換
+func newNotificationPreferencesResponse(preferences *notification.Preferences) NotificationPreferencesResponse {
+	resp := NotificationPreferencesResponse{}
+	for t, enabled := range preferences.All() {
+		resp[string(t)] = enabled
+	}
+	return resp
+}
+
+// GetNotifications : 自分宛ての通知一覧取得（cursorで次のページを取得する）
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	page, err := h.notificationUsecase.GetNotifications(r.Context(), userID, r.URL.Query().Get("cursor"), unreadOnly, limit)
+	if err != nil {
+		if errors.Is(err, notification.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := NotificationListResponse{
+		Notifications: []NotificationResponse{},
+		UnreadCount:   page.UnreadCount,
+	}
+	for _, notification := range page.Notifications {
+		resp.Notifications = append(resp.Notifications, newNotificationResponse(notification))
+	}
+	if page.NextCursor != "" {
+		resp.NextCursor = &page.NextCursor
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetUnreadCount : 自分宛ての未読の通知の件数取得
+func (h *NotificationHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	count, err := h.notificationUsecase.GetUnreadCount(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(UnreadCountResponse{UnreadCount: count})
+}
+
+// MarkRead : 指定した通知の既読化
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req MarkReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	count, err := h.notificationUsecase.MarkRead(r.Context(), userID, req.IDs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(UnreadCountResponse{UnreadCount: count})
+}
+
+// MarkAllRead : 自分宛ての全ての通知の既読化
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.notificationUsecase.MarkAllRead(r.Context(), userID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(UnreadCountResponse{UnreadCount: 0})
+}
+
+// GetPreferences : 自分の通知設定取得
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	preferences, err := h.notificationUsecase.GetPreferences(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newNotificationPreferencesResponse(preferences))
+}
+
+// UpdatePreferences : 自分の通知設定の更新（指定した通知種別のみ変更する）
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req map[string]bool
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	preferences, err := h.notificationUsecase.UpdatePreferences(r.Context(), userID, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newNotificationPreferencesResponse(preferences))
+}
@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"myblog/app/domain/model/ranking"
+	"myblog/app/infra/query"
+	"myblog/app/usecase"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RankingHandler : ランキングハンドラー
+type RankingHandler struct {
+	rankingUseCase *usecase.RankingUseCase
+}
+
+// NewRankingHandler : RankingHandlerの生成
+func NewRankingHandler(rankingUseCase *usecase.RankingUseCase) *RankingHandler {
+	return &RankingHandler{
+		rankingUseCase: rankingUseCase,
+	}
+}
+
+// RankedBlogSummaryResponse : ランキング上のブログ概要レスポンス
+type RankedBlogSummaryResponse struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Excerpt    string `json:"excerpt"`
+	UserID     string `json:"user_id"`
+	AuthorName string `json:"author_name"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// RankingEntryResponse : ランキング項目レスポンス
+type RankingEntryResponse struct {
+	Position         int                       `json:"position"`
+	PreviousPosition *int                      `json:"previous_position"`
+	Movement         *int                      `json:"movement"`
+	Score            float64                   `json:"score"`
+	Blog             RankedBlogSummaryResponse `json:"blog"`
+}
+
+// RankingResponse : ランキングレスポンス
+type RankingResponse struct {
+	Type         string                 `json:"type"`
+	CalculatedAt *string                `json:"calculated_at"`
+	Rankings     []RankingEntryResponse `json:"rankings"`
+}
+
+// RankedAuthorSummaryResponse : ランキング上の著者概要レスポンス
+type RankedAuthorSummaryResponse struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// AuthorRankingEntryResponse : 著者ランキング項目レスポンス
+type AuthorRankingEntryResponse struct {
+	Position         int                         `json:"position"`
+	PreviousPosition *int                        `json:"previous_position"`
+	Movement         *int                        `json:"movement"`
+	Score            float64                     `json:"score"`
+	BlogCount        int                         `json:"blog_count"`
+	User             RankedAuthorSummaryResponse `json:"user"`
+}
+
+// AuthorRankingResponse : 著者ランキングレスポンス
+type AuthorRankingResponse struct {
+	Type         string                       `json:"type"`
+	CalculatedAt *string                      `json:"calculated_at"`
+	Rankings     []AuthorRankingEntryResponse `json:"rankings"`
+}
+
+// RankingHistoryEntryResponse : ランキング履歴項目レスポンス
+type RankingHistoryEntryResponse struct {
+	CalculatedAt string  `json:"calculated_at"`
+	Position     int     `json:"position"`
+	Score        float64 `json:"score"`
+}
+
+// RankingHistoryResponse : ランキング履歴レスポンス
+type RankingHistoryResponse struct {
+	BlogID  string                        `json:"blog_id"`
+	Type    string                        `json:"type"`
+	History []RankingHistoryEntryResponse `json:"history"`
+}
+
+// parseRankingType : クエリパラメータからランキング種別を取得（省略時は週間）
+func parseRankingType(r *http.Request) (ranking.Type, error) {
+	typeStr := r.URL.Query().Get("type")
+	if typeStr == "" {
+		return ranking.TypeWeekly, nil
+	}
+	return ranking.ParseType(typeStr)
+}
+
+// parseRankingLimit : クエリパラメータから取得件数を取得（省略時は10件）
+func parseRankingLimit(r *http.Request) int {
+	limit := 10
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+	return limit
+}
+
+// newRankingResponse : ランキングをレスポンス形式に変換
+func newRankingResponse(popularRanking *query.PopularRanking) RankingResponse {
+	resp := RankingResponse{Type: string(popularRanking.Type), Rankings: []RankingEntryResponse{}}
+	if popularRanking.CalculatedAt != nil {
+		calculatedAt := popularRanking.CalculatedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.CalculatedAt = &calculatedAt
+	}
+	for _, blog := range popularRanking.Blogs {
+		resp.Rankings = append(resp.Rankings, RankingEntryResponse{
+			Position:         blog.Position,
+			PreviousPosition: blog.PreviousPosition,
+			Movement:         blog.Movement(),
+			Score:            blog.Score,
+			Blog: RankedBlogSummaryResponse{
+				ID:         blog.BlogID,
+				Title:      blog.Title,
+				Excerpt:    blog.Excerpt,
+				UserID:     blog.UserID,
+				AuthorName: blog.AuthorName,
+				CreatedAt:  blog.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			},
+		})
+	}
+	return resp
+}
+
+// GetRankings : 人気記事ランキング取得
+func (h *RankingHandler) GetRankings(w http.ResponseWriter, r *http.Request) {
+	rankingType, err := parseRankingType(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	popularRanking, err := h.rankingUseCase.GetPopularRanking(r.Context(), rankingType, parseRankingLimit(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newRankingResponse(popularRanking))
+}
+
+// GetUserRankings : ユーザーの人気記事ランキング取得（順位はユーザーの記事の中での順位）
+func (h *RankingHandler) GetUserRankings(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	rankingType, err := parseRankingType(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	popularRanking, err := h.rankingUseCase.GetAuthorBlogRanking(r.Context(), userID, rankingType, parseRankingLimit(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newRankingResponse(popularRanking))
+}
+
+// GetAuthorRankings : 著者ランキング取得
+func (h *RankingHandler) GetAuthorRankings(w http.ResponseWriter, r *http.Request) {
+	rankingType, err := parseRankingType(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	authorRanking, err := h.rankingUseCase.GetAuthorRanking(r.Context(), rankingType, parseRankingLimit(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := AuthorRankingResponse{Type: string(rankingType), Rankings: []AuthorRankingEntryResponse{}}
+	if authorRanking.CalculatedAt != nil {
+		calculatedAt := authorRanking.CalculatedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.CalculatedAt = &calculatedAt
+	}
+	for _, author := range authorRanking.Authors {
+		resp.Rankings = append(resp.Rankings, AuthorRankingEntryResponse{
+			Position:         author.Position,
+			PreviousPosition: author.PreviousPosition,
+			Movement:         author.Movement(),
+			Score:            author.Score,
+			BlogCount:        author.BlogCount,
+			User: RankedAuthorSummaryResponse{
+				ID:       author.UserID,
+				Username: author.Username,
+			},
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetBlogRankingHistory : ブログのランキング順位の推移取得
+func (h *RankingHandler) GetBlogRankingHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "Blog ID is required", http.StatusBadRequest)
+		return
+	}
+
+	rankingType, err := parseRankingType(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	days := 30
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		d, err := strconv.Atoi(daysStr)
+		if err == nil && d > 0 && d <= 365 {
+			days = d
+		}
+	}
+
+	history, err := h.rankingUseCase.GetBlogRankingHistory(r.Context(), id, rankingType, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := RankingHistoryResponse{
+		BlogID:  id,
+		Type:    string(rankingType),
+		History: []RankingHistoryEntryResponse{},
+	}
+	for _, point := range history {
+		resp.History = append(resp.History, RankingHistoryEntryResponse{
+			CalculatedAt: point.CalculatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Position:     point.Position,
+			Score:        point.Score,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"myblog/app/domain/model/reaction"
+	"myblog/app/ui/http/middleware/auth"
+	"myblog/app/usecase"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ReactionHandler : リアクションハンドラー
+type ReactionHandler struct {
+	reactionUsecase usecase.ReactionUsecase
+}
+
+// NewReactionHandler : ReactionHandlerの生成
+func NewReactionHandler(reactionUsecase usecase.ReactionUsecase) *ReactionHandler {
+	return &ReactionHandler{
+		reactionUsecase: reactionUsecase,
+	}
+}
+
+// ReactionRequest : リアクション追加リクエスト
+type ReactionRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+// ReactionsResponse : リアクション集計レスポンス
+type ReactionsResponse struct {
+	Counts map[string]int `json:"counts"`
+	Mine   *string        `json:"mine"`
+}
+
+// newReactionsResponse : リアクション集計をレスポンス形式に変換
+func newReactionsResponse(summary *reaction.Summary) ReactionsResponse {
+	if summary == nil {
+		summary = reaction.NewSummary()
+	}
+
+	resp := ReactionsResponse{Counts: summary.Counts}
+	if summary.Mine != "" {
+		mine := summary.Mine
+		resp.Mine = &mine
+	}
+	return resp
+}
+
+// GetEmojis : 利用可能な絵文字一覧取得
+func (h *ReactionHandler) GetEmojis(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string][]string{"emojis": h.reactionUsecase.AvailableEmojis()})
+}
+
+// AddBlogReaction : ブログへのリアクション追加
+func (h *ReactionHandler) AddBlogReaction(w http.ResponseWriter, r *http.Request) {
+	h.addReaction(w, r, reaction.TargetBlog)
+}
+
+// RemoveBlogReaction : ブログへのリアクション削除
+func (h *ReactionHandler) RemoveBlogReaction(w http.ResponseWriter, r *http.Request) {
+	h.removeReaction(w, r, reaction.TargetBlog)
+}
+
+// AddCommentReaction : コメントへのリアクション追加
+func (h *ReactionHandler) AddCommentReaction(w http.ResponseWriter, r *http.Request) {
+	h.addReaction(w, r, reaction.TargetComment)
+}
+
+// RemoveCommentReaction : コメントへのリアクション削除
+func (h *ReactionHandler) RemoveCommentReaction(w http.ResponseWriter, r *http.Request) {
+	h.removeReaction(w, r, reaction.TargetComment)
+}
+
+// addReaction : リアクション追加（同じリクエストを繰り返しても結果は変わらない）
+func (h *ReactionHandler) addReaction(w http.ResponseWriter, r *http.Request, targetType reaction.TargetType) {
+	targetID := chi.URLParam(r, "id")
+	if targetID == "" {
+		http.Error(w, "Target ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req ReactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.reactionUsecase.AddReaction(r.Context(), targetType, targetID, userID, req.Emoji)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newReactionsResponse(summary))
+}
+
+// removeReaction : リアクション削除（未リアクションの場合も成功とする）
+func (h *ReactionHandler) removeReaction(w http.ResponseWriter, r *http.Request, targetType reaction.TargetType) {
+	targetID := chi.URLParam(r, "id")
+	if targetID == "" {
+		http.Error(w, "Target ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	summary, err := h.reactionUsecase.RemoveReaction(r.Context(), targetType, targetID, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newReactionsResponse(summary))
+}
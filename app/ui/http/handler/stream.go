@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"myblog/app/domain/model/comment"
+	"myblog/app/ui/http/middleware/auth"
+	"myblog/app/usecase"
+)
+
+// maxStreamBlogs : 1つの接続で購読できるブログの最大数
+const maxStreamBlogs = 50
+
+// StreamHandler : Server-Sent Eventsによるストリーミング配信ハンドラー
+type StreamHandler struct {
+	hub       *usecase.StreamHub
+	tickets   *auth.Tickets
+	heartbeat time.Duration
+}
+
+// NewStreamHandler : StreamHandlerの生成
+// heartbeatの間隔で接続維持のためのコメントを送信する
+func NewStreamHandler(hub *usecase.StreamHub, tickets *auth.Tickets, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{
+		hub:       hub,
+		tickets:   tickets,
+		heartbeat: heartbeat,
+	}
+}
+
+// StreamTicketResponse : ストリーミング配信の接続用チケットのレスポンス
+type StreamTicketResponse struct {
+	Ticket    string `json:"ticket"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// IssueTicket : ストリーミング配信の接続用チケットの発行（有効期間内は再接続にも使用できる）
+func (h *StreamHandler) IssueTicket(w http.ResponseWriter, r *http.Request) {
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ticket, expiresAt, err := h.tickets.Issue(userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(StreamTicketResponse{
+		Ticket:    ticket,
+		ExpiresAt: expiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
+
+// StreamCommentResponse : コメント投稿イベントのレスポンス
+type StreamCommentResponse struct {
+	ID        string `json:"id"`
+	BlogID    string `json:"blog_id"`
+	UserID    string `json:"user_id"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Stream : 購読したブログへのコメントと自分のブログへのコメントのストリーミング配信
+// クライアントが切断するか、サーバーが停止するまで接続を維持する
+func (h *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	// 認証済みユーザーIDの取得
+	userID, ok := auth.ExtractUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	var blogIDs []string
+	if blogsStr := r.URL.Query().Get("blogs"); blogsStr != "" {
+		for _, blogID := range strings.Split(blogsStr, ",") {
+			if blogID = strings.TrimSpace(blogID); blogID != "" {
+				blogIDs = append(blogIDs, blogID)
+			}
+		}
+	}
+	if len(blogIDs) > maxStreamBlogs {
+		http.Error(w, fmt.Sprintf("購読できるブログは%d件までです", maxStreamBlogs), http.StatusBadRequest)
+		return
+	}
+
+	// 再接続時はブラウザがLast-Event-IDヘッダーを送信する（初回接続時はクエリパラメータでも指定できる）
+	lastEventIDStr := r.Header.Get("Last-Event-ID")
+	if lastEventIDStr == "" {
+		lastEventIDStr = r.URL.Query().Get("last_event_id")
+	}
+	var lastEventID int64
+	if lastEventIDStr != "" {
+		id, err := strconv.ParseInt(lastEventIDStr, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+		lastEventID = id
+	}
+
+	subscription, missed, err := h.hub.Subscribe(userID, blogIDs, lastEventID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrStreamClosed) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer h.hub.Unsubscribe(subscription)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// 切断時の再接続までの待機時間（ミリ秒）
+	fmt.Fprint(w, "retry: 3000\n\n")
+	for _, e := range missed {
+		if err := writeStreamEvent(w, e); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			// クライアントが切断した
+			return
+		case e, ok := <-subscription.Events():
+			if !ok {
+				// サーバーの停止または受信の遅延により購読が解除された
+				return
+			}
+			if err := writeStreamEvent(w, e); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeStreamEvent : イベントをServer-Sent Eventsの形式で書き込む
+func writeStreamEvent(w http.ResponseWriter, e usecase.StreamEvent) error {
+	var data interface{}
+	switch payload := e.Payload.(type) {
+	case *comment.Comment:
+		data = StreamCommentResponse{
+			ID:        payload.ID().String(),
+			BlogID:    payload.BlogID().String(),
+			UserID:    payload.UserID().String(),
+			Content:   payload.Content(),
+			CreatedAt: payload.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
+		}
+	default:
+		data = payload
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, body)
+	return err
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+
+	"myblog/app/ui/http/middleware/auth"
+	"myblog/app/usecase"
+)
+
+// botUserAgentKeywords : クローラー等の自動アクセスとみなすUser-Agentのキーワード
+var botUserAgentKeywords = []string{
+	"bot", "crawler", "spider", "slurp", "preview", "headless",
+	"curl", "wget", "python-requests", "go-http-client", "httpclient",
+}
+
+// isBot : User-Agentからクローラー等の自動アクセスかどうかを判定
+func isBot(userAgent string) bool {
+	if userAgent == "" {
+		return true
+	}
+
+	ua := strings.ToLower(userAgent)
+	for _, keyword := range botUserAgentKeywords {
+		if strings.Contains(ua, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// newViewer : リクエストから閲覧者を生成
+// ログインユーザーはユーザーID、匿名の閲覧者はIPアドレスとUser-Agentのハッシュで識別する
+func newViewer(r *http.Request) usecase.Viewer {
+	userAgent := r.UserAgent()
+	viewer := usecase.Viewer{IsBot: isBot(userAgent)}
+
+	if userID, ok := auth.ExtractUserID(r.Context()); ok && userID != "" {
+		viewer.UserID = userID
+		viewer.Key = "user:" + userID
+		return viewer
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	sum := sha256.Sum256([]byte(host + "\x00" + userAgent))
+	viewer.Key = "anon:" + hex.EncodeToString(sum[:])
+	return viewer
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// ticketAudience : チケットの用途（ログイン時のJWTと区別するために検証する）
+const ticketAudience = "stream"
+
+// Tickets : ストリーミング配信の接続用のチケット
+// ヘッダーを設定できないEventSourceなどのクライアントが、JWTをURLに含めずに認証するために使用する
+// チケットはユーザーIDと用途と有効期限をJWTの秘密鍵で署名したもので、サーバーには保持しない
+// そのため有効期間内は再接続に何度でも使用でき、どのAPIプロセスでも検証できる
+type Tickets struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewTickets : Ticketsの生成
+func NewTickets(jwtSecret string, ttl time.Duration) *Tickets {
+	return &Tickets{
+		secret: []byte(jwtSecret),
+		ttl:    ttl,
+	}
+}
+
+// Issue : チケットの発行
+func (t *Tickets) Issue(userID string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(t.ttl)
+
+	// ログイン時のJWTのidクレームを含めないため、チケットをAuthorizationヘッダーで使用することはできない
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   userID,
+		Audience:  jwt.ClaimStrings{ticketAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	})
+	value, err := token.SignedString(t.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("チケット生成エラー: %w", err)
+	}
+	return value, expiresAt, nil
+}
+
+// Verify : チケットの検証（有効な場合は発行したユーザーのIDを返す）
+func (t *Tickets) Verify(value string) (string, bool) {
+	var claims jwt.RegisteredClaims
+	token, err := jwt.ParseWithClaims(value, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("無効な署名方式です")
+		}
+		return t.secret, nil
+	})
+	if err != nil || !token.Valid {
+		return "", false
+	}
+	// 有効期限のないトークンとログイン時のJWTは受け付けない
+	if claims.ExpiresAt == nil || !claims.VerifyAudience(ticketAudience, true) || claims.Subject == "" {
+		return "", false
+	}
+	return claims.Subject, true
+}
+
+// TicketOrJWT : クエリパラメータのチケットまたはAuthorizationヘッダーのJWTによる認証ミドルウェア
+// Authorizationヘッダーがある場合はJWTMiddlewareと同じ
+func TicketOrJWT(tickets *Tickets, param string, jwtSecret string) func(next http.Handler) http.Handler {
+	jwtMiddleware := JWTMiddleware(jwtSecret)
+	return func(next http.Handler) http.Handler {
+		withJWT := jwtMiddleware(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := r.URL.Query().Get(param)
+			if value == "" || r.Header.Get("Authorization") != "" {
+				withJWT.ServeHTTP(w, r)
+				return
+			}
+
+			userID, ok := tickets.Verify(value)
+			if !ok {
+				http.Error(w, "無効なチケットです", http.StatusUnauthorized)
+				return
+			}
+
+			// ユーザーIDをコンテキストに設定
+			ctx := context.WithValue(r.Context(), userIDContextKey, userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
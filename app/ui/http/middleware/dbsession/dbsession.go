@@ -0,0 +1,19 @@
+package dbsession
+
+import (
+	"net/http"
+
+	"myblog/app/infra/db/rdb"
+	"myblog/app/ui/http/middleware/auth"
+)
+
+// ReadYourWrites : 書き込み後の読み取りをプライマリに振り分けるためのセッションを設定するミドルウェア
+// 認証済みの場合はユーザーIDをキーとし、同じユーザーの後続のリクエストの読み取りも一定期間プライマリで行う
+// 認証ミドルウェアの後に適用する（未認証の場合はリクエスト内のみ）
+func ReadYourWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, _ := auth.ExtractUserID(r.Context())
+		ctx := rdb.WithSession(r.Context(), userID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
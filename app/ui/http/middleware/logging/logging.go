@@ -0,0 +1,54 @@
+package logging
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RedactedValue : ログに出力しないクエリパラメータの値の置き換え
+const RedactedValue = "REDACTED"
+
+// RedactQuery : アクセスログに出力するURI（RequestURI）からクエリパラメータの値を伏せるミドルウェア
+// アクセスログのミドルウェアより前に適用する。ハンドラーが参照するr.URLは変更しない
+func RedactQuery(params ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if redacted, ok := redactURI(r.RequestURI, params); ok {
+				r2 := *r
+				r2.RequestURI = redacted
+				r = &r2
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// redactURI : URIのクエリパラメータの値の置き換え（置き換えた場合はtrueを返す）
+func redactURI(uri string, params []string) (string, bool) {
+	path, rawQuery, found := strings.Cut(uri, "?")
+	if !found {
+		return uri, false
+	}
+
+	redacted := false
+	pairs := strings.Split(rawQuery, "&")
+	for i, pair := range pairs {
+		key, _, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			continue
+		}
+		for _, param := range params {
+			if name == param {
+				pairs[i] = key + "=" + RedactedValue
+				redacted = true
+				break
+			}
+		}
+	}
+	if !redacted {
+		return uri, false
+	}
+	return path + "?" + strings.Join(pairs, "&"), true
+}
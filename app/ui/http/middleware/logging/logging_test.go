@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedactQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		want string
+	}{
+		{name: "クエリなし", uri: "/api/stream", want: "/api/stream"},
+		{name: "対象外のパラメータ", uri: "/api/stream?blogs=1", want: "/api/stream?blogs=1"},
+		{name: "チケット", uri: "/api/stream?blogs=1&ticket=abc", want: "/api/stream?blogs=1&ticket=REDACTED"},
+		{name: "アクセストークン", uri: "/api/stream?access_token=x.y.z&blogs=1", want: "/api/stream?access_token=REDACTED&blogs=1"},
+		{name: "エンコードされたキー", uri: "/api/stream?%74icket=abc", want: "/api/stream?%74icket=REDACTED"},
+		{name: "値なし", uri: "/api/stream?ticket", want: "/api/stream?ticket=REDACTED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logged, handled string
+			logger := func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					logged = r.RequestURI
+					next.ServeHTTP(w, r)
+				})
+			}
+			h := RedactQuery("ticket", "access_token")(logger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handled = r.URL.RawQuery
+			})))
+
+			req := httptest.NewRequest(http.MethodGet, tt.uri, nil)
+			rawQuery := req.URL.RawQuery
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if logged != tt.want {
+				t.Errorf("RequestURI = %q, want %q", logged, tt.want)
+			}
+			if handled != rawQuery {
+				t.Errorf("URL.RawQuery = %q, want %q", handled, rawQuery)
+			}
+		})
+	}
+}
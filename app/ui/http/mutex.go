@@ -3,29 +3,126 @@ package http
 import (
 	"context"
 	"errors"
+	"log"
+	"sync"
 	"time"
+
+	"github.com/google/uuid"
 )
 
-// Mutex はロックを提供するインターフェース
+// ErrLocked はロックが既に取得されていることを示すエラー
+var ErrLocked = errors.New("locked")
+
+// ErrLockLost はロックを保持している間にロックが失われたことを示すエラー（Lockが返すコンテキストの終了理由）
+var ErrLockLost = errors.New("lock lost")
+
+// LockStore はロックの保存先のインターフェース
+// ロックは所有者トークンとTTLを持ち、TTLを過ぎたロックは他の所有者が取得できる
+type LockStore interface {
+	// Acquire はidのロックをownerとしてttlの間取得する（他の所有者が保持している場合はfalse）
+	Acquire(ctx context.Context, id string, owner string, ttl time.Duration) (bool, error)
+	// Renew はownerが保持しているロックの期限を現在からttl後に延長する（保持していない場合はfalse）
+	Renew(ctx context.Context, id string, owner string, ttl time.Duration) (bool, error)
+	// Release はownerが保持しているロックを解放する（保持していない場合はfalse）
+	Release(ctx context.Context, id string, owner string) (bool, error)
+}
+
+// Mutex は複数プロセス間の排他制御を提供する
 type Mutex struct {
-	cache interface{} // TODO: キャッシュクライアントを使用
+	store LockStore
 }
 
 // NewMutex はMutexのコンストラクタ
-func NewMutex(cache interface{}) *Mutex {
+func NewMutex(store LockStore) *Mutex {
 	return &Mutex{
-		cache: cache,
+		store: store,
 	}
 }
 
-// errLocked はロックが既に取得されていることを示すエラー
-var errLocked = errors.New("locked")
+// releaseTimeout はロック解放処理のタイムアウト
+const releaseTimeout = 10 * time.Second
+
+// Lock はidのロックをttlの間取得し、ロックを保持している間のコンテキストと解放する関数を返す
+// 他のプロセスがロックを保持している場合はErrLockedを返す
+// ロックを保持している間はttlの1/3ごとに期限を延長するため、ttlより長い処理でもロックは失われない
+// 延長できずにロックが失われた場合は返したコンテキストをErrLockLostを理由（context.Cause）として終了するため、
+// ロックが必要な処理は返したコンテキストで実行すること
+// 解放する関数は自身が保持しているロックのみを解放し、複数回呼び出しても安全
+func (m *Mutex) Lock(ctx context.Context, id string, ttl time.Duration) (context.Context, func(), error) {
+	owner := uuid.New().String()
+
+	acquired, err := m.store.Acquire(ctx, id, owner, ttl)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !acquired {
+		return nil, nil, ErrLocked
+	}
+
+	lockCtx, cancelLock := context.WithCancelCause(ctx)
+	renewCtx, cancel := context.WithCancel(context.Background())
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		if !m.renew(renewCtx, id, owner, ttl) {
+			cancelLock(ErrLockLost)
+		}
+	}()
+
+	var once sync.Once
+	return lockCtx, func() {
+		once.Do(func() {
+			cancel()
+			<-renewed
+			cancelLock(nil)
+
+			releaseCtx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+			defer cancel()
 
-// TODO: キャッシュを使用してロックを実装
-func (m *Mutex) Lock(ctx context.Context, id string, ttl time.Duration) (func(), error) {
-	// 実際の実装ではキャッシュを使用してロックを取得する
-	// ここではダミーの実装を返す
-	return func() {
-		// ロック解放処理
+			released, err := m.store.Release(releaseCtx, id, owner)
+			if err != nil {
+				log.Printf("ロックの解放に失敗しました（id: %s）: %v", id, err)
+				return
+			}
+			if !released {
+				log.Printf("ロックは既に失われていました（id: %s）", id)
+			}
+		})
 	}, nil
 }
+
+// renew はctxが終了するまでロックの期限を定期的に延長する
+// ロックが失われた場合（他の所有者に移った場合、またはttlの間延長に失敗し続けた場合）はfalseを返す
+func (m *Mutex) renew(ctx context.Context, id string, owner string, ttl time.Duration) bool {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	// 最後に延長した時点からttlを過ぎると、他のプロセスがロックを取得できる
+	expiresAt := time.Now().Add(ttl)
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-ticker.C:
+			now := time.Now()
+			renewed, err := m.store.Renew(ctx, id, owner, ttl)
+			if err != nil {
+				if ctx.Err() != nil {
+					return true
+				}
+				if !now.Before(expiresAt) {
+					log.Printf("ロックの延長に失敗し、ロックの期限が切れました（id: %s）: %v", id, err)
+					return false
+				}
+				// 一時的なエラーの可能性があるため、次の延長で再試行する
+				log.Printf("ロックの延長に失敗しました（id: %s）: %v", id, err)
+				continue
+			}
+			if !renewed {
+				log.Printf("ロックが失われました（id: %s）", id)
+				return false
+			}
+			expiresAt = now.Add(ttl)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLockStore はRenewの結果を指定できるLockStore
+type fakeLockStore struct {
+	mu       sync.Mutex
+	renewed  bool
+	renewErr error
+	released bool
+}
+
+func (s *fakeLockStore) Acquire(ctx context.Context, id string, owner string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (s *fakeLockStore) Renew(ctx context.Context, id string, owner string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.renewed, s.renewErr
+}
+
+func (s *fakeLockStore) Release(ctx context.Context, id string, owner string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.released = true
+	return true, nil
+}
+
+func TestMutex_Lock(t *testing.T) {
+	const ttl = 30 * time.Millisecond
+
+	tests := []struct {
+		name     string
+		store    *fakeLockStore
+		wantLost bool
+	}{
+		{name: "延長できる間はロックを保持する", store: &fakeLockStore{renewed: true}},
+		{name: "他の所有者に移った場合はロックを失う", store: &fakeLockStore{renewed: false}, wantLost: true},
+		{name: "ttlの間延長に失敗し続けた場合はロックを失う", store: &fakeLockStore{renewErr: errors.New("connection refused")}, wantLost: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, unlock, err := NewMutex(tt.store).Lock(context.Background(), "test", ttl)
+			if err != nil {
+				t.Fatalf("ロックの取得に失敗しました: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				if !tt.wantLost {
+					t.Fatalf("ロックを保持している間にコンテキストが終了しました: %v", context.Cause(ctx))
+				}
+				if cause := context.Cause(ctx); !errors.Is(cause, ErrLockLost) {
+					t.Errorf("終了理由が異なります: %v", cause)
+				}
+			case <-time.After(5 * ttl):
+				if tt.wantLost {
+					t.Fatal("ロックを失ってもコンテキストが終了しませんでした")
+				}
+			}
+
+			unlock()
+			if ctx.Err() == nil {
+				t.Error("解放後もコンテキストが終了していません")
+			}
+			if !tt.store.released {
+				t.Error("ロックが解放されていません")
+			}
+		})
+	}
+}
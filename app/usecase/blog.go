@@ -6,14 +6,18 @@ import (
 	"fmt"
 
 	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/event"
 	"myblog/app/domain/model/user"
+	"myblog/app/domain/model/view"
 	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
 )
 
 // BlogUsecase : ブログユースケースインターフェース
 type BlogUsecase interface {
 	CreateBlog(ctx context.Context, userID, title, content string) (*blog.Blog, error)
 	GetBlogByID(ctx context.Context, id string) (*blog.Blog, error)
+	ViewBlog(ctx context.Context, id string, viewer Viewer) (*blog.Blog, error)
 	GetBlogsByUserID(ctx context.Context, userID string) ([]*blog.Blog, error)
 	GetAllBlogs(ctx context.Context, page, perPage int) ([]*blog.Blog, error)
 	UpdateBlog(ctx context.Context, id, userID, title, content string) (*blog.Blog, error)
@@ -22,15 +26,48 @@ type BlogUsecase interface {
 
 // blogUsecase : ブログユースケースの実装
 type blogUsecase struct {
-	blogRepo repository.Blog
-	userRepo repository.User
+	blogRepo    repository.Blog
+	userRepo    repository.User
+	mentionRepo repository.Mention
+	outboxRepo  repository.Outbox
+	mentions    *mentionRecorder
+	views       ViewRecorder
+	txManager   rdb.TransactionManager
+}
+
+// Viewer : ブログの閲覧者
+type Viewer struct {
+	UserID string // ログインユーザーのID（匿名の場合は空）
+	Key    string // 閲覧者を識別するキー
+	IsBot  bool   // クローラー等の自動アクセスかどうか
 }
 
 // NewBlogUsecase : ブログユースケースの生成
-func NewBlogUsecase(blogRepo repository.Blog, userRepo repository.User) BlogUsecase {
+func NewBlogUsecase(
+	blogRepo repository.Blog,
+	userRepo repository.User,
+	mentionRepo repository.Mention,
+	notificationRepo repository.Notification,
+	preferenceRepo repository.NotificationPreference,
+	outboxRepo repository.Outbox,
+	views ViewRecorder,
+	txManager rdb.TransactionManager,
+) BlogUsecase {
 	return &blogUsecase{
-		blogRepo: blogRepo,
-		userRepo: userRepo,
+		blogRepo:    blogRepo,
+		userRepo:    userRepo,
+		mentionRepo: mentionRepo,
+		outboxRepo:  outboxRepo,
+		mentions: &mentionRecorder{
+			userRepo:    userRepo,
+			mentionRepo: mentionRepo,
+			notifications: &notificationSender{
+				notificationRepo: notificationRepo,
+				preferenceRepo:   preferenceRepo,
+			},
+		},
+		views:     views,
+		txManager: txManager,
 	}
 }
 
@@ -48,9 +85,26 @@ func (b *blogUsecase) CreateBlog(ctx context.Context, userID, title, content str
 		return nil, fmt.Errorf("ブログ作成エラー: %w", err)
 	}
 
-	// ブログの保存
-	if err := b.blogRepo.Save(ctx, newBlog); err != nil {
-		return nil, fmt.Errorf("ブログ保存エラー: %w", err)
+	published, err := event.NewBlogPublished(newBlog)
+	if err != nil {
+		return nil, fmt.Errorf("イベント作成エラー: %w", err)
+	}
+
+	// ブログとメンション、投稿イベントをトランザクション内で保存
+	err = b.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := b.blogRepo.Save(ctx, newBlog); err != nil {
+			return fmt.Errorf("ブログ保存エラー: %w", err)
+		}
+		if _, err := b.mentions.record(ctx, user.ID(), newBlog.ID(), nil, newBlog.Content(), nil); err != nil {
+			return err
+		}
+		if err := b.outboxRepo.Save(ctx, published); err != nil {
+			return fmt.Errorf("イベント保存エラー: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return newBlog, nil
@@ -65,6 +119,28 @@ func (b *blogUsecase) GetBlogByID(ctx context.Context, id string) (*blog.Blog, e
 	return blog, nil
 }
 
+// ViewBlog : 閲覧を記録してブログを取得
+// 閲覧の記録は非同期で行い、著者自身やクローラーによる閲覧は数えない
+func (b *blogUsecase) ViewBlog(ctx context.Context, id string, viewer Viewer) (*blog.Blog, error) {
+	blog, err := b.GetBlogByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if viewer.IsBot || viewer.UserID == blog.UserID().String() {
+		return blog, nil
+	}
+
+	v, err := view.NewView(blog.ID(), viewer.Key)
+	if err != nil {
+		// 閲覧者を識別できない場合は記録せずにブログを返す
+		return blog, nil
+	}
+	b.views.Record(v)
+
+	return blog, nil
+}
+
 // GetBlogsByUserID : ユーザーIDによるブログ一覧取得
 func (b *blogUsecase) GetBlogsByUserID(ctx context.Context, userID string) ([]*blog.Blog, error) {
 	// ユーザーIDの検証
@@ -119,15 +195,36 @@ func (b *blogUsecase) UpdateBlog(ctx context.Context, id, userID, title, content
 		}
 	}
 
+	contentChanged := content != "" && content != existingBlog.Content()
 	if content != "" {
 		if err := existingBlog.UpdateContent(content); err != nil {
 			return nil, fmt.Errorf("コンテンツ更新エラー: %w", err)
 		}
 	}
 
-	// ブログの保存
-	if err := b.blogRepo.Update(ctx, existingBlog); err != nil {
-		return nil, fmt.Errorf("ブログ更新エラー: %w", err)
+	// ブログとメンションをトランザクション内で保存
+	err = b.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := b.blogRepo.Update(ctx, existingBlog); err != nil {
+			return fmt.Errorf("ブログ更新エラー: %w", err)
+		}
+
+		if !contentChanged {
+			return nil
+		}
+
+		// メンションを本文に合わせて作り直す（既にメンション済みのユーザーには再通知しない）
+		previous, err := b.mentionRepo.FindByBlogIDs(ctx, []blog.ID{existingBlog.ID()})
+		if err != nil {
+			return fmt.Errorf("メンション取得エラー: %w", err)
+		}
+		if err := b.mentionRepo.DeleteByBlogID(ctx, existingBlog.ID()); err != nil {
+			return fmt.Errorf("メンション削除エラー: %w", err)
+		}
+		_, err = b.mentions.record(ctx, existingBlog.UserID(), existingBlog.ID(), nil, existingBlog.Content(), previous)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return existingBlog, nil
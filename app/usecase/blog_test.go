@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/event"
+	"myblog/app/domain/model/notification"
+)
+
+// newTestBlogUsecase : インメモリのリポジトリを使用するブログユースケースの生成
+func newTestBlogUsecase(repos *testRepos, views ViewRecorder) BlogUsecase {
+	return NewBlogUsecase(repos.blogs, repos.users, repos.mentions, repos.notifications, repos.preferences, repos.outbox, views, repos.tx)
+}
+
+func TestBlogUsecase_CreateBlog(t *testing.T) {
+	tests := []struct {
+		name              string
+		unknownAuthor     bool
+		title             string
+		content           string
+		bobMutesMentions  bool
+		failingOutbox     bool
+		wantErr           string
+		wantMentions      []string
+		wantBobNotified   bool
+		wantAliceNotified bool
+	}{
+		{name: "作成できる", title: "title", content: "content"},
+		{name: "メンションしたユーザーに通知する", title: "title", content: "hello @bob and @unknown", wantMentions: []string{"bob"}, wantBobNotified: true},
+		{name: "自分自身へのメンションは通知しない", title: "title", content: "I am @alice", wantMentions: []string{"alice"}},
+		{name: "メンションの通知を受け取らない設定のユーザーには通知しない", title: "title", content: "hello @bob", bobMutesMentions: true, wantMentions: []string{"bob"}},
+		{name: "存在しないユーザー", unknownAuthor: true, title: "title", content: "content", wantErr: "ユーザー取得エラー"},
+		{name: "タイトルが空", title: "", content: "content", wantErr: "タイトルが空です"},
+		{name: "本文が空", title: "title", content: "", wantErr: "コンテンツが空です"},
+		{name: "イベントの保存に失敗した場合はブログもメンションも保存しない", title: "title", content: "hello @bob", failingOutbox: true, wantErr: "イベント保存エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+
+			if tt.bobMutesMentions {
+				preferences := notification.NewPreferences(bob.ID())
+				if err := preferences.Set(notification.TypeMention, false); err != nil {
+					t.Fatal(err)
+				}
+				if err := repos.preferences.Save(ctx, preferences); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			outbox := repos.outbox
+			if tt.failingOutbox {
+				outbox = failingOutbox{outbox}
+			}
+			uc := NewBlogUsecase(repos.blogs, repos.users, repos.mentions, repos.notifications, repos.preferences, outbox, &recordingViews{}, repos.tx)
+
+			authorID := alice.ID().String()
+			if tt.unknownAuthor {
+				authorID = "unknown"
+			}
+
+			created, err := uc.CreateBlog(ctx, authorID, tt.title, tt.content)
+			assertError(t, err, tt.wantErr)
+
+			if tt.wantErr != "" {
+				blogs, err := repos.blogs.FindAll(ctx, 0, 10)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(blogs) != 0 {
+					t.Errorf("失敗した作成のブログが保存されています: %d件", len(blogs))
+				}
+				if n := repos.notificationsOf(t, bob); len(n) != 0 {
+					t.Errorf("失敗した作成の通知が保存されています: %v", notificationTypes(n))
+				}
+				return
+			}
+
+			if _, err := repos.blogs.FindByID(ctx, created.ID().String()); err != nil {
+				t.Fatalf("作成したブログが保存されていません: %v", err)
+			}
+
+			mentions, err := repos.mentions.FindByBlogIDs(ctx, []blog.ID{created.ID()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var usernames []string
+			for _, m := range mentions {
+				usernames = append(usernames, m.Username())
+			}
+			if !reflect.DeepEqual(usernames, tt.wantMentions) {
+				t.Errorf("メンションが異なります: got %v, want %v", usernames, tt.wantMentions)
+			}
+
+			if got := len(repos.notificationsOf(t, bob)) == 1; got != tt.wantBobNotified {
+				t.Errorf("bobへの通知の有無が異なります: got %v, want %v", got, tt.wantBobNotified)
+			}
+			if got := len(repos.notificationsOf(t, alice)) == 1; got != tt.wantAliceNotified {
+				t.Errorf("aliceへの通知の有無が異なります: got %v, want %v", got, tt.wantAliceNotified)
+			}
+
+			if events := repos.pendingEvents(t); len(events) != 1 || events[0] != event.TypeBlogPublished {
+				t.Errorf("投稿イベントが保存されていません: %v", events)
+			}
+		})
+	}
+}
+
+func TestBlogUsecase_ViewBlog(t *testing.T) {
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	bob := repos.createUser(t, "bob", "password")
+	b := repos.createBlog(t, alice, "title", "content")
+
+	tests := []struct {
+		name       string
+		id         string
+		viewer     Viewer
+		wantErr    string
+		wantRecord bool
+	}{
+		{name: "ログインユーザーの閲覧を記録する", id: b.ID().String(), viewer: Viewer{UserID: bob.ID().String(), Key: bob.ID().String()}, wantRecord: true},
+		{name: "匿名の閲覧を記録する", id: b.ID().String(), viewer: Viewer{Key: "anonymous"}, wantRecord: true},
+		{name: "著者自身の閲覧は記録しない", id: b.ID().String(), viewer: Viewer{UserID: alice.ID().String(), Key: alice.ID().String()}},
+		{name: "クローラーの閲覧は記録しない", id: b.ID().String(), viewer: Viewer{Key: "crawler", IsBot: true}},
+		{name: "閲覧者を識別できない場合は記録しない", id: b.ID().String(), viewer: Viewer{}},
+		{name: "存在しないブログ", id: "unknown", viewer: Viewer{Key: "anonymous"}, wantErr: "ブログ取得エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views := &recordingViews{}
+			uc := newTestBlogUsecase(repos, views)
+
+			got, err := uc.ViewBlog(context.Background(), tt.id, tt.viewer)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr == "" && got.ID() != b.ID() {
+				t.Errorf("取得したブログが異なります: %s", got.ID().String())
+			}
+
+			want := 0
+			if tt.wantRecord {
+				want = 1
+			}
+			if views.count() != want {
+				t.Errorf("記録した閲覧の件数が異なります: got %d, want %d", views.count(), want)
+			}
+		})
+	}
+}
+
+func TestBlogUsecase_GetBlogsByUserID(t *testing.T) {
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	bob := repos.createUser(t, "bob", "password")
+	first := repos.createBlog(t, alice, "first", "content")
+	second := repos.createBlog(t, alice, "second", "content")
+	repos.createBlog(t, bob, "bob's", "content")
+	uc := newTestBlogUsecase(repos, &recordingViews{})
+
+	tests := []struct {
+		name      string
+		userID    string
+		wantErr   string
+		wantBlogs []blog.ID
+	}{
+		{name: "新しい順に取得できる", userID: alice.ID().String(), wantBlogs: []blog.ID{second.ID(), first.ID()}},
+		{name: "ブログのないユーザー", userID: "unknown", wantBlogs: []blog.ID{}},
+		{name: "ユーザーIDが空", userID: "", wantErr: "ユーザーID検証エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blogs, err := uc.GetBlogsByUserID(context.Background(), tt.userID)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+			if got := blogIDsOf(blogs); !reflect.DeepEqual(got, tt.wantBlogs) {
+				t.Errorf("ブログが異なります: got %v, want %v", got, tt.wantBlogs)
+			}
+		})
+	}
+}
+
+func TestBlogUsecase_GetAllBlogs(t *testing.T) {
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	var created []blog.ID
+	for i := 0; i < 12; i++ {
+		created = append(created, repos.createBlog(t, alice, "title", "content").ID())
+	}
+	// 新しい順
+	newest := make([]blog.ID, len(created))
+	for i, id := range created {
+		newest[len(created)-1-i] = id
+	}
+	uc := newTestBlogUsecase(repos, &recordingViews{})
+
+	tests := []struct {
+		name    string
+		page    int
+		perPage int
+		want    []blog.ID
+	}{
+		{name: "最初のページ", page: 0, perPage: 5, want: newest[0:5]},
+		{name: "途中のページ", page: 1, perPage: 5, want: newest[5:10]},
+		{name: "最後のページは残りの件数", page: 2, perPage: 5, want: newest[10:12]},
+		{name: "範囲外のページは空", page: 3, perPage: 5, want: []blog.ID{}},
+		{name: "負のページは最初のページ", page: -1, perPage: 5, want: newest[0:5]},
+		{name: "件数の指定がない場合は10件", page: 0, perPage: 0, want: newest[0:10]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blogs, err := uc.GetAllBlogs(context.Background(), tt.page, tt.perPage)
+			assertError(t, err, "")
+			if got := blogIDsOf(blogs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ブログが異なります: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlogUsecase_UpdateBlog(t *testing.T) {
+	tests := []struct {
+		name            string
+		unknownBlog     bool
+		asBob           bool
+		title           string
+		content         string
+		wantErr         string
+		wantTitle       string
+		wantContent     string
+		wantBobNotified int
+		wantCarol       int
+	}{
+		{name: "タイトルのみ更新できる", title: "new title", wantTitle: "new title", wantContent: "hello @bob", wantBobNotified: 1},
+		{name: "本文の変更で新たにメンションしたユーザーにのみ通知する", content: "hello @bob and @carol", wantTitle: "title", wantContent: "hello @bob and @carol", wantBobNotified: 1, wantCarol: 1},
+		{name: "他のユーザーのブログは更新できない", asBob: true, title: "hacked", wantErr: "このブログを更新する権限がありません", wantTitle: "title", wantContent: "hello @bob", wantBobNotified: 1},
+		{name: "存在しないブログ", unknownBlog: true, title: "new title", wantErr: "ブログ取得エラー", wantTitle: "title", wantContent: "hello @bob", wantBobNotified: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			carol := repos.createUser(t, "carol", "password")
+			uc := newTestBlogUsecase(repos, &recordingViews{})
+
+			b, err := uc.CreateBlog(ctx, alice.ID().String(), "title", "hello @bob")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			id := b.ID().String()
+			if tt.unknownBlog {
+				id = "unknown"
+			}
+			userID := alice.ID().String()
+			if tt.asBob {
+				userID = bob.ID().String()
+			}
+
+			_, err = uc.UpdateBlog(ctx, id, userID, tt.title, tt.content)
+			assertError(t, err, tt.wantErr)
+
+			saved, err := repos.blogs.FindByID(ctx, b.ID().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.Title() != tt.wantTitle || saved.Content() != tt.wantContent {
+				t.Errorf("保存されたブログが異なります: got (%s, %s), want (%s, %s)", saved.Title(), saved.Content(), tt.wantTitle, tt.wantContent)
+			}
+			if got := len(repos.notificationsOf(t, bob)); got != tt.wantBobNotified {
+				t.Errorf("bobへの通知の件数が異なります: got %d, want %d", got, tt.wantBobNotified)
+			}
+			if got := len(repos.notificationsOf(t, carol)); got != tt.wantCarol {
+				t.Errorf("carolへの通知の件数が異なります: got %d, want %d", got, tt.wantCarol)
+			}
+		})
+	}
+}
+
+func TestBlogUsecase_DeleteBlog(t *testing.T) {
+	tests := []struct {
+		name        string
+		unknownBlog bool
+		asBob       bool
+		wantErr     string
+	}{
+		{name: "著者は削除できる"},
+		{name: "他のユーザーのブログは削除できない", asBob: true, wantErr: "このブログを削除する権限がありません"},
+		{name: "存在しないブログ", unknownBlog: true, wantErr: "ブログ取得エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			c := repos.createComment(t, b, bob, "comment")
+			uc := newTestBlogUsecase(repos, &recordingViews{})
+
+			id := b.ID().String()
+			if tt.unknownBlog {
+				id = "unknown"
+			}
+			userID := alice.ID().String()
+			if tt.asBob {
+				userID = bob.ID().String()
+			}
+
+			err := uc.DeleteBlog(ctx, id, userID)
+			assertError(t, err, tt.wantErr)
+
+			_, blogErr := repos.blogs.FindByID(ctx, b.ID().String())
+			_, commentErr := repos.comments.FindByID(ctx, c.ID().String())
+			deleted := tt.wantErr == ""
+			if (blogErr != nil) != deleted || (commentErr != nil) != deleted {
+				t.Errorf("削除の結果が異なります: blog=%v, comment=%v, 削除されるべきか=%v", blogErr, commentErr, deleted)
+			}
+		})
+	}
+}
+
+// blogIDsOf : ブログのIDの一覧
+func blogIDsOf(blogs []*blog.Blog) []blog.ID {
+	ids := make([]blog.ID, len(blogs))
+	for i, b := range blogs {
+		ids[i] = b.ID()
+	}
+	return ids
+}
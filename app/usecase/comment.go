@@ -7,8 +7,10 @@ import (
 
 	"myblog/app/domain/model/blog"
 	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/event"
 	"myblog/app/domain/model/user"
 	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
 )
 
 // CommentUsecase : コメントユースケースインターフェース
@@ -25,14 +27,47 @@ type commentUsecase struct {
 	commentRepo repository.Comment
 	blogRepo    repository.Blog
 	userRepo    repository.User
+	mentionRepo repository.Mention
+	outboxRepo  repository.Outbox
+	mentions    *mentionRecorder
+	notifier    *commentNotifier
+	stream      StreamPublisher
+	txManager   rdb.TransactionManager
 }
 
 // NewCommentUsecase : コメントユースケースの生成
-func NewCommentUsecase(commentRepo repository.Comment, blogRepo repository.Blog, userRepo repository.User) CommentUsecase {
+func NewCommentUsecase(
+	commentRepo repository.Comment,
+	blogRepo repository.Blog,
+	userRepo repository.User,
+	mentionRepo repository.Mention,
+	notificationRepo repository.Notification,
+	preferenceRepo repository.NotificationPreference,
+	outboxRepo repository.Outbox,
+	stream StreamPublisher,
+	txManager rdb.TransactionManager,
+) CommentUsecase {
+	notifications := &notificationSender{
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+	}
 	return &commentUsecase{
 		commentRepo: commentRepo,
 		blogRepo:    blogRepo,
 		userRepo:    userRepo,
+		mentionRepo: mentionRepo,
+		outboxRepo:  outboxRepo,
+		mentions: &mentionRecorder{
+			userRepo:      userRepo,
+			mentionRepo:   mentionRepo,
+			notifications: notifications,
+		},
+		notifier: &commentNotifier{
+			commentRepo:   commentRepo,
+			notifications: notifications,
+		},
+		stream:    stream,
+		txManager: txManager,
 	}
 }
 
@@ -45,7 +80,7 @@ func (c *commentUsecase) CreateComment(ctx context.Context, blogID, userID, cont
 	}
 
 	// ブログの存在確認
-	_, err = c.blogRepo.FindByID(ctx, blogID)
+	existingBlog, err := c.blogRepo.FindByID(ctx, blogID)
 	if err != nil {
 		return nil, fmt.Errorf("ブログ取得エラー: %w", err)
 	}
@@ -67,11 +102,41 @@ func (c *commentUsecase) CreateComment(ctx context.Context, blogID, userID, cont
 		return nil, fmt.Errorf("コメント作成エラー: %w", err)
 	}
 
-	// コメントの保存
-	if err := c.commentRepo.Save(ctx, newComment); err != nil {
-		return nil, fmt.Errorf("コメント保存エラー: %w", err)
+	added, err := event.NewCommentAdded(newComment, existingBlog.UserID())
+	if err != nil {
+		return nil, fmt.Errorf("イベント作成エラー: %w", err)
+	}
+
+	// コメントとメンション、通知、投稿イベントをトランザクション内で保存
+	err = c.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := c.commentRepo.Save(ctx, newComment); err != nil {
+			return fmt.Errorf("コメント保存エラー: %w", err)
+		}
+		commentID := newComment.ID()
+		mentioned, err := c.mentions.record(ctx, existingUser.ID(), newComment.BlogID(), &commentID, newComment.Content(), nil)
+		if err != nil {
+			return err
+		}
+		if err := c.notifier.notify(ctx, existingBlog, newComment, mentioned); err != nil {
+			return err
+		}
+		if err := c.outboxRepo.Save(ctx, added); err != nil {
+			return fmt.Errorf("イベント保存エラー: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	// コミット後にブログの購読者とブログの著者へ配信する
+	c.stream.Publish(StreamEvent{
+		Type:    StreamEventComment,
+		BlogID:  newComment.BlogID().String(),
+		UserIDs: []string{existingBlog.UserID().String()},
+		Payload: newComment,
+	})
+
 	return newComment, nil
 }
 
@@ -124,9 +189,26 @@ func (c *commentUsecase) UpdateComment(ctx context.Context, id, userID, content
 		return nil, fmt.Errorf("コンテンツ更新エラー: %w", err)
 	}
 
-	// コメントの保存
-	if err := c.commentRepo.Update(ctx, existingComment); err != nil {
-		return nil, fmt.Errorf("コメント更新エラー: %w", err)
+	// コメントとメンションをトランザクション内で保存
+	err = c.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := c.commentRepo.Update(ctx, existingComment); err != nil {
+			return fmt.Errorf("コメント更新エラー: %w", err)
+		}
+
+		// メンションを本文に合わせて作り直す（既にメンション済みのユーザーには再通知しない）
+		commentID := existingComment.ID()
+		previous, err := c.mentionRepo.FindByCommentIDs(ctx, []comment.ID{commentID})
+		if err != nil {
+			return fmt.Errorf("メンション取得エラー: %w", err)
+		}
+		if err := c.mentionRepo.DeleteByCommentID(ctx, commentID); err != nil {
+			return fmt.Errorf("メンション削除エラー: %w", err)
+		}
+		_, err = c.mentions.record(ctx, existingComment.UserID(), existingComment.BlogID(), &commentID, existingComment.Content(), previous)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return existingComment, nil
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/event"
+	"myblog/app/domain/model/notification"
+)
+
+// newTestCommentUsecase : インメモリのリポジトリを使用するコメントユースケースの生成
+func newTestCommentUsecase(repos *testRepos, stream StreamPublisher) CommentUsecase {
+	return NewCommentUsecase(repos.comments, repos.blogs, repos.users, repos.mentions, repos.notifications, repos.preferences, repos.outbox, stream, repos.tx)
+}
+
+func TestCommentUsecase_CreateComment(t *testing.T) {
+	// aliceのブログにbobが既にコメントしている状態で、commenterがコメントする
+	tests := []struct {
+		name          string
+		blogID        func(blogID string) string
+		commenter     string
+		unknownUser   bool
+		emptyUserID   bool
+		content       string
+		failingOutbox bool
+		wantErr       string
+		wantAlice     []notification.Type
+		wantBob       []notification.Type
+		wantCarol     []notification.Type
+	}{
+		{name: "著者にコメント、他のコメント投稿者に返信を通知する", commenter: "carol", content: "nice", wantAlice: []notification.Type{notification.TypeComment}, wantBob: []notification.Type{notification.TypeReply}},
+		{name: "メンションしたユーザーには返信ではなくメンションを通知する", commenter: "carol", content: "@bob nice", wantAlice: []notification.Type{notification.TypeComment}, wantBob: []notification.Type{notification.TypeMention}},
+		{name: "メンションした著者にはコメントではなくメンションを通知する", commenter: "carol", content: "@alice nice", wantAlice: []notification.Type{notification.TypeMention}, wantBob: []notification.Type{notification.TypeReply}},
+		{name: "著者自身のコメントは著者に通知しない", commenter: "alice", content: "thanks", wantBob: []notification.Type{notification.TypeReply}},
+		{name: "既にコメントしたユーザー自身には返信を通知しない", commenter: "bob", content: "again", wantAlice: []notification.Type{notification.TypeComment}},
+		{name: "ブログIDが空", blogID: func(string) string { return "" }, commenter: "carol", content: "nice", wantErr: "ブログID検証エラー"},
+		{name: "存在しないブログ", blogID: func(string) string { return "unknown" }, commenter: "carol", content: "nice", wantErr: "ブログ取得エラー"},
+		{name: "ユーザーIDが空", emptyUserID: true, content: "nice", wantErr: "ユーザーID検証エラー"},
+		{name: "存在しないユーザー", unknownUser: true, content: "nice", wantErr: "ユーザー取得エラー"},
+		{name: "本文が空", commenter: "carol", content: "", wantErr: "コンテンツが空です"},
+		{name: "イベントの保存に失敗した場合はコメントも通知も保存せず配信しない", commenter: "carol", content: "@bob nice", failingOutbox: true, wantErr: "イベント保存エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			users := map[string]string{}
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			carol := repos.createUser(t, "carol", "password")
+			users["alice"], users["bob"], users["carol"] = alice.ID().String(), bob.ID().String(), carol.ID().String()
+			b := repos.createBlog(t, alice, "title", "content")
+			repos.createComment(t, b, bob, "first")
+
+			outbox := repos.outbox
+			if tt.failingOutbox {
+				outbox = failingOutbox{outbox}
+			}
+			stream := &recordingStream{}
+			uc := NewCommentUsecase(repos.comments, repos.blogs, repos.users, repos.mentions, repos.notifications, repos.preferences, outbox, stream, repos.tx)
+
+			blogID := b.ID().String()
+			if tt.blogID != nil {
+				blogID = tt.blogID(blogID)
+			}
+			userID := users[tt.commenter]
+			if tt.unknownUser {
+				userID = "unknown"
+			}
+			if tt.emptyUserID {
+				userID = ""
+			}
+
+			created, err := uc.CreateComment(ctx, blogID, userID, tt.content)
+			assertError(t, err, tt.wantErr)
+
+			if got := notificationTypes(repos.notificationsOf(t, alice)); !equalTypes(got, tt.wantAlice) {
+				t.Errorf("aliceへの通知が異なります: got %v, want %v", got, tt.wantAlice)
+			}
+			if got := notificationTypes(repos.notificationsOf(t, bob)); !equalTypes(got, tt.wantBob) {
+				t.Errorf("bobへの通知が異なります: got %v, want %v", got, tt.wantBob)
+			}
+			if got := notificationTypes(repos.notificationsOf(t, carol)); !equalTypes(got, tt.wantCarol) {
+				t.Errorf("carolへの通知が異なります: got %v, want %v", got, tt.wantCarol)
+			}
+
+			comments, err := repos.comments.FindByBlogID(ctx, b.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			published := stream.published()
+
+			if tt.wantErr != "" {
+				if len(comments) != 1 {
+					t.Errorf("失敗した作成のコメントが保存されています: %d件", len(comments))
+				}
+				if len(published) != 0 {
+					t.Errorf("失敗した作成のイベントが配信されています: %d件", len(published))
+				}
+				return
+			}
+
+			if len(comments) != 2 || comments[1].ID() != created.ID() {
+				t.Errorf("作成したコメントが保存されていません: %d件", len(comments))
+			}
+			if len(published) != 1 {
+				t.Fatalf("配信したイベントの件数が異なります: %d件", len(published))
+			}
+			if e := published[0]; e.Type != StreamEventComment || e.BlogID != b.ID().String() || !reflect.DeepEqual(e.UserIDs, []string{alice.ID().String()}) {
+				t.Errorf("配信したイベントが異なります: %+v", e)
+			}
+			if events := repos.pendingEvents(t); len(events) != 1 || events[0] != event.TypeCommentAdded {
+				t.Errorf("投稿イベントが保存されていません: %v", events)
+			}
+		})
+	}
+}
+
+func TestCommentUsecase_GetCommentsByBlogID(t *testing.T) {
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	b := repos.createBlog(t, alice, "title", "content")
+	empty := repos.createBlog(t, alice, "empty", "content")
+	first := repos.createComment(t, b, alice, "first")
+	second := repos.createComment(t, b, alice, "second")
+	uc := newTestCommentUsecase(repos, &recordingStream{})
+
+	tests := []struct {
+		name    string
+		blogID  string
+		wantErr string
+		want    []comment.ID
+	}{
+		{name: "古い順に取得できる", blogID: b.ID().String(), want: []comment.ID{first.ID(), second.ID()}},
+		{name: "コメントのないブログ", blogID: empty.ID().String(), want: []comment.ID{}},
+		{name: "ブログIDが空", blogID: "", wantErr: "ブログID検証エラー"},
+		{name: "存在しないブログ", blogID: "unknown", wantErr: "ブログ取得エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments, err := uc.GetCommentsByBlogID(context.Background(), tt.blogID)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+			got := make([]comment.ID, len(comments))
+			for i, c := range comments {
+				got[i] = c.ID()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("コメントが異なります: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentUsecase_UpdateComment(t *testing.T) {
+	tests := []struct {
+		name          string
+		unknown       bool
+		asAlice       bool
+		content       string
+		wantErr       string
+		wantContent   string
+		wantCarolType []notification.Type
+	}{
+		{name: "投稿者は更新できる", content: "edited", wantContent: "edited"},
+		{name: "新たにメンションしたユーザーに通知する", content: "hi @carol", wantContent: "hi @carol", wantCarolType: []notification.Type{notification.TypeMention}},
+		{name: "ブログの著者でも他のユーザーのコメントは更新できない", asAlice: true, content: "hacked", wantErr: "このコメントを更新する権限がありません", wantContent: "original"},
+		{name: "本文が空", content: "", wantErr: "コンテンツが空です", wantContent: "original"},
+		{name: "存在しないコメント", unknown: true, content: "edited", wantErr: "コメント取得エラー", wantContent: "original"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			carol := repos.createUser(t, "carol", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			c := repos.createComment(t, b, bob, "original")
+			uc := newTestCommentUsecase(repos, &recordingStream{})
+
+			id := c.ID().String()
+			if tt.unknown {
+				id = "unknown"
+			}
+			userID := bob.ID().String()
+			if tt.asAlice {
+				userID = alice.ID().String()
+			}
+
+			_, err := uc.UpdateComment(ctx, id, userID, tt.content)
+			assertError(t, err, tt.wantErr)
+
+			saved, err := repos.comments.FindByID(ctx, c.ID().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.Content() != tt.wantContent {
+				t.Errorf("保存されたコメントが異なります: got %q, want %q", saved.Content(), tt.wantContent)
+			}
+			if got := notificationTypes(repos.notificationsOf(t, carol)); !equalTypes(got, tt.wantCarolType) {
+				t.Errorf("carolへの通知が異なります: got %v, want %v", got, tt.wantCarolType)
+			}
+		})
+	}
+}
+
+func TestCommentUsecase_DeleteComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		unknown bool
+		asAlice bool
+		wantErr string
+	}{
+		{name: "投稿者は削除できる"},
+		{name: "ブログの著者でも他のユーザーのコメントは削除できない", asAlice: true, wantErr: "このコメントを削除する権限がありません"},
+		{name: "存在しないコメント", unknown: true, wantErr: "コメント取得エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			c := repos.createComment(t, b, bob, "original")
+			uc := newTestCommentUsecase(repos, &recordingStream{})
+
+			id := c.ID().String()
+			if tt.unknown {
+				id = "unknown"
+			}
+			userID := bob.ID().String()
+			if tt.asAlice {
+				userID = alice.ID().String()
+			}
+
+			err := uc.DeleteComment(ctx, id, userID)
+			assertError(t, err, tt.wantErr)
+
+			_, findErr := repos.comments.FindByID(ctx, c.ID().String())
+			if deleted := findErr != nil; deleted != (tt.wantErr == "") {
+				t.Errorf("削除の結果が異なります: %v", findErr)
+			}
+		})
+	}
+}
+
+// equalTypes : 通知の種別の一覧の比較（nilと空を同じとみなす）
+func equalTypes(got, want []notification.Type) bool {
+	if len(got) == 0 && len(want) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(got, want)
+}
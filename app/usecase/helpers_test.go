@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/event"
+	"myblog/app/domain/model/notification"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/model/view"
+	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
+	"myblog/app/testing/memory"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// errInjected : テストで注入する失敗
+var errInjected = errors.New("injected failure")
+
+// testRepos : ユースケースのテストで使用するインメモリのリポジトリ（全て同じStoreを参照する）
+type testRepos struct {
+	store         *memory.Store
+	users         repository.User
+	blogs         repository.Blog
+	comments      repository.Comment
+	mentions      repository.Mention
+	notifications repository.Notification
+	preferences   repository.NotificationPreference
+	outbox        repository.Outbox
+	likes         repository.Like
+	reactions     repository.Reaction
+	jobRuns       repository.JobRun
+	blogViews     repository.BlogView
+	rankings      repository.RankingRepository
+	blogStats     BlogStatsQuery
+	rankingList   RankingListQuery
+	tx            rdb.TransactionManager
+}
+
+// newTestRepos : 空のStoreを参照するリポジトリの生成
+func newTestRepos() *testRepos {
+	store := memory.NewStore()
+	return &testRepos{
+		store:         store,
+		users:         memory.NewUserRepository(store),
+		blogs:         memory.NewBlogRepository(store),
+		comments:      memory.NewCommentRepository(store),
+		mentions:      memory.NewMentionRepository(store),
+		notifications: memory.NewNotificationRepository(store),
+		preferences:   memory.NewNotificationPreferenceRepository(store),
+		outbox:        memory.NewOutboxRepository(store),
+		likes:         memory.NewLikeRepository(store),
+		reactions:     memory.NewReactionRepository(store),
+		jobRuns:       memory.NewJobRunRepository(store),
+		blogViews:     memory.NewBlogViewRepository(store),
+		rankings:      memory.NewRankingRepository(store),
+		blogStats:     memory.NewBlogStats(store),
+		rankingList:   memory.NewRankingList(store),
+		tx:            memory.NewTransactionManager(store),
+	}
+}
+
+// createUser : ユーザーの作成（テストを速くするため最小のコストでパスワードをハッシュ化する）
+func (r *testRepos) createUser(t *testing.T, username, password string) *user.User {
+	t.Helper()
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("パスワードのハッシュ化に失敗しました: %v", err)
+	}
+	now := time.Now()
+	u, err := user.Reconstruct(uuid.New().String(), username, strings.ToLower(username)+"@example.com", hashed, now, now)
+	if err != nil {
+		t.Fatalf("ユーザーの生成に失敗しました: %v", err)
+	}
+	if err := r.users.Save(context.Background(), u); err != nil {
+		t.Fatalf("ユーザーの保存に失敗しました: %v", err)
+	}
+	return u
+}
+
+// createBlog : ブログの作成（メンションと通知は記録しない）
+func (r *testRepos) createBlog(t *testing.T, author *user.User, title, content string) *blog.Blog {
+	t.Helper()
+
+	b, err := blog.NewBlog(author.ID(), title, content)
+	if err != nil {
+		t.Fatalf("ブログの生成に失敗しました: %v", err)
+	}
+	if err := r.blogs.Save(context.Background(), b); err != nil {
+		t.Fatalf("ブログの保存に失敗しました: %v", err)
+	}
+	return b
+}
+
+// createComment : コメントの作成（メンションと通知は記録しない）
+func (r *testRepos) createComment(t *testing.T, b *blog.Blog, author *user.User, content string) *comment.Comment {
+	t.Helper()
+
+	c, err := comment.NewComment(b.ID(), author.ID(), content)
+	if err != nil {
+		t.Fatalf("コメントの生成に失敗しました: %v", err)
+	}
+	if err := r.comments.Save(context.Background(), c); err != nil {
+		t.Fatalf("コメントの保存に失敗しました: %v", err)
+	}
+	return c
+}
+
+// notificationsOf : ユーザーの全ての通知（新しい順）
+func (r *testRepos) notificationsOf(t *testing.T, u *user.User) []*notification.Notification {
+	t.Helper()
+
+	notifications, err := r.notifications.FindByUserID(context.Background(), u.ID(), nil, false, 1000)
+	if err != nil {
+		t.Fatalf("通知の取得に失敗しました: %v", err)
+	}
+	return notifications
+}
+
+// pendingEvents : 未配信のイベントの種別
+func (r *testRepos) pendingEvents(t *testing.T) []event.Type {
+	t.Helper()
+
+	events, err := r.outbox.FindPending(context.Background(), time.Now().Add(time.Hour), 1000)
+	if err != nil {
+		t.Fatalf("イベントの取得に失敗しました: %v", err)
+	}
+	types := make([]event.Type, len(events))
+	for i, e := range events {
+		types[i] = e.Type()
+	}
+	return types
+}
+
+// notificationTypes : 通知の種別の一覧
+func notificationTypes(notifications []*notification.Notification) []notification.Type {
+	types := make([]notification.Type, len(notifications))
+	for i, n := range notifications {
+		types[i] = n.Type()
+	}
+	return types
+}
+
+// assertError : エラーの有無とメッセージの検証（wantが空の場合はエラーなし、それ以外はwantを含むエラー）
+func assertError(t *testing.T, err error, want string) {
+	t.Helper()
+
+	if want == "" {
+		if err != nil {
+			t.Fatalf("エラーが発生しました: %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("エラーが発生しませんでした（期待するエラー: %q）", want)
+	}
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("エラーが一致しません: got %q, want %q を含む", err.Error(), want)
+	}
+}
+
+// failingOutbox : イベントの保存に失敗するアウトボックス（トランザクションのロールバックの検証用）
+type failingOutbox struct {
+	repository.Outbox
+}
+
+// Save : 常に失敗する
+func (failingOutbox) Save(ctx context.Context, events ...*event.Event) error {
+	return errInjected
+}
+
+// recordingViews : 記録した閲覧を保持するViewRecorder
+type recordingViews struct {
+	mu    sync.Mutex
+	views []*view.View
+}
+
+// Record : 閲覧の保持
+func (r *recordingViews) Record(v *view.View) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.views = append(r.views, v)
+}
+
+// count : 保持している閲覧の件数
+func (r *recordingViews) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.views)
+}
+
+// recordingStream : 発行したイベントを保持するStreamPublisher
+type recordingStream struct {
+	mu     sync.Mutex
+	events []StreamEvent
+}
+
+// Publish : イベントの保持
+func (s *recordingStream) Publish(e StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, e)
+}
+
+// published : 保持しているイベント
+func (s *recordingStream) published() []StreamEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]StreamEvent(nil), s.events...)
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/jobrun"
+	"myblog/app/domain/repository"
+)
+
+// JobRunUsecase : ジョブ実行履歴ユースケースインターフェース
+type JobRunUsecase interface {
+	StartJobRun(ctx context.Context, jobName string, params string, scheduledAt *time.Time) (*jobrun.JobRun, bool, error)
+	FinishJobRun(ctx context.Context, run *jobrun.JobRun, rowsProcessed int64, attempts int, runErr error) error
+	GetJobRuns(ctx context.Context, jobName string, limit int) ([]*jobrun.JobRun, error)
+}
+
+// jobRunUsecase : ジョブ実行履歴ユースケースの実装
+type jobRunUsecase struct {
+	jobRunRepo repository.JobRun
+}
+
+// NewJobRunUsecase : ジョブ実行履歴ユースケースの生成
+func NewJobRunUsecase(jobRunRepo repository.JobRun) JobRunUsecase {
+	return &jobRunUsecase{
+		jobRunRepo: jobRunRepo,
+	}
+}
+
+// StartJobRun : ジョブ実行の開始を記録
+// 同じジョブの同じ予定実行日時の実行が既に記録されている場合は記録せずfalseを返す
+func (j *jobRunUsecase) StartJobRun(ctx context.Context, jobName string, params string, scheduledAt *time.Time) (*jobrun.JobRun, bool, error) {
+	run, err := jobrun.NewJobRun(jobName, params, scheduledAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("ジョブ実行作成エラー: %w", err)
+	}
+
+	created, err := j.jobRunRepo.Create(ctx, run)
+	if err != nil {
+		return nil, false, fmt.Errorf("ジョブ実行保存エラー: %w", err)
+	}
+
+	return run, created, nil
+}
+
+// FinishJobRun : ジョブ実行の終了を記録（runErrがnilの場合は成功）
+func (j *jobRunUsecase) FinishJobRun(ctx context.Context, run *jobrun.JobRun, rowsProcessed int64, attempts int, runErr error) error {
+	run.Finish(rowsProcessed, attempts, runErr)
+
+	if err := j.jobRunRepo.Update(ctx, run); err != nil {
+		return fmt.Errorf("ジョブ実行更新エラー: %w", err)
+	}
+
+	return nil
+}
+
+// GetJobRuns : ジョブ実行履歴の取得（新しい順。jobNameが空の場合は全てのジョブ）
+func (j *jobRunUsecase) GetJobRuns(ctx context.Context, jobName string, limit int) ([]*jobrun.JobRun, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	runs, err := j.jobRunRepo.FindRecent(ctx, jobName, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ジョブ実行履歴取得エラー: %w", err)
+	}
+
+	return runs, nil
+}
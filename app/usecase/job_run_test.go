@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"myblog/app/domain/model/jobrun"
+
+	"github.com/google/uuid"
+)
+
+func TestJobRunUsecase_StartJobRun(t *testing.T) {
+	scheduledAt := time.Now().Truncate(time.Hour)
+	otherSchedule := scheduledAt.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		jobName     string
+		scheduledAt *time.Time
+		wantErr     string
+		wantCreated bool
+	}{
+		{name: "予定実行を記録できる", jobName: "ranking", scheduledAt: &otherSchedule, wantCreated: true},
+		{name: "同じ予定実行日時の実行は記録しない", jobName: "ranking", scheduledAt: &scheduledAt},
+		{name: "別のジョブの同じ予定実行日時の実行は記録する", jobName: "prune", scheduledAt: &scheduledAt, wantCreated: true},
+		{name: "手動実行は何度でも記録する", jobName: "ranking", wantCreated: true},
+		{name: "ジョブ名が空", jobName: "", wantErr: "ジョブ実行作成エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			uc := NewJobRunUsecase(repos.jobRuns)
+			// rankingの手動実行とscheduledAtの予定実行が記録済み
+			for _, s := range []*time.Time{nil, &scheduledAt} {
+				if _, created, err := uc.StartJobRun(ctx, "ranking", "", s); err != nil || !created {
+					t.Fatalf("前提のジョブ実行を記録できません: %v", err)
+				}
+			}
+
+			run, created, err := uc.StartJobRun(ctx, tt.jobName, "{}", tt.scheduledAt)
+			assertError(t, err, tt.wantErr)
+			if created != tt.wantCreated {
+				t.Errorf("記録の有無が異なります: got %v, want %v", created, tt.wantCreated)
+			}
+			if tt.wantErr == "" && run.Status() != jobrun.StatusRunning {
+				t.Errorf("開始したジョブ実行の状態が異なります: %s", run.Status())
+			}
+		})
+	}
+}
+
+func TestJobRunUsecase_FinishJobRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		runErr      error
+		wantStatus  jobrun.Status
+		wantMessage string
+	}{
+		{name: "成功を記録できる", wantStatus: jobrun.StatusSucceeded},
+		{name: "失敗を記録できる", runErr: errors.New("集計に失敗しました"), wantStatus: jobrun.StatusFailed, wantMessage: "集計に失敗しました"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			uc := NewJobRunUsecase(repos.jobRuns)
+			run, _, err := uc.StartJobRun(ctx, "ranking", "", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := uc.FinishJobRun(ctx, run, 42, 2, tt.runErr); err != nil {
+				t.Fatalf("エラーが発生しました: %v", err)
+			}
+
+			runs, err := uc.GetJobRuns(ctx, "ranking", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(runs) != 1 {
+				t.Fatalf("ジョブ実行の件数が異なります: %d", len(runs))
+			}
+			saved := runs[0]
+			if saved.Status() != tt.wantStatus || saved.ErrorMessage() != tt.wantMessage || saved.RowsProcessed() != 42 || saved.Attempts() != 2 || saved.FinishedAt() == nil {
+				t.Errorf("保存されたジョブ実行が異なります: %s, %q, %d, %d, %v", saved.Status(), saved.ErrorMessage(), saved.RowsProcessed(), saved.Attempts(), saved.FinishedAt())
+			}
+		})
+	}
+}
+
+func TestJobRunUsecase_GetJobRuns(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos()
+	uc := NewJobRunUsecase(repos.jobRuns)
+
+	// 開始日時が重複しないよう再構築したジョブ実行を記録する
+	base := time.Now().Truncate(time.Second).Add(-time.Hour)
+	var ranking, all []string
+	for i := 0; i < 25; i++ {
+		name := "ranking"
+		if i%5 == 0 {
+			name = "prune"
+		}
+		run, err := jobrun.Reconstruct(uuid.New().String(), name, "", nil, jobrun.StatusSucceeded, 0, 1, "", base.Add(time.Duration(i)*time.Minute), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repos.jobRuns.Create(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+		all = append([]string{run.ID().String()}, all...)
+		if name == "ranking" {
+			ranking = append([]string{run.ID().String()}, ranking...)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		jobName string
+		limit   int
+		want    []string
+	}{
+		{name: "件数の指定がない場合は20件取得する", want: all[:20]},
+		{name: "ジョブ名で絞り込める", jobName: "ranking", limit: 3, want: ranking[:3]},
+		{name: "件数を指定できる", limit: 30, want: all},
+		{name: "存在しないジョブ", jobName: "unknown", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs, err := uc.GetJobRuns(ctx, tt.jobName, tt.limit)
+			assertError(t, err, "")
+			got := make([]string, len(runs))
+			for i, run := range runs {
+				got[i] = run.ID().String()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ジョブ実行が異なります: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
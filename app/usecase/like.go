@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/like"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// LikeUsecase : いいねユースケースインターフェース
+type LikeUsecase interface {
+	LikeBlog(ctx context.Context, blogID, userID string) (*like.Summary, error)
+	UnlikeBlog(ctx context.Context, blogID, userID string) (*like.Summary, error)
+	GetSummaries(ctx context.Context, blogIDs []blog.ID, viewerID string) (map[string]*like.Summary, error)
+}
+
+// likeUsecase : いいねユースケースの実装
+type likeUsecase struct {
+	likeRepo repository.Like
+	blogRepo repository.Blog
+}
+
+// NewLikeUsecase : いいねユースケースの生成
+func NewLikeUsecase(likeRepo repository.Like, blogRepo repository.Blog) LikeUsecase {
+	return &likeUsecase{
+		likeRepo: likeRepo,
+		blogRepo: blogRepo,
+	}
+}
+
+// LikeBlog : ブログへのいいね（いいね済みの場合は何もしない）
+func (l *likeUsecase) LikeBlog(ctx context.Context, blogID, userID string) (*like.Summary, error) {
+	userIDObj, err := user.NewID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーID検証エラー: %w", err)
+	}
+
+	existingBlog, err := l.blogRepo.FindByID(ctx, blogID)
+	if err != nil {
+		return nil, fmt.Errorf("ブログ取得エラー: %w", err)
+	}
+
+	if err := l.likeRepo.Save(ctx, like.NewLike(existingBlog.ID(), *userIDObj)); err != nil {
+		return nil, fmt.Errorf("いいね保存エラー: %w", err)
+	}
+
+	return l.getSummary(ctx, existingBlog.ID(), userID)
+}
+
+// UnlikeBlog : ブログへのいいねの取り消し（未いいねの場合も成功とする）
+func (l *likeUsecase) UnlikeBlog(ctx context.Context, blogID, userID string) (*like.Summary, error) {
+	userIDObj, err := user.NewID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーID検証エラー: %w", err)
+	}
+
+	existingBlog, err := l.blogRepo.FindByID(ctx, blogID)
+	if err != nil {
+		return nil, fmt.Errorf("ブログ取得エラー: %w", err)
+	}
+
+	if err := l.likeRepo.Delete(ctx, existingBlog.ID(), *userIDObj); err != nil {
+		return nil, fmt.Errorf("いいね削除エラー: %w", err)
+	}
+
+	return l.getSummary(ctx, existingBlog.ID(), userID)
+}
+
+// GetSummaries : ブログIDごとのいいね集計を取得（viewerIDが空の場合はいいね済みかどうかを含めない）
+func (l *likeUsecase) GetSummaries(ctx context.Context, blogIDs []blog.ID, viewerID string) (map[string]*like.Summary, error) {
+	counts, err := l.likeRepo.CountByBlogIDs(ctx, blogIDs)
+	if err != nil {
+		return nil, fmt.Errorf("いいね集計エラー: %w", err)
+	}
+
+	liked := make(map[string]bool)
+	if viewerID != "" {
+		viewerIDObj, err := user.NewID(viewerID)
+		if err != nil {
+			return nil, fmt.Errorf("ユーザーID検証エラー: %w", err)
+		}
+
+		liked, err = l.likeRepo.FindLikedBlogIDs(ctx, *viewerIDObj, blogIDs)
+		if err != nil {
+			return nil, fmt.Errorf("いいね取得エラー: %w", err)
+		}
+	}
+
+	summaries := make(map[string]*like.Summary, len(blogIDs))
+	for _, blogID := range blogIDs {
+		summaries[blogID.String()] = &like.Summary{
+			Count: counts[blogID.String()],
+			Liked: liked[blogID.String()],
+		}
+	}
+
+	return summaries, nil
+}
+
+// getSummary : 単一ブログのいいね集計を取得
+func (l *likeUsecase) getSummary(ctx context.Context, blogID blog.ID, viewerID string) (*like.Summary, error) {
+	summaries, err := l.GetSummaries(ctx, []blog.ID{blogID}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return summaries[blogID.String()], nil
+}
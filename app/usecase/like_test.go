@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/like"
+)
+
+func TestLikeUsecase_LikeBlog(t *testing.T) {
+	tests := []struct {
+		name       string
+		blogID     func(blogID string) string
+		userID     func(bob string) string
+		likedTwice bool
+		wantErr    string
+		want       like.Summary
+	}{
+		{name: "いいねできる", want: like.Summary{Count: 2, Liked: true}},
+		{name: "いいね済みの場合は件数を増やさない", likedTwice: true, want: like.Summary{Count: 2, Liked: true}},
+		{name: "ユーザーIDが空", userID: func(string) string { return "" }, wantErr: "ユーザーID検証エラー"},
+		{name: "ブログIDが空", blogID: func(string) string { return "" }, wantErr: "ブログ取得エラー"},
+		{name: "存在しないブログ", blogID: func(string) string { return "unknown" }, wantErr: "ブログ取得エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			uc := NewLikeUsecase(repos.likes, repos.blogs)
+			if _, err := uc.LikeBlog(ctx, b.ID().String(), alice.ID().String()); err != nil {
+				t.Fatal(err)
+			}
+
+			blogID := b.ID().String()
+			if tt.blogID != nil {
+				blogID = tt.blogID(blogID)
+			}
+			userID := bob.ID().String()
+			if tt.userID != nil {
+				userID = tt.userID(userID)
+			}
+			if tt.likedTwice {
+				if _, err := uc.LikeBlog(ctx, blogID, userID); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := uc.LikeBlog(ctx, blogID, userID)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+			if *got != tt.want {
+				t.Errorf("いいね集計が異なります: got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLikeUsecase_UnlikeBlog(t *testing.T) {
+	tests := []struct {
+		name    string
+		liked   bool
+		blogID  func(blogID string) string
+		wantErr string
+		want    like.Summary
+	}{
+		{name: "いいねを取り消せる", liked: true, want: like.Summary{Count: 1}},
+		{name: "未いいねの場合も成功する", want: like.Summary{Count: 1}},
+		{name: "存在しないブログ", blogID: func(string) string { return "unknown" }, wantErr: "ブログ取得エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			uc := NewLikeUsecase(repos.likes, repos.blogs)
+			if _, err := uc.LikeBlog(ctx, b.ID().String(), alice.ID().String()); err != nil {
+				t.Fatal(err)
+			}
+			if tt.liked {
+				if _, err := uc.LikeBlog(ctx, b.ID().String(), bob.ID().String()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			blogID := b.ID().String()
+			if tt.blogID != nil {
+				blogID = tt.blogID(blogID)
+			}
+
+			got, err := uc.UnlikeBlog(ctx, blogID, bob.ID().String())
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+			if *got != tt.want {
+				t.Errorf("いいね集計が異なります: got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLikeUsecase_GetSummaries(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	bob := repos.createUser(t, "bob", "password")
+	popular := repos.createBlog(t, alice, "popular", "content")
+	quiet := repos.createBlog(t, alice, "quiet", "content")
+	uc := NewLikeUsecase(repos.likes, repos.blogs)
+	for _, u := range []string{alice.ID().String(), bob.ID().String()} {
+		if _, err := uc.LikeBlog(ctx, popular.ID().String(), u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := uc.LikeBlog(ctx, quiet.ID().String(), alice.ID().String()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		viewerID string
+		want     map[string]like.Summary
+	}{
+		{
+			name:     "閲覧ユーザーのいいねを含む",
+			viewerID: bob.ID().String(),
+			want: map[string]like.Summary{
+				popular.ID().String(): {Count: 2, Liked: true},
+				quiet.ID().String():   {Count: 1},
+			},
+		},
+		{
+			name: "未ログインの場合はいいね済みを含めない",
+			want: map[string]like.Summary{
+				popular.ID().String(): {Count: 2},
+				quiet.ID().String():   {Count: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summaries, err := uc.GetSummaries(ctx, []blog.ID{popular.ID(), quiet.ID()}, tt.viewerID)
+			assertError(t, err, "")
+			if len(summaries) != len(tt.want) {
+				t.Fatalf("集計の件数が異なります: got %d, want %d", len(summaries), len(tt.want))
+			}
+			for id, want := range tt.want {
+				if got := summaries[id]; got == nil || *got != want {
+					t.Errorf("%sの集計が異なります: got %+v, want %+v", id, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/mention"
+	"myblog/app/domain/model/notification"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// MentionUsecase : メンションユースケースインターフェース
+type MentionUsecase interface {
+	GetBlogMentions(ctx context.Context, blogIDs []blog.ID) (map[string][]*mention.Mention, error)
+	GetCommentMentions(ctx context.Context, commentIDs []comment.ID) (map[string][]*mention.Mention, error)
+}
+
+// mentionUsecase : メンションユースケースの実装
+type mentionUsecase struct {
+	mentionRepo repository.Mention
+}
+
+// NewMentionUsecase : メンションユースケースの生成
+func NewMentionUsecase(mentionRepo repository.Mention) MentionUsecase {
+	return &mentionUsecase{
+		mentionRepo: mentionRepo,
+	}
+}
+
+// GetBlogMentions : ブログ本文内のメンションをブログIDごとに取得
+func (m *mentionUsecase) GetBlogMentions(ctx context.Context, blogIDs []blog.ID) (map[string][]*mention.Mention, error) {
+	mentions, err := m.mentionRepo.FindByBlogIDs(ctx, blogIDs)
+	if err != nil {
+		return nil, fmt.Errorf("メンション取得エラー: %w", err)
+	}
+
+	result := make(map[string][]*mention.Mention)
+	for _, mention := range mentions {
+		key := mention.BlogID().String()
+		result[key] = append(result[key], mention)
+	}
+	return result, nil
+}
+
+// GetCommentMentions : コメント内のメンションをコメントIDごとに取得
+func (m *mentionUsecase) GetCommentMentions(ctx context.Context, commentIDs []comment.ID) (map[string][]*mention.Mention, error) {
+	mentions, err := m.mentionRepo.FindByCommentIDs(ctx, commentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("メンション取得エラー: %w", err)
+	}
+
+	result := make(map[string][]*mention.Mention)
+	for _, mention := range mentions {
+		key := mention.CommentID().String()
+		result[key] = append(result[key], mention)
+	}
+	return result, nil
+}
+
+// mentionRecorder : 本文中のメンションを記録し、メンションされたユーザーへ通知する
+type mentionRecorder struct {
+	userRepo      repository.User
+	mentionRepo   repository.Mention
+	notifications *notificationSender
+}
+
+// record : 本文からメンションを抽出して保存し、メンションされたユーザーのIDを返す
+// previousに含まれるユーザーは既に通知済みとみなし、再通知しない
+func (m *mentionRecorder) record(ctx context.Context, actorID user.ID, blogID blog.ID, commentID *comment.ID, content string, previous []*mention.Mention) ([]user.ID, error) {
+	usernames := mention.ExtractUsernames(content)
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	// 存在しないユーザーへのメンションは無視する
+	users, err := m.userRepo.FindByUsernames(ctx, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("メンション先ユーザー取得エラー: %w", err)
+	}
+
+	notified := make(map[string]bool)
+	for _, p := range previous {
+		notified[p.UserID().String()] = true
+	}
+
+	resolved := make(map[string]bool)
+	var mentioned []user.ID
+	var mentions []*mention.Mention
+	var notifications []*notification.Notification
+	for _, u := range users {
+		// 同名ユーザーが複数いる場合は最も古いユーザーを優先する
+		key := strings.ToLower(u.Username())
+		if resolved[key] {
+			continue
+		}
+		resolved[key] = true
+
+		newMention, err := mention.NewMention(blogID, commentID, u.ID(), u.Username())
+		if err != nil {
+			return nil, fmt.Errorf("メンション作成エラー: %w", err)
+		}
+		mentions = append(mentions, newMention)
+		mentioned = append(mentioned, u.ID())
+
+		// 自分自身へのメンションと通知済みのユーザーには通知しない
+		if u.ID().String() == actorID.String() || notified[u.ID().String()] {
+			continue
+		}
+
+		newNotification, err := notification.NewNotification(u.ID(), actorID, notification.TypeMention, blogID, commentID)
+		if err != nil {
+			return nil, fmt.Errorf("通知作成エラー: %w", err)
+		}
+		notifications = append(notifications, newNotification)
+	}
+
+	if err := m.mentionRepo.SaveAll(ctx, mentions); err != nil {
+		return nil, fmt.Errorf("メンション保存エラー: %w", err)
+	}
+
+	if err := m.notifications.send(ctx, notifications); err != nil {
+		return nil, err
+	}
+
+	return mentioned, nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/mention"
+)
+
+// mentionedUsernames : メンションIDごとのメンション先ユーザー名
+func mentionedUsernames(grouped map[string][]*mention.Mention) map[string][]string {
+	usernames := make(map[string][]string, len(grouped))
+	for id, mentions := range grouped {
+		for _, m := range mentions {
+			usernames[id] = append(usernames[id], m.Username())
+		}
+	}
+	return usernames
+}
+
+func TestMentionUsecase(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	bob := repos.createUser(t, "bob", "password")
+	repos.createUser(t, "carol", "password")
+
+	blogUsecase := newTestBlogUsecase(repos, &recordingViews{})
+	commentUsecase := newTestCommentUsecase(repos, &recordingStream{})
+	mentioned, err := blogUsecase.CreateBlog(ctx, alice.ID().String(), "title", "hi @bob and @carol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := blogUsecase.CreateBlog(ctx, alice.ID().String(), "title", "no mentions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := commentUsecase.CreateComment(ctx, mentioned.ID().String(), bob.ID().String(), "thanks @alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uc := NewMentionUsecase(repos.mentions)
+
+	t.Run("ブログIDごとにブログ本文内のメンションを取得できる", func(t *testing.T) {
+		grouped, err := uc.GetBlogMentions(ctx, []blog.ID{mentioned.ID(), plain.ID()})
+		assertError(t, err, "")
+		want := map[string][]string{mentioned.ID().String(): {"bob", "carol"}}
+		if got := mentionedUsernames(grouped); !reflect.DeepEqual(got, want) {
+			t.Errorf("メンションが異なります: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("コメントIDごとにコメント内のメンションを取得できる", func(t *testing.T) {
+		grouped, err := uc.GetCommentMentions(ctx, []comment.ID{c.ID()})
+		assertError(t, err, "")
+		want := map[string][]string{c.ID().String(): {"alice"}}
+		if got := mentionedUsernames(grouped); !reflect.DeepEqual(got, want) {
+			t.Errorf("メンションが異なります: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("IDが指定されていない場合は空", func(t *testing.T) {
+		grouped, err := uc.GetBlogMentions(ctx, nil)
+		assertError(t, err, "")
+		if len(grouped) != 0 {
+			t.Errorf("メンションが取得されました: %v", grouped)
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/comment"
+	"myblog/app/domain/model/notification"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// NotificationUsecase : 通知ユースケースインターフェース
+type NotificationUsecase interface {
+	GetNotifications(ctx context.Context, userID string, cursor string, unreadOnly bool, limit int) (*NotificationPage, error)
+	GetUnreadCount(ctx context.Context, userID string) (int, error)
+	MarkRead(ctx context.Context, userID string, ids []string) (int, error)
+	MarkAllRead(ctx context.Context, userID string) error
+	GetPreferences(ctx context.Context, userID string) (*notification.Preferences, error)
+	UpdatePreferences(ctx context.Context, userID string, enabled map[string]bool) (*notification.Preferences, error)
+}
+
+// NotificationPage : 通知一覧の1ページ
+type NotificationPage struct {
+	Notifications []*notification.Notification
+	NextCursor    string // 次のページのカーソル（次のページがない場合は空）
+	UnreadCount   int    // 未読の通知の総数
+}
+
+// notificationUsecase : 通知ユースケースの実装
+type notificationUsecase struct {
+	notificationRepo repository.Notification
+	preferenceRepo   repository.NotificationPreference
+}
+
+// NewNotificationUsecase : 通知ユースケースの生成
+func NewNotificationUsecase(notificationRepo repository.Notification, preferenceRepo repository.NotificationPreference) NotificationUsecase {
+	return &notificationUsecase{
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+	}
+}
+
+// GetNotifications : ユーザーの通知一覧取得（新しい順）
+// cursorには前のページのNextCursorを指定する（最初のページは空）
+func (n *notificationUsecase) GetNotifications(ctx context.Context, userID string, cursor string, unreadOnly bool, limit int) (*NotificationPage, error) {
+	userIDObj, err := user.NewID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーID検証エラー: %w", err)
+	}
+
+	var cursorObj *notification.Cursor
+	if cursor != "" {
+		cursorObj, err = notification.ParseCursor(cursor)
+		if err != nil {
+			return nil, fmt.Errorf("カーソル検証エラー: %w", err)
+		}
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+
+	// 次のページの有無を判定するため1件多く取得する
+	notifications, err := n.notificationRepo.FindByUserID(ctx, *userIDObj, cursorObj, unreadOnly, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("通知一覧取得エラー: %w", err)
+	}
+
+	page := &NotificationPage{Notifications: notifications}
+	if len(notifications) > limit {
+		page.Notifications = notifications[:limit]
+		page.NextCursor = notification.NewCursor(notifications[limit-1]).Encode()
+	}
+
+	page.UnreadCount, err = n.notificationRepo.CountUnread(ctx, *userIDObj)
+	if err != nil {
+		return nil, fmt.Errorf("未読件数取得エラー: %w", err)
+	}
+
+	return page, nil
+}
+
+// GetUnreadCount : ユーザーの未読の通知の件数取得
+func (n *notificationUsecase) GetUnreadCount(ctx context.Context, userID string) (int, error) {
+	userIDObj, err := user.NewID(userID)
+	if err != nil {
+		return 0, fmt.Errorf("ユーザーID検証エラー: %w", err)
+	}
+
+	count, err := n.notificationRepo.CountUnread(ctx, *userIDObj)
+	if err != nil {
+		return 0, fmt.Errorf("未読件数取得エラー: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead : 通知の既読化（他のユーザーの通知は無視する）。既読化後の未読件数を返す
+func (n *notificationUsecase) MarkRead(ctx context.Context, userID string, ids []string) (int, error) {
+	userIDObj, err := user.NewID(userID)
+	if err != nil {
+		return 0, fmt.Errorf("ユーザーID検証エラー: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, errors.New("既読にする通知が指定されていません")
+	}
+
+	idObjs := make([]notification.ID, len(ids))
+	for i, id := range ids {
+		idObj, err := notification.NewID(id)
+		if err != nil {
+			return 0, fmt.Errorf("通知ID検証エラー: %w", err)
+		}
+		idObjs[i] = *idObj
+	}
+
+	if err := n.notificationRepo.MarkRead(ctx, *userIDObj, idObjs, time.Now()); err != nil {
+		return 0, fmt.Errorf("既読化エラー: %w", err)
+	}
+
+	return n.GetUnreadCount(ctx, userID)
+}
+
+// MarkAllRead : ユーザーの全ての通知の既読化
+func (n *notificationUsecase) MarkAllRead(ctx context.Context, userID string) error {
+	userIDObj, err := user.NewID(userID)
+	if err != nil {
+		return fmt.Errorf("ユーザーID検証エラー: %w", err)
+	}
+
+	if err := n.notificationRepo.MarkAllRead(ctx, *userIDObj, time.Now()); err != nil {
+		return fmt.Errorf("既読化エラー: %w", err)
+	}
+	return nil
+}
+
+// GetPreferences : ユーザーの通知設定取得
+func (n *notificationUsecase) GetPreferences(ctx context.Context, userID string) (*notification.Preferences, error) {
+	userIDObj, err := user.NewID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーID検証エラー: %w", err)
+	}
+
+	preferences, err := n.preferenceRepo.FindByUserIDs(ctx, []user.ID{*userIDObj})
+	if err != nil {
+		return nil, fmt.Errorf("通知設定取得エラー: %w", err)
+	}
+	return preferences[userIDObj.String()], nil
+}
+
+// UpdatePreferences : ユーザーの通知設定の更新（指定されなかった通知種別の設定は変更しない）
+func (n *notificationUsecase) UpdatePreferences(ctx context.Context, userID string, enabled map[string]bool) (*notification.Preferences, error) {
+	preferences, err := n.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for typeStr, e := range enabled {
+		t, err := notification.ParseType(typeStr)
+		if err != nil {
+			return nil, fmt.Errorf("通知設定検証エラー: %w", err)
+		}
+		if err := preferences.Set(t, e); err != nil {
+			return nil, fmt.Errorf("通知設定検証エラー: %w", err)
+		}
+	}
+
+	if err := n.preferenceRepo.Save(ctx, preferences); err != nil {
+		return nil, fmt.Errorf("通知設定保存エラー: %w", err)
+	}
+	return preferences, nil
+}
+
+// notificationSender : 受け取るユーザーの通知設定に従って通知を保存する
+type notificationSender struct {
+	notificationRepo repository.Notification
+	preferenceRepo   repository.NotificationPreference
+}
+
+// send : 通知の保存（受け取らない設定の通知種別の通知は保存しない）
+func (s *notificationSender) send(ctx context.Context, notifications []*notification.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	userIDs := make([]user.ID, len(notifications))
+	for i, n := range notifications {
+		userIDs[i] = n.UserID()
+	}
+	preferences, err := s.preferenceRepo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return fmt.Errorf("通知設定取得エラー: %w", err)
+	}
+
+	var enabled []*notification.Notification
+	for _, n := range notifications {
+		if p, ok := preferences[n.UserID().String()]; ok && !p.Enabled(n.Type()) {
+			continue
+		}
+		enabled = append(enabled, n)
+	}
+
+	if err := s.notificationRepo.SaveAll(ctx, enabled); err != nil {
+		return fmt.Errorf("通知保存エラー: %w", err)
+	}
+	return nil
+}
+
+// commentNotifier : コメントの投稿をブログの著者と他のコメント投稿者へ通知する
+type commentNotifier struct {
+	commentRepo   repository.Comment
+	notifications *notificationSender
+}
+
+// notify : ブログの著者へコメント、ブログにコメントした他のユーザーへ返信を通知する
+// コメントの投稿者自身と、コメント内でメンションされたユーザー（メンションとして通知済み）には通知しない
+func (c *commentNotifier) notify(ctx context.Context, b *blog.Blog, newComment *comment.Comment, mentioned []user.ID) error {
+	skip := map[string]bool{newComment.UserID().String(): true}
+	for _, id := range mentioned {
+		skip[id.String()] = true
+	}
+
+	commentID := newComment.ID()
+	var notifications []*notification.Notification
+
+	if !skip[b.UserID().String()] {
+		n, err := notification.NewNotification(b.UserID(), newComment.UserID(), notification.TypeComment, b.ID(), &commentID)
+		if err != nil {
+			return fmt.Errorf("通知作成エラー: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	skip[b.UserID().String()] = true
+
+	comments, err := c.commentRepo.FindByBlogID(ctx, b.ID())
+	if err != nil {
+		return fmt.Errorf("コメント取得エラー: %w", err)
+	}
+	for _, existing := range comments {
+		if skip[existing.UserID().String()] {
+			continue
+		}
+		skip[existing.UserID().String()] = true
+
+		n, err := notification.NewNotification(existing.UserID(), newComment.UserID(), notification.TypeReply, b.ID(), &commentID)
+		if err != nil {
+			return fmt.Errorf("通知作成エラー: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	return c.notifications.send(ctx, notifications)
+}
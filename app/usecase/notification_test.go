@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/notification"
+	"myblog/app/domain/model/user"
+
+	"github.com/google/uuid"
+)
+
+// seedNotifications : 作成日時が1分ずつ異なる通知の保存（readの数だけ古い通知を既読にする）。新しい順に返す
+func (r *testRepos) seedNotifications(t *testing.T, to, from *user.User, b *blog.Blog, count, read int) []*notification.Notification {
+	t.Helper()
+
+	// 保存時に丸められないよう秒単位の日時を使用する
+	base := time.Now().Truncate(time.Second).Add(-time.Hour)
+	notifications := make([]*notification.Notification, count)
+	for i := 0; i < count; i++ {
+		createdAt := base.Add(time.Duration(i) * time.Minute)
+		var readAt *time.Time
+		if i < read {
+			readAt = &createdAt
+		}
+		n, err := notification.Reconstruct(uuid.New().String(), to.ID(), from.ID(), notification.TypeComment, b.ID(), nil, readAt, createdAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		notifications[count-1-i] = n
+	}
+	if err := r.notifications.SaveAll(context.Background(), notifications); err != nil {
+		t.Fatalf("通知の保存に失敗しました: %v", err)
+	}
+	return notifications
+}
+
+// notificationIDsOf : 通知のIDの一覧
+func notificationIDsOf(notifications []*notification.Notification) []string {
+	ids := make([]string, len(notifications))
+	for i, n := range notifications {
+		ids[i] = n.ID().String()
+	}
+	return ids
+}
+
+func TestNotificationUsecase_GetNotifications(t *testing.T) {
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	bob := repos.createUser(t, "bob", "password")
+	b := repos.createBlog(t, alice, "title", "content")
+	seeded := repos.seedNotifications(t, alice, bob, b, 5, 2)
+	repos.seedNotifications(t, bob, alice, b, 1, 0)
+	uc := NewNotificationUsecase(repos.notifications, repos.preferences)
+
+	tests := []struct {
+		name           string
+		userID         string
+		cursor         string
+		unreadOnly     bool
+		limit          int
+		wantErr        string
+		wantErrIs      error
+		want           []*notification.Notification
+		wantNextCursor string
+	}{
+		{name: "新しい順に取得できる", userID: alice.ID().String(), want: seeded},
+		{name: "次のページがある場合はカーソルを返す", userID: alice.ID().String(), limit: 2, want: seeded[:2], wantNextCursor: notification.NewCursor(seeded[1]).Encode()},
+		{name: "カーソル以降を取得できる", userID: alice.ID().String(), cursor: notification.NewCursor(seeded[1]).Encode(), limit: 2, want: seeded[2:4], wantNextCursor: notification.NewCursor(seeded[3]).Encode()},
+		{name: "最後のページはカーソルを返さない", userID: alice.ID().String(), cursor: notification.NewCursor(seeded[3]).Encode(), limit: 2, want: seeded[4:]},
+		{name: "未読のみ取得できる", userID: alice.ID().String(), unreadOnly: true, want: seeded[:3]},
+		{name: "ユーザーIDが空", userID: "", wantErr: "ユーザーID検証エラー"},
+		{name: "不正なカーソル", userID: alice.ID().String(), cursor: "!!!", wantErr: "カーソル検証エラー", wantErrIs: notification.ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := uc.GetNotifications(context.Background(), tt.userID, tt.cursor, tt.unreadOnly, tt.limit)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("エラーが異なります: got %v, want %v", err, tt.wantErrIs)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if got, want := notificationIDsOf(page.Notifications), notificationIDsOf(tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("通知が異なります: got %v, want %v", got, want)
+			}
+			if page.NextCursor != tt.wantNextCursor {
+				t.Errorf("次のページのカーソルが異なります: got %q, want %q", page.NextCursor, tt.wantNextCursor)
+			}
+			if page.UnreadCount != 3 {
+				t.Errorf("未読件数が異なります: %d", page.UnreadCount)
+			}
+		})
+	}
+}
+
+func TestNotificationUsecase_MarkRead(t *testing.T) {
+	tests := []struct {
+		name       string
+		ids        func(alice, bob []*notification.Notification) []string
+		wantErr    string
+		wantUnread int
+	}{
+		{name: "既読にできる", ids: func(alice, _ []*notification.Notification) []string { return notificationIDsOf(alice[:2]) }, wantUnread: 1},
+		{name: "他のユーザーの通知は無視する", ids: func(alice, bob []*notification.Notification) []string {
+			return append(notificationIDsOf(alice[:1]), notificationIDsOf(bob)...)
+		}, wantUnread: 2},
+		{name: "通知が指定されていない", ids: func(_, _ []*notification.Notification) []string { return nil }, wantErr: "既読にする通知が指定されていません", wantUnread: 3},
+		{name: "空の通知ID", ids: func(_, _ []*notification.Notification) []string { return []string{""} }, wantErr: "通知ID検証エラー", wantUnread: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			aliceNotifications := repos.seedNotifications(t, alice, bob, b, 3, 0)
+			bobNotifications := repos.seedNotifications(t, bob, alice, b, 1, 0)
+			uc := NewNotificationUsecase(repos.notifications, repos.preferences)
+
+			unread, err := uc.MarkRead(ctx, alice.ID().String(), tt.ids(aliceNotifications, bobNotifications))
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr == "" && unread != tt.wantUnread {
+				t.Errorf("返された未読件数が異なります: got %d, want %d", unread, tt.wantUnread)
+			}
+
+			if got, err := uc.GetUnreadCount(ctx, alice.ID().String()); err != nil || got != tt.wantUnread {
+				t.Errorf("aliceの未読件数が異なります: got %d (%v), want %d", got, err, tt.wantUnread)
+			}
+			if got, err := uc.GetUnreadCount(ctx, bob.ID().String()); err != nil || got != 1 {
+				t.Errorf("bobの通知が既読になっています: %d (%v)", got, err)
+			}
+		})
+	}
+}
+
+func TestNotificationUsecase_MarkAllRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  func(alice string) string
+		wantErr string
+	}{
+		{name: "全て既読にできる"},
+		{name: "ユーザーIDが空", userID: func(string) string { return "" }, wantErr: "ユーザーID検証エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			repos.seedNotifications(t, alice, bob, b, 3, 1)
+			repos.seedNotifications(t, bob, alice, b, 1, 0)
+			uc := NewNotificationUsecase(repos.notifications, repos.preferences)
+
+			userID := alice.ID().String()
+			if tt.userID != nil {
+				userID = tt.userID(userID)
+			}
+
+			err := uc.MarkAllRead(ctx, userID)
+			assertError(t, err, tt.wantErr)
+
+			wantUnread := 0
+			if tt.wantErr != "" {
+				wantUnread = 2
+			}
+			if got, err := uc.GetUnreadCount(ctx, alice.ID().String()); err != nil || got != wantUnread {
+				t.Errorf("aliceの未読件数が異なります: got %d (%v), want %d", got, err, wantUnread)
+			}
+			if got, err := uc.GetUnreadCount(ctx, bob.ID().String()); err != nil || got != 1 {
+				t.Errorf("bobの通知が既読になっています: %d (%v)", got, err)
+			}
+		})
+	}
+}
+
+func TestNotificationUsecase_UpdatePreferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  func(alice string) string
+		enabled map[string]bool
+		wantErr string
+		want    map[notification.Type]bool
+	}{
+		{
+			name:    "指定した通知種別のみ変更する",
+			enabled: map[string]bool{"reply": false},
+			want:    map[notification.Type]bool{notification.TypeMention: false, notification.TypeComment: true, notification.TypeReply: false},
+		},
+		{
+			name:    "無効にした通知種別を再度有効にできる",
+			enabled: map[string]bool{"mention": true},
+			want:    map[notification.Type]bool{notification.TypeMention: true, notification.TypeComment: true, notification.TypeReply: true},
+		},
+		{
+			name:    "不正な通知種別",
+			enabled: map[string]bool{"reply": false, "follow": true},
+			wantErr: "通知設定検証エラー",
+			want:    map[notification.Type]bool{notification.TypeMention: false, notification.TypeComment: true, notification.TypeReply: true},
+		},
+		{name: "ユーザーIDが空", userID: func(string) string { return "" }, enabled: map[string]bool{"reply": false}, wantErr: "ユーザーID検証エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			uc := NewNotificationUsecase(repos.notifications, repos.preferences)
+
+			// メンションの通知は無効にしている
+			if _, err := uc.UpdatePreferences(ctx, alice.ID().String(), map[string]bool{"mention": false}); err != nil {
+				t.Fatal(err)
+			}
+
+			userID := alice.ID().String()
+			if tt.userID != nil {
+				userID = tt.userID(userID)
+			}
+
+			_, err := uc.UpdatePreferences(ctx, userID, tt.enabled)
+			assertError(t, err, tt.wantErr)
+			if tt.want == nil {
+				return
+			}
+
+			saved, err := uc.GetPreferences(ctx, alice.ID().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := saved.All(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("保存された通知設定が異なります: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
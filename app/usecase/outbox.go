@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"myblog/app/domain/model/event"
+	"myblog/app/domain/repository"
+)
+
+// EventHandler : ドメインイベントの購読処理
+// 配信は少なくとも1回（at-least-once）のため、同じイベントが複数回渡されても問題ないように実装すること
+// （イベントのIdempotencyKeyで重複を検出できる）
+type EventHandler func(ctx context.Context, e *event.Event) error
+
+// subscription : 購読者
+type subscription struct {
+	name      string
+	eventType event.Type
+	handler   EventHandler
+}
+
+// EventBus : プロセス内のドメインイベントの購読者の登録先
+type EventBus struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+}
+
+// NewEventBus : EventBusの生成
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe : 購読者の登録
+// nameは配信済みの記録に使用するため、購読者ごとに一意かつ変更しない名前を指定する
+func (b *EventBus) Subscribe(name string, eventType event.Type, handler EventHandler) error {
+	if name == "" {
+		return errors.New("購読者名が空です")
+	}
+	if handler == nil {
+		return errors.New("購読処理が空です")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, s := range b.subscriptions {
+		if s.name == name {
+			return fmt.Errorf("購読者が既に登録されています: %s", name)
+		}
+	}
+	b.subscriptions = append(b.subscriptions, subscription{name: name, eventType: eventType, handler: handler})
+	return nil
+}
+
+// subscribers : イベント種別の購読者の取得
+func (b *EventBus) subscribers(eventType event.Type) []subscription {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var subscriptions []subscription
+	for _, s := range b.subscriptions {
+		if s.eventType == eventType {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	return subscriptions
+}
+
+// OutboxRelay : アウトボックスに記録されたイベントを購読者に配信する
+// 購読者ごとに配信完了を記録し、失敗した購読者にのみ再配信する
+type OutboxRelay struct {
+	outboxRepo repository.Outbox
+	bus        *EventBus
+	// 配信に失敗したイベントの再配信までの待機時間（失敗のたびに2倍にし、maxBackoffを上限とする）
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// NewOutboxRelay : OutboxRelayの生成
+func NewOutboxRelay(outboxRepo repository.Outbox, bus *EventBus) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo:     outboxRepo,
+		bus:            bus,
+		initialBackoff: 10 * time.Second,
+		maxBackoff:     time.Hour,
+	}
+}
+
+// RelayOnce : 未配信のイベントを最大limit件配信し、全ての購読者への配信が完了した件数を返す
+func (r *OutboxRelay) RelayOnce(ctx context.Context, limit int) (int, error) {
+	events, err := r.outboxRepo.FindPending(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("未配信イベント取得エラー: %w", err)
+	}
+
+	published := 0
+	for _, e := range events {
+		if err := ctx.Err(); err != nil {
+			return published, err
+		}
+
+		if deliverErr := r.deliver(ctx, e); deliverErr != nil {
+			log.Printf("イベントの配信に失敗しました（ID: %s, 種別: %s）: %v", e.ID().String(), e.Type(), deliverErr)
+			if err := r.outboxRepo.MarkFailed(ctx, e.ID(), deliverErr.Error(), time.Now().Add(r.backoff(e.Attempts()))); err != nil {
+				return published, fmt.Errorf("配信失敗の記録エラー: %w", err)
+			}
+			continue
+		}
+
+		if err := r.outboxRepo.MarkPublished(ctx, e.ID(), time.Now()); err != nil {
+			return published, fmt.Errorf("配信完了の記録エラー: %w", err)
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// deliver : 配信が完了していない購読者にイベントを配信する
+func (r *OutboxRelay) deliver(ctx context.Context, e *event.Event) error {
+	delivered, err := r.outboxRepo.FindDeliveredSubscribers(ctx, e.ID())
+	if err != nil {
+		return fmt.Errorf("配信済み購読者取得エラー: %w", err)
+	}
+	done := make(map[string]bool, len(delivered))
+	for _, name := range delivered {
+		done[name] = true
+	}
+
+	var errs []error
+	for _, s := range r.bus.subscribers(e.Type()) {
+		if done[s.name] {
+			continue
+		}
+		if err := s.handler(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+			continue
+		}
+		if err := r.outboxRepo.SaveDelivery(ctx, e.ID(), s.name, time.Now()); err != nil {
+			errs = append(errs, fmt.Errorf("%s: 配信完了の記録エラー: %w", s.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// backoff : attempts回失敗したイベントの再配信までの待機時間
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	backoff := r.initialBackoff
+	for i := 0; i < attempts && backoff < r.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > r.maxBackoff {
+		backoff = r.maxBackoff
+	}
+	return backoff
+}
+
+// Run : ctxがキャンセルされるまでinterval間隔で未配信のイベントを配信する
+// 未配信のイベントがlimit件以上ある場合は待機せずに続けて配信する
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration, limit int) error {
+	for {
+		published, err := r.RelayOnce(ctx, limit)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("イベントの配信中にエラーが発生しました: %v", err)
+		}
+		if err == nil && published >= limit {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
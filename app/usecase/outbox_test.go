@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"myblog/app/domain/model/event"
+)
+
+func TestEventBus_Subscribe(t *testing.T) {
+	noop := func(context.Context, *event.Event) error { return nil }
+
+	tests := []struct {
+		name    string
+		subName string
+		handler EventHandler
+		wantErr string
+	}{
+		{name: "登録できる", subName: "mailer", handler: noop},
+		{name: "購読者名が空", subName: "", handler: noop, wantErr: "購読者名が空です"},
+		{name: "購読処理が空", subName: "mailer", wantErr: "購読処理が空です"},
+		{name: "購読者名が重複している", subName: "search", handler: noop, wantErr: "購読者が既に登録されています"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewEventBus()
+			if err := bus.Subscribe("search", event.TypeBlogPublished, noop); err != nil {
+				t.Fatal(err)
+			}
+
+			err := bus.Subscribe(tt.subName, event.TypeUserRegistered, tt.handler)
+			assertError(t, err, tt.wantErr)
+		})
+	}
+}
+
+// handlerLog : 購読処理の呼び出しの記録
+type handlerLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+// handler : 呼び出しを記録し、failの間は失敗する購読処理
+func (l *handlerLog) handler(name string, fail *bool) EventHandler {
+	return func(ctx context.Context, e *event.Event) error {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.calls = append(l.calls, name)
+		if fail != nil && *fail {
+			return errInjected
+		}
+		return nil
+	}
+}
+
+// take : 記録した呼び出しを取り出す
+func (l *handlerLog) take() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	calls := l.calls
+	l.calls = nil
+	return calls
+}
+
+func TestOutboxRelay_RelayOnce(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	registered, err := event.NewUserRegistered(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.outbox.Save(ctx, registered); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := &handlerLog{}
+	mailerFails := true
+	bus := NewEventBus()
+	for _, s := range []struct {
+		name      string
+		eventType event.Type
+		fail      *bool
+	}{
+		{"mailer", event.TypeUserRegistered, &mailerFails},
+		{"search", event.TypeUserRegistered, nil},
+		{"feed", event.TypeBlogPublished, nil},
+	} {
+		if err := bus.Subscribe(s.name, s.eventType, calls.handler(s.name, s.fail)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	relay := NewOutboxRelay(repos.outbox, bus)
+	relay.initialBackoff = 0
+
+	steps := []struct {
+		name          string
+		mailerFails   bool
+		wantPublished int
+		wantCalls     []string
+		wantPending   int
+	}{
+		{name: "購読者の一部が失敗した場合は未配信のまま", mailerFails: true, wantCalls: []string{"mailer", "search"}, wantPending: 1},
+		{name: "失敗した購読者にのみ再配信する", mailerFails: false, wantPublished: 1, wantCalls: []string{"mailer"}},
+		{name: "配信済みのイベントは配信しない", wantCalls: nil},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			mailerFails = step.mailerFails
+
+			published, err := relay.RelayOnce(ctx, 10)
+			assertError(t, err, "")
+			if published != step.wantPublished {
+				t.Errorf("配信が完了した件数が異なります: got %d, want %d", published, step.wantPublished)
+			}
+			if got := calls.take(); !reflect.DeepEqual(got, step.wantCalls) {
+				t.Errorf("呼び出された購読処理が異なります: got %v, want %v", got, step.wantCalls)
+			}
+			if got := len(repos.pendingEvents(t)); got != step.wantPending {
+				t.Errorf("未配信のイベントの件数が異なります: got %d, want %d", got, step.wantPending)
+			}
+		})
+	}
+}
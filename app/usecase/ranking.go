@@ -1,68 +1,343 @@
 package usecase
 
 import (
+	"container/heap"
 	"context"
 	"fmt"
+	"log"
+	"sort"
+	"time"
 
 	"myblog/app/domain/model/blog"
 	"myblog/app/domain/model/ranking"
+	"myblog/app/domain/model/user"
 	"myblog/app/domain/repository"
-	"myblog/app/infra/db/rdb"
 	"myblog/app/infra/query"
 )
 
+// BlogStatsQuery はランキングの集計に使用するブログの統計情報のクエリ（実装はquery.BlogStats）
+type BlogStatsQuery interface {
+	// ForEachBlogRankingData は指定日時以降に閲覧・いいね・コメントのいずれかがあったブログのランキングデータを1件ずつfnに渡す
+	ForEachBlogRankingData(ctx context.Context, since time.Time, fn func(data query.BlogRankingData) error) error
+}
+
+// RankingListQuery は公開済みのランキングを参照するクエリ（実装はquery.RankingList）
+type RankingListQuery interface {
+	// GetPopularRanking は指定した種別の最新の人気記事ランキングを上位limit件取得する
+	GetPopularRanking(ctx context.Context, rankingType ranking.Type, limit int) (*query.PopularRanking, error)
+	// GetAuthorBlogRanking は指定した種別の最新の著者の人気記事ランキングを上位limit件取得する
+	GetAuthorBlogRanking(ctx context.Context, userID string, rankingType ranking.Type, limit int) (*query.PopularRanking, error)
+	// GetAuthorRanking は指定した種別の最新の著者ランキングを上位limit件取得する
+	GetAuthorRanking(ctx context.Context, rankingType ranking.Type, limit int) (*query.AuthorRanking, error)
+	// GetBlogRankingHistory は指定日時以降のブログの順位の推移を取得する
+	GetBlogRankingHistory(ctx context.Context, blogID string, rankingType ranking.Type, since time.Time) ([]query.RankingHistoryPoint, error)
+}
+
 // RankingUseCase はランキングに関するユースケース
 type RankingUseCase struct {
 	rankingRepository repository.RankingRepository
-	blogStatsQuery    *query.BlogStats
-	txManager         rdb.TransactionManager
+	blogStatsQuery    BlogStatsQuery
+	rankingListQuery  RankingListQuery
 }
 
 // NewRankingUseCase はRankingUseCaseのコンストラクタ
 func NewRankingUseCase(
 	rankingRepository repository.RankingRepository,
-	blogStatsQuery *query.BlogStats,
-	txManager rdb.TransactionManager,
+	blogStatsQuery BlogStatsQuery,
+	rankingListQuery RankingListQuery,
 ) *RankingUseCase {
 	return &RankingUseCase{
 		rankingRepository: rankingRepository,
 		blogStatsQuery:    blogStatsQuery,
-		txManager:         txManager,
+		rankingListQuery:  rankingListQuery,
+	}
+}
+
+// GetPopularRanking は指定した種別の最新の人気記事ランキングを上位limit件取得する
+func (u *RankingUseCase) GetPopularRanking(ctx context.Context, rankingType ranking.Type, limit int) (*query.PopularRanking, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	popularRanking, err := u.rankingListQuery.GetPopularRanking(ctx, rankingType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ランキングの取得に失敗しました: %w", err)
+	}
+
+	return popularRanking, nil
+}
+
+// GetBlogRankingHistory は指定日時以降のブログの順位の推移を取得する
+func (u *RankingUseCase) GetBlogRankingHistory(ctx context.Context, blogID string, rankingType ranking.Type, since time.Time) ([]query.RankingHistoryPoint, error) {
+	if _, err := blog.NewID(blogID); err != nil {
+		return nil, fmt.Errorf("ブログIDのパースに失敗しました: %w", err)
+	}
+
+	history, err := u.rankingListQuery.GetBlogRankingHistory(ctx, blogID, rankingType, since)
+	if err != nil {
+		return nil, fmt.Errorf("ランキング履歴の取得に失敗しました: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetAuthorRanking は指定した種別の最新の著者ランキングを上位limit件取得する
+func (u *RankingUseCase) GetAuthorRanking(ctx context.Context, rankingType ranking.Type, limit int) (*query.AuthorRanking, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	authorRanking, err := u.rankingListQuery.GetAuthorRanking(ctx, rankingType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("著者ランキングの取得に失敗しました: %w", err)
+	}
+
+	return authorRanking, nil
+}
+
+// GetAuthorBlogRanking は指定した種別の最新の著者の人気記事ランキングを上位limit件取得する
+func (u *RankingUseCase) GetAuthorBlogRanking(ctx context.Context, userID string, rankingType ranking.Type, limit int) (*query.PopularRanking, error) {
+	if _, err := user.NewID(userID); err != nil {
+		return nil, fmt.Errorf("ユーザーIDのパースに失敗しました: %w", err)
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	popularRanking, err := u.rankingListQuery.GetAuthorBlogRanking(ctx, userID, rankingType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("著者の人気記事ランキングの取得に失敗しました: %w", err)
+	}
+
+	return popularRanking, nil
+}
+
+// PruneRankingSnapshots は保持期間を過ぎたランキングのスナップショットを削除する
+func (u *RankingUseCase) PruneRankingSnapshots(ctx context.Context, retention time.Duration) (int64, error) {
+	deleted, err := u.rankingRepository.DeleteSnapshotsBefore(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("ランキングのスナップショットの削除に失敗しました: %w", err)
 	}
+	return deleted, nil
 }
 
-// CalculatePopularRanking は人気記事ランキングを集計する
-func (u *RankingUseCase) CalculatePopularRanking(ctx context.Context, days int) error {
-	// ブログの統計データを取得
-	blogStats, err := u.blogStatsQuery.GetBlogRankingData(ctx, days)
+// CalculatePopularRanking は人気記事ランキングを集計して保存する
+func (u *RankingUseCase) CalculatePopularRanking(ctx context.Context, rankingType ranking.Type, scorer ranking.Scorer, size int, authorSize int, authorBlogSize int) (*ranking.Result, error) {
+	result, err := u.ComputePopularRanking(ctx, rankingType, scorer, size, authorSize, authorBlogSize)
 	if err != nil {
-		return fmt.Errorf("ブログ統計データの取得に失敗しました: %w", err)
+		return nil, err
 	}
 
-	// ランキングを作成
-	var rankings []*ranking.Ranking
-	for i, stat := range blogStats {
+	if err := u.SavePopularRanking(ctx, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ComputePopularRanking は人気記事ランキングを集計する（保存はしない）
+// スコアの算出方法はscorerで指定し、スコアの上位size件をランキングとする
+// 同時に、著者ごとの記事のスコアの合計による著者ランキング（上位authorSize件）と、
+// 著者ランキングに入った著者の人気記事ランキング（著者ごとに上位authorBlogSize件）を集計する
+func (u *RankingUseCase) ComputePopularRanking(ctx context.Context, rankingType ranking.Type, scorer ranking.Scorer, size int, authorSize int, authorBlogSize int) (*ranking.Result, error) {
+	if size <= 0 {
+		size = ranking.DefaultSize
+	}
+	if authorSize <= 0 {
+		authorSize = ranking.DefaultAuthorSize
+	}
+	if authorBlogSize <= 0 {
+		authorBlogSize = ranking.DefaultAuthorBlogSize
+	}
+
+	// 種別ごとの集計期間でブログの統計データを1件ずつ読み込み、スコアの上位size件のみを保持する
+	snapshot := ranking.NewSnapshot(rankingType, time.Now())
+	top := &topRankings{size: size}
+	authors := make(map[string]*authorScore)
+	blogCount := 0
+	err := u.blogStatsQuery.ForEachBlogRankingData(ctx, rankingType.Since(snapshot.CalculatedAt), func(stat query.BlogRankingData) error {
 		blogID, err := blog.NewID(stat.BlogID)
 		if err != nil {
 			return fmt.Errorf("ブログIDのパースに失敗しました: %w", err)
 		}
+		userID, err := user.NewID(stat.UserID)
+		if err != nil {
+			return fmt.Errorf("ユーザーIDのパースに失敗しました: %w", err)
+		}
 
-		rank := ranking.NewRanking(*blogID, i+1, stat.TotalScore)
-		rankings = append(rankings, rank)
-	}
+		score := scorer.Score(ranking.Stats{
+			BlogID:      *blogID,
+			Views:       stat.ViewCount,
+			Likes:       stat.LikeCount,
+			Comments:    stat.CommentCount,
+			PublishedAt: stat.CreatedAt,
+		}, snapshot.CalculatedAt)
+		top.add(ranking.NewRanking(*blogID, 0, score))
 
-	// トランザクション内でランキングを保存
-	err = u.txManager.Transaction(ctx, func(ctx context.Context) error {
-		// ランキングを保存
-		if err := u.rankingRepository.SaveRankings(ctx, rankings); err != nil {
-			return fmt.Errorf("ランキングの保存に失敗しました: %w", err)
+		author, ok := authors[stat.UserID]
+		if !ok {
+			author = &authorScore{userID: *userID, blogs: &topRankings{size: authorBlogSize}}
+			authors[stat.UserID] = author
 		}
+		author.score += score
+		author.blogCount++
+		author.blogs.add(ranking.NewRanking(*blogID, 0, score))
+
+		blogCount++
 		return nil
 	})
-
 	if err != nil {
+		return nil, fmt.Errorf("ブログ統計データの取得に失敗しました: %w", err)
+	}
+
+	// スコアの降順に順位を付ける
+	rankings := top.sorted()
+	for i, rank := range rankings {
+		rank.RankingPosition = i + 1
+	}
+
+	authorRankings, authorBlogRankings := rankAuthors(authors, authorSize)
+
+	return &ranking.Result{
+		Snapshot:           snapshot,
+		Rankings:           rankings,
+		AuthorRankings:     authorRankings,
+		AuthorBlogRankings: authorBlogRankings,
+		BlogCount:          blogCount,
+	}, nil
+}
+
+// SavePopularRanking は集計したランキングを保存する
+// 未公開のスナップショットとして書き込み、全件の書き込み後に公開するため、
+// 参照側から書き込み途中のランキングが見えることはない
+// 以前はTransactionManagerで全件の書き込みを1つのトランザクションにしていたが、
+// 公開フラグ（published_at）の更新でランキングが切り替わるため、長時間のトランザクションで行をロックしないよう使用しない
+func (u *RankingUseCase) SavePopularRanking(ctx context.Context, result *ranking.Result) error {
+	if err := u.rankingRepository.CreateSnapshot(ctx, result.Snapshot); err != nil {
+		return fmt.Errorf("ランキングのスナップショットの作成に失敗しました: %w", err)
+	}
+
+	if err := u.saveSnapshot(ctx, result); err != nil {
+		// 書き込み途中のスナップショットは公開されないが、残さないよう削除する
+		if deleteErr := u.rankingRepository.DeleteSnapshot(ctx, result.Snapshot); deleteErr != nil {
+			log.Printf("書き込みに失敗したランキングのスナップショットの削除に失敗しました: %v", deleteErr)
+		}
 		return err
 	}
 
+	if err := u.rankingRepository.PublishSnapshot(ctx, result.Snapshot); err != nil {
+		return fmt.Errorf("ランキングの公開に失敗しました: %w", err)
+	}
+
 	return nil
 }
+
+// saveSnapshot はスナップショットに各ランキングを書き込む
+func (u *RankingUseCase) saveSnapshot(ctx context.Context, result *ranking.Result) error {
+	if err := u.rankingRepository.SaveRankings(ctx, result.Snapshot, result.Rankings); err != nil {
+		return fmt.Errorf("ランキングの保存に失敗しました: %w", err)
+	}
+	if err := u.rankingRepository.SaveAuthorRankings(ctx, result.Snapshot, result.AuthorRankings); err != nil {
+		return fmt.Errorf("著者ランキングの保存に失敗しました: %w", err)
+	}
+	if err := u.rankingRepository.SaveAuthorBlogRankings(ctx, result.Snapshot, result.AuthorBlogRankings); err != nil {
+		return fmt.Errorf("著者ごとの人気記事ランキングの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// authorScore は集計中の著者ごとのスコア
+type authorScore struct {
+	userID    user.ID
+	score     float64
+	blogCount int
+	blogs     *topRankings
+}
+
+// rankAuthors は著者ごとのスコアから、上位size件の著者ランキングとそれらの著者の人気記事ランキングを作成する
+func rankAuthors(authors map[string]*authorScore, size int) ([]*ranking.AuthorRanking, []*ranking.AuthorBlogRanking) {
+	sorted := make([]*authorScore, 0, len(authors))
+	for _, author := range authors {
+		sorted = append(sorted, author)
+	}
+	// スコアの降順（同点の場合はユーザーIDの昇順）
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].score != sorted[j].score {
+			return sorted[i].score > sorted[j].score
+		}
+		return sorted[i].userID.String() < sorted[j].userID.String()
+	})
+
+	if len(sorted) > size {
+		sorted = sorted[:size]
+	}
+
+	// 著者ごとの人気記事ランキングは著者ランキングに入った著者のみ作成し、
+	// スナップショットの行数が著者の数に比例して増えないようにする
+	authorRankings := make([]*ranking.AuthorRanking, len(sorted))
+	var authorBlogRankings []*ranking.AuthorBlogRanking
+	for i, author := range sorted {
+		authorRankings[i] = ranking.NewAuthorRanking(author.userID, i+1, author.score, author.blogCount)
+		for j, rank := range author.blogs.sorted() {
+			authorBlogRankings = append(authorBlogRankings, ranking.NewAuthorBlogRanking(author.userID, rank.BlogID, j+1, rank.Score))
+		}
+	}
+
+	return authorRankings, authorBlogRankings
+}
+
+// topRankings はスコアの上位size件のランキングを保持する
+// スコアが最も低いランキングを先頭とするヒープで、保持件数を超えた場合は先頭を取り除く
+type topRankings struct {
+	size     int
+	rankings []*ranking.Ranking
+}
+
+// less はaがbより下位かどうかを返す（同点の場合はブログIDの大きい方を下位とする）
+func (t *topRankings) less(a, b *ranking.Ranking) bool {
+	if a.Score != b.Score {
+		return a.Score < b.Score
+	}
+	return a.BlogID.String() > b.BlogID.String()
+}
+
+// Len はheap.Interfaceの実装
+func (t *topRankings) Len() int { return len(t.rankings) }
+
+// Less はheap.Interfaceの実装
+func (t *topRankings) Less(i, j int) bool { return t.less(t.rankings[i], t.rankings[j]) }
+
+// Swap はheap.Interfaceの実装
+func (t *topRankings) Swap(i, j int) { t.rankings[i], t.rankings[j] = t.rankings[j], t.rankings[i] }
+
+// Push はheap.Interfaceの実装
+func (t *topRankings) Push(x interface{}) { t.rankings = append(t.rankings, x.(*ranking.Ranking)) }
+
+// Pop はheap.Interfaceの実装
+func (t *topRankings) Pop() interface{} {
+	last := t.rankings[len(t.rankings)-1]
+	t.rankings = t.rankings[:len(t.rankings)-1]
+	return last
+}
+
+// add はランキングを追加する（上位size件に入らない場合は破棄する）
+func (t *topRankings) add(rank *ranking.Ranking) {
+	if len(t.rankings) < t.size {
+		heap.Push(t, rank)
+		return
+	}
+	if t.less(t.rankings[0], rank) {
+		t.rankings[0] = rank
+		heap.Fix(t, 0)
+	}
+}
+
+// sorted は保持しているランキングをスコアの降順で返す
+func (t *topRankings) sorted() []*ranking.Ranking {
+	result := make([]*ranking.Ranking, len(t.rankings))
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(t).(*ranking.Ranking)
+	}
+	return result
+}
@@ -0,0 +1,486 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/like"
+	"myblog/app/domain/model/ranking"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/model/view"
+)
+
+func TestRankingUseCase_Validation(t *testing.T) {
+	uc := newRankingUseCase(newTestRepos())
+
+	t.Run("ランキング履歴のブログIDが空", func(t *testing.T) {
+		_, err := uc.GetBlogRankingHistory(context.Background(), "", ranking.TypeDaily, time.Now())
+		assertError(t, err, "ブログIDのパースに失敗しました")
+	})
+
+	t.Run("著者の人気記事ランキングのユーザーIDが空", func(t *testing.T) {
+		_, err := uc.GetAuthorBlogRanking(context.Background(), "", ranking.TypeDaily, 10)
+		assertError(t, err, "ユーザーIDのパースに失敗しました")
+	})
+}
+
+// newRankingUseCase : インメモリのリポジトリとクエリを使用するRankingUseCaseの生成
+func newRankingUseCase(repos *testRepos) *RankingUseCase {
+	return NewRankingUseCase(repos.rankings, repos.blogStats, repos.rankingList)
+}
+
+// rankingFixture : ランキングの集計に使用するブログ
+// 重みが全て1のcountScorerでの集計期間（1日）内のスコアは a1: 5, b1: 4, a2: 3, c1: 1 で、idleは対象外
+// 著者のスコアは alice: 8（2件）, bob: 4（1件）, carol: 1（1件）
+type rankingFixture struct {
+	alice, bob, carol    *user.User
+	a1, a2, b1, c1, idle *blog.Blog
+}
+
+// seedRankingData : ランキングの集計に使用するデータの作成
+func (r *testRepos) seedRankingData(t *testing.T) *rankingFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	f := &rankingFixture{
+		alice: r.createUser(t, "alice", "password"),
+		bob:   r.createUser(t, "bob", "password"),
+		carol: r.createUser(t, "carol", "password"),
+	}
+	f.a1 = r.createBlog(t, f.alice, "a1", "content")
+	f.a2 = r.createBlog(t, f.alice, "a2", "content")
+	f.b1 = r.createBlog(t, f.bob, "b1", "content")
+	f.c1 = r.createBlog(t, f.carol, "c1", "content")
+	f.idle = r.createBlog(t, f.alice, "idle", "content")
+
+	r.addViews(t, f.a1, time.Now(), 5)
+	r.addViews(t, f.b1, time.Now(), 4)
+	r.createComment(t, f.a2, f.bob, "comment")
+	for _, u := range []*user.User{f.bob, f.carol} {
+		if err := r.likes.Save(ctx, like.NewLike(f.a2.ID(), u.ID())); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.addViews(t, f.c1, time.Now(), 1)
+	// 日別ランキングの集計期間外の閲覧（週間ランキングではc1が1位になる）
+	r.addViews(t, f.c1, time.Now().AddDate(0, 0, -3), 10)
+	return f
+}
+
+// addViews : 日別閲覧数の加算
+func (r *testRepos) addViews(t *testing.T, b *blog.Blog, date time.Time, views int) {
+	t.Helper()
+
+	if err := r.blogViews.IncrementDailyCounts(context.Background(), []*view.DailyCount{{BlogID: b.ID(), Date: date, Views: views}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// blogTitles : ランキングのブログのタイトル（順位の昇順）
+func blogTitles(t *testing.T, repos *testRepos, rankings []*ranking.Ranking) []string {
+	t.Helper()
+
+	titles := make([]string, len(rankings))
+	for i, rank := range rankings {
+		if rank.RankingPosition != i+1 {
+			t.Errorf("%d件目の順位が異なります: %d", i+1, rank.RankingPosition)
+		}
+		b, err := repos.blogs.FindByID(context.Background(), rank.BlogID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		titles[i] = fmt.Sprintf("%s:%g", b.Title(), rank.Score)
+	}
+	return titles
+}
+
+func TestRankingUseCase_ComputePopularRanking(t *testing.T) {
+	tests := []struct {
+		name           string
+		rankingType    ranking.Type
+		size           int
+		authorSize     int
+		authorBlogSize int
+		wantRankings   []string
+		wantAuthors    []string
+		wantAuthorBlog []string
+	}{
+		{
+			name:           "スコアの降順に順位を付け、活動のないブログは含めない",
+			rankingType:    ranking.TypeDaily,
+			size:           10,
+			authorSize:     10,
+			authorBlogSize: 10,
+			wantRankings:   []string{"a1:5", "b1:4", "a2:3", "c1:1"},
+			wantAuthors:    []string{"alice:8/2", "bob:4/1", "carol:1/1"},
+			wantAuthorBlog: []string{"alice/a1#1", "alice/a2#2", "bob/b1#1", "carol/c1#1"},
+		},
+		{
+			name:           "集計期間の閲覧のみを数える",
+			rankingType:    ranking.TypeWeekly,
+			size:           10,
+			authorSize:     10,
+			authorBlogSize: 10,
+			wantRankings:   []string{"c1:11", "a1:5", "b1:4", "a2:3"},
+			wantAuthors:    []string{"carol:11/1", "alice:8/2", "bob:4/1"},
+			wantAuthorBlog: []string{"carol/c1#1", "alice/a1#1", "alice/a2#2", "bob/b1#1"},
+		},
+		{
+			name:           "ランキングは上位size件",
+			rankingType:    ranking.TypeDaily,
+			size:           2,
+			authorSize:     10,
+			authorBlogSize: 10,
+			wantRankings:   []string{"a1:5", "b1:4"},
+			wantAuthors:    []string{"alice:8/2", "bob:4/1", "carol:1/1"},
+			wantAuthorBlog: []string{"alice/a1#1", "alice/a2#2", "bob/b1#1", "carol/c1#1"},
+		},
+		{
+			name:           "著者ランキングは上位authorSize件で、著者ごとの人気記事ランキングは著者ランキングに入った著者のみ",
+			rankingType:    ranking.TypeDaily,
+			size:           10,
+			authorSize:     1,
+			authorBlogSize: 10,
+			wantRankings:   []string{"a1:5", "b1:4", "a2:3", "c1:1"},
+			wantAuthors:    []string{"alice:8/2"},
+			wantAuthorBlog: []string{"alice/a1#1", "alice/a2#2"},
+		},
+		{
+			name:           "著者ごとの人気記事ランキングは著者ごとに上位authorBlogSize件",
+			rankingType:    ranking.TypeDaily,
+			size:           10,
+			authorSize:     10,
+			authorBlogSize: 1,
+			wantRankings:   []string{"a1:5", "b1:4", "a2:3", "c1:1"},
+			wantAuthors:    []string{"alice:8/2", "bob:4/1", "carol:1/1"},
+			wantAuthorBlog: []string{"alice/a1#1", "bob/b1#1", "carol/c1#1"},
+		},
+	}
+
+	scorer, err := ranking.NewCountScorer(ranking.DefaultWeights)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newTestRepos()
+			f := repos.seedRankingData(t)
+			usernames := map[user.ID]string{f.alice.ID(): "alice", f.bob.ID(): "bob", f.carol.ID(): "carol"}
+
+			result, err := newRankingUseCase(repos).ComputePopularRanking(context.Background(), tt.rankingType, scorer, tt.size, tt.authorSize, tt.authorBlogSize)
+			assertError(t, err, "")
+
+			if result.Snapshot.Type != tt.rankingType {
+				t.Errorf("スナップショットの種別が異なります: %s", result.Snapshot.Type)
+			}
+			if result.BlogCount != 4 {
+				t.Errorf("集計したブログの件数が異なります: got %d, want 4", result.BlogCount)
+			}
+			if got := blogTitles(t, repos, result.Rankings); !reflect.DeepEqual(got, tt.wantRankings) {
+				t.Errorf("ランキングが異なります: got %v, want %v", got, tt.wantRankings)
+			}
+
+			var gotAuthors []string
+			for i, rank := range result.AuthorRankings {
+				if rank.RankingPosition != i+1 {
+					t.Errorf("%d件目の著者の順位が異なります: %d", i+1, rank.RankingPosition)
+				}
+				gotAuthors = append(gotAuthors, fmt.Sprintf("%s:%g/%d", usernames[rank.UserID], rank.Score, rank.BlogCount))
+			}
+			if !reflect.DeepEqual(gotAuthors, tt.wantAuthors) {
+				t.Errorf("著者ランキングが異なります: got %v, want %v", gotAuthors, tt.wantAuthors)
+			}
+
+			titles := map[blog.ID]string{f.a1.ID(): "a1", f.a2.ID(): "a2", f.b1.ID(): "b1", f.c1.ID(): "c1"}
+			var gotAuthorBlog []string
+			for _, rank := range result.AuthorBlogRankings {
+				gotAuthorBlog = append(gotAuthorBlog, fmt.Sprintf("%s/%s#%d", usernames[rank.UserID], titles[rank.BlogID], rank.RankingPosition))
+			}
+			if !reflect.DeepEqual(gotAuthorBlog, tt.wantAuthorBlog) {
+				t.Errorf("著者ごとの人気記事ランキングが異なります: got %v, want %v", gotAuthorBlog, tt.wantAuthorBlog)
+			}
+		})
+	}
+}
+
+func TestRankingUseCase_CalculatePopularRanking(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos()
+	f := repos.seedRankingData(t)
+	uc := newRankingUseCase(repos)
+
+	scorer, err := ranking.NewCountScorer(ranking.DefaultWeights)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 集計前は空のランキング
+	popular, err := uc.GetPopularRanking(ctx, ranking.TypeDaily, 10)
+	assertError(t, err, "")
+	if popular.CalculatedAt != nil || len(popular.Blogs) != 0 {
+		t.Errorf("集計前のランキングが空ではありません: %+v", popular)
+	}
+
+	if _, err := uc.CalculatePopularRanking(ctx, ranking.TypeDaily, scorer, 10, 10, 10); err != nil {
+		t.Fatal(err)
+	}
+	// c1の閲覧が増えて1位になる（前回4位）
+	repos.addViews(t, f.c1, time.Now(), 9)
+	if _, err := uc.CalculatePopularRanking(ctx, ranking.TypeDaily, scorer, 10, 10, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	popular, err = uc.GetPopularRanking(ctx, ranking.TypeDaily, 3)
+	assertError(t, err, "")
+	var gotBlogs []string
+	for _, b := range popular.Blogs {
+		movement := "new"
+		if m := b.Movement(); m != nil {
+			movement = fmt.Sprintf("%+d", *m)
+		}
+		gotBlogs = append(gotBlogs, fmt.Sprintf("%d:%s by %s (%s)", b.Position, b.Title, b.AuthorName, movement))
+	}
+	if want := []string{"1:c1 by carol (+3)", "2:a1 by alice (-1)", "3:b1 by bob (-1)"}; !reflect.DeepEqual(gotBlogs, want) {
+		t.Errorf("人気記事ランキングが異なります: got %v, want %v", gotBlogs, want)
+	}
+
+	authors, err := uc.GetAuthorRanking(ctx, ranking.TypeDaily, 10)
+	assertError(t, err, "")
+	var gotAuthors []string
+	for _, a := range authors.Authors {
+		gotAuthors = append(gotAuthors, fmt.Sprintf("%d:%s", a.Position, a.Username))
+	}
+	if want := []string{"1:carol", "2:alice", "3:bob"}; !reflect.DeepEqual(gotAuthors, want) {
+		t.Errorf("著者ランキングが異なります: got %v, want %v", gotAuthors, want)
+	}
+
+	authorBlogs, err := uc.GetAuthorBlogRanking(ctx, f.alice.ID().String(), ranking.TypeDaily, 10)
+	assertError(t, err, "")
+	var gotAuthorBlogs []string
+	for _, b := range authorBlogs.Blogs {
+		gotAuthorBlogs = append(gotAuthorBlogs, fmt.Sprintf("%d:%s", b.Position, b.Title))
+	}
+	if want := []string{"1:a1", "2:a2"}; !reflect.DeepEqual(gotAuthorBlogs, want) {
+		t.Errorf("著者の人気記事ランキングが異なります: got %v, want %v", gotAuthorBlogs, want)
+	}
+
+	history, err := uc.GetBlogRankingHistory(ctx, f.c1.ID().String(), ranking.TypeDaily, time.Now().Add(-time.Hour))
+	assertError(t, err, "")
+	var gotHistory []int
+	for _, point := range history {
+		gotHistory = append(gotHistory, point.Position)
+	}
+	if want := []int{4, 1}; !reflect.DeepEqual(gotHistory, want) {
+		t.Errorf("順位の推移が異なります: got %v, want %v", gotHistory, want)
+	}
+}
+
+// mustBlogID : テスト用のブログIDの生成
+func mustBlogID(t *testing.T, value string) blog.ID {
+	t.Helper()
+
+	id, err := blog.NewID(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *id
+}
+
+// mustUserID : テスト用のユーザーIDの生成
+func mustUserID(t *testing.T, value string) user.ID {
+	t.Helper()
+
+	id, err := user.NewID(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *id
+}
+
+func TestRankingUseCase_SavePopularRanking(t *testing.T) {
+	tests := []struct {
+		name        string
+		unknownBlog bool
+		wantErr     string
+		wantPruned  int64
+	}{
+		{name: "保存したランキングを公開する", wantPruned: 1},
+		{name: "保存に失敗した場合はスナップショットを削除し、前回のランキングを参照できる", unknownBlog: true, wantErr: "ランキングの保存に失敗しました"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			previous := repos.createBlog(t, alice, "previous", "content")
+			b := repos.createBlog(t, alice, "title", "content")
+			uc := newRankingUseCase(repos)
+
+			result := func(calculatedAt time.Time, blogID blog.ID) *ranking.Result {
+				return &ranking.Result{
+					Snapshot:           ranking.NewSnapshot(ranking.TypeDaily, calculatedAt),
+					Rankings:           []*ranking.Ranking{ranking.NewRanking(blogID, 1, 10)},
+					AuthorRankings:     []*ranking.AuthorRanking{ranking.NewAuthorRanking(alice.ID(), 1, 10, 1)},
+					AuthorBlogRankings: []*ranking.AuthorBlogRanking{ranking.NewAuthorBlogRanking(alice.ID(), blogID, 1, 10)},
+					BlogCount:          1,
+				}
+			}
+			if err := uc.SavePopularRanking(ctx, result(time.Now().Add(-time.Hour), previous.ID())); err != nil {
+				t.Fatal(err)
+			}
+
+			blogID := b.ID()
+			want := blogID
+			if tt.unknownBlog {
+				blogID = mustBlogID(t, "unknown")
+				want = previous.ID()
+			}
+
+			err := uc.SavePopularRanking(ctx, result(time.Now(), blogID))
+			assertError(t, err, tt.wantErr)
+
+			rankings, err := repos.rankings.GetRankings(ctx, ranking.TypeDaily, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rankings) != 1 || rankings[0].BlogID != want {
+				t.Errorf("公開されているランキングが異なります: %+v", rankings)
+			}
+
+			// 最新の公開済みスナップショット以外を削除し、失敗したスナップショットが残っていないことを確認する
+			deleted, err := repos.rankings.DeleteSnapshotsBefore(ctx, time.Now().Add(time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if deleted != tt.wantPruned {
+				t.Errorf("削除されたスナップショットの件数が異なります: got %d, want %d", deleted, tt.wantPruned)
+			}
+		})
+	}
+}
+
+func TestRankingUseCase_PruneRankingSnapshots(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos()
+	uc := newRankingUseCase(repos)
+
+	now := time.Now()
+	snapshots := []struct {
+		rankingType  ranking.Type
+		calculatedAt time.Time
+		published    bool
+	}{
+		{ranking.TypeDaily, now.Add(-72 * time.Hour), true},  // 保持期間を過ぎている
+		{ranking.TypeDaily, now.Add(-60 * time.Hour), false}, // 保持期間を過ぎた未公開のスナップショット
+		{ranking.TypeDaily, now.Add(-time.Hour), true},       // 保持期間内
+		{ranking.TypeWeekly, now.Add(-72 * time.Hour), true}, // 保持期間を過ぎているが、種別の最新の公開済みスナップショット
+	}
+	for _, s := range snapshots {
+		snapshot := ranking.NewSnapshot(s.rankingType, s.calculatedAt)
+		if err := repos.rankings.CreateSnapshot(ctx, snapshot); err != nil {
+			t.Fatal(err)
+		}
+		if s.published {
+			if err := repos.rankings.PublishSnapshot(ctx, snapshot); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	deleted, err := uc.PruneRankingSnapshots(ctx, 48*time.Hour)
+	assertError(t, err, "")
+	if deleted != 2 {
+		t.Errorf("削除されたスナップショットの件数が異なります: got %d, want 2", deleted)
+	}
+
+	// 残りは種別ごとの最新の公開済みスナップショットのみ
+	deleted, err = uc.PruneRankingSnapshots(ctx, 0)
+	assertError(t, err, "")
+	if deleted != 0 {
+		t.Errorf("最新の公開済みスナップショットが削除されました: %d件", deleted)
+	}
+}
+
+func TestTopRankings(t *testing.T) {
+	tests := []struct {
+		name   string
+		size   int
+		scores map[string]float64
+		want   []string
+	}{
+		{name: "スコアの上位size件を降順で保持する", size: 3, scores: map[string]float64{"a": 1, "b": 5, "c": 3, "d": 4, "e": 2}, want: []string{"b", "d", "c"}},
+		{name: "同点の場合はブログIDの昇順", size: 2, scores: map[string]float64{"c": 1, "a": 1, "b": 1}, want: []string{"a", "b"}},
+		{name: "size件未満の場合は全て保持する", size: 10, scores: map[string]float64{"a": 1, "b": 2}, want: []string{"b", "a"}},
+		{name: "空", size: 10, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			top := &topRankings{size: tt.size}
+			for id, score := range tt.scores {
+				top.add(ranking.NewRanking(mustBlogID(t, id), 0, score))
+			}
+
+			sorted := top.sorted()
+			got := make([]string, len(sorted))
+			for i, rank := range sorted {
+				got[i] = rank.BlogID.String()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ランキングが異なります: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankAuthors(t *testing.T) {
+	// aliceは記事a1・a2（合計5点）、bobは記事b1（合計5点）、carolは記事c1（合計1点）
+	authors := make(map[string]*authorScore)
+	for _, s := range []struct {
+		userID, blogID string
+		score          float64
+	}{
+		{"alice", "a1", 3}, {"alice", "a2", 2}, {"bob", "b1", 5}, {"carol", "c1", 1},
+	} {
+		author, ok := authors[s.userID]
+		if !ok {
+			author = &authorScore{userID: mustUserID(t, s.userID), blogs: &topRankings{size: 10}}
+			authors[s.userID] = author
+		}
+		author.score += s.score
+		author.blogCount++
+		author.blogs.add(ranking.NewRanking(mustBlogID(t, s.blogID), 0, s.score))
+	}
+
+	authorRankings, authorBlogRankings := rankAuthors(authors, 2)
+
+	// 同点の場合はユーザーIDの昇順で、上位2件のみ
+	var gotAuthors []string
+	for _, rank := range authorRankings {
+		gotAuthors = append(gotAuthors, rank.UserID.String())
+		if rank.RankingPosition != len(gotAuthors) {
+			t.Errorf("%sの順位が異なります: %d", rank.UserID.String(), rank.RankingPosition)
+		}
+	}
+	if want := []string{"alice", "bob"}; !reflect.DeepEqual(gotAuthors, want) {
+		t.Errorf("著者ランキングが異なります: got %v, want %v", gotAuthors, want)
+	}
+	if authorRankings[0].BlogCount != 2 || authorRankings[0].Score != 5 {
+		t.Errorf("aliceの集計が異なります: %+v", authorRankings[0])
+	}
+
+	// 著者ごとの人気記事ランキングは著者ランキングに入った著者のみ
+	var gotBlogs []string
+	for _, rank := range authorBlogRankings {
+		gotBlogs = append(gotBlogs, fmt.Sprintf("%s/%s#%d", rank.UserID.String(), rank.BlogID.String(), rank.RankingPosition))
+	}
+	if want := []string{"alice/a1#1", "alice/a2#2", "bob/b1#1"}; !reflect.DeepEqual(gotBlogs, want) {
+		t.Errorf("著者ごとの人気記事ランキングが異なります: got %v, want %v", gotBlogs, want)
+	}
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"myblog/app/domain/model/reaction"
+	"myblog/app/domain/model/user"
+	"myblog/app/domain/repository"
+)
+
+// ReactionUsecase : リアクションユースケースインターフェース
+type ReactionUsecase interface {
+	AddReaction(ctx context.Context, targetType reaction.TargetType, targetID, userID, emoji string) (*reaction.Summary, error)
+	RemoveReaction(ctx context.Context, targetType reaction.TargetType, targetID, userID string) (*reaction.Summary, error)
+	GetSummaries(ctx context.Context, targetType reaction.TargetType, targetIDs []string, viewerID string) (map[string]*reaction.Summary, error)
+	AvailableEmojis() []string
+}
+
+// reactionUsecase : リアクションユースケースの実装
+type reactionUsecase struct {
+	reactionRepo repository.Reaction
+	blogRepo     repository.Blog
+	commentRepo  repository.Comment
+	emojis       *reaction.EmojiSet
+}
+
+// NewReactionUsecase : リアクションユースケースの生成
+func NewReactionUsecase(reactionRepo repository.Reaction, blogRepo repository.Blog, commentRepo repository.Comment, emojis *reaction.EmojiSet) ReactionUsecase {
+	return &reactionUsecase{
+		reactionRepo: reactionRepo,
+		blogRepo:     blogRepo,
+		commentRepo:  commentRepo,
+		emojis:       emojis,
+	}
+}
+
+// AddReaction : リアクションの追加（既に別の絵文字でリアクション済みの場合は置き換える）
+func (r *reactionUsecase) AddReaction(ctx context.Context, targetType reaction.TargetType, targetID, userID, emoji string) (*reaction.Summary, error) {
+	userIDObj, err := user.NewID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーID検証エラー: %w", err)
+	}
+
+	if err := r.ensureTargetExists(ctx, targetType, targetID); err != nil {
+		return nil, err
+	}
+
+	newReaction, err := reaction.NewReaction(*userIDObj, targetType, targetID, emoji, r.emojis)
+	if err != nil {
+		return nil, fmt.Errorf("リアクション作成エラー: %w", err)
+	}
+
+	if err := r.reactionRepo.Save(ctx, newReaction); err != nil {
+		return nil, fmt.Errorf("リアクション保存エラー: %w", err)
+	}
+
+	return r.getSummary(ctx, targetType, targetID, userID)
+}
+
+// RemoveReaction : リアクションの削除（未リアクションの場合も成功とする）
+func (r *reactionUsecase) RemoveReaction(ctx context.Context, targetType reaction.TargetType, targetID, userID string) (*reaction.Summary, error) {
+	userIDObj, err := user.NewID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーID検証エラー: %w", err)
+	}
+
+	if err := r.ensureTargetExists(ctx, targetType, targetID); err != nil {
+		return nil, err
+	}
+
+	if err := r.reactionRepo.Delete(ctx, *userIDObj, targetType, targetID); err != nil {
+		return nil, fmt.Errorf("リアクション削除エラー: %w", err)
+	}
+
+	return r.getSummary(ctx, targetType, targetID, userID)
+}
+
+// GetSummaries : 対象IDごとのリアクション集計を取得（viewerIDが空の場合は自身のリアクションを含めない）
+func (r *reactionUsecase) GetSummaries(ctx context.Context, targetType reaction.TargetType, targetIDs []string, viewerID string) (map[string]*reaction.Summary, error) {
+	counts, err := r.reactionRepo.CountByTargetIDs(ctx, targetType, targetIDs)
+	if err != nil {
+		return nil, fmt.Errorf("リアクション集計エラー: %w", err)
+	}
+
+	mine := make(map[string]string)
+	if viewerID != "" {
+		viewerIDObj, err := user.NewID(viewerID)
+		if err != nil {
+			return nil, fmt.Errorf("ユーザーID検証エラー: %w", err)
+		}
+
+		mine, err = r.reactionRepo.FindEmojisByUserID(ctx, *viewerIDObj, targetType, targetIDs)
+		if err != nil {
+			return nil, fmt.Errorf("リアクション取得エラー: %w", err)
+		}
+	}
+
+	summaries := make(map[string]*reaction.Summary, len(targetIDs))
+	for _, targetID := range targetIDs {
+		summary := reaction.NewSummary()
+		for emoji, count := range counts[targetID] {
+			summary.Counts[emoji] = count
+		}
+		summary.Mine = mine[targetID]
+		summaries[targetID] = summary
+	}
+
+	return summaries, nil
+}
+
+// AvailableEmojis : 利用可能な絵文字一覧の取得
+func (r *reactionUsecase) AvailableEmojis() []string {
+	return r.emojis.Emojis()
+}
+
+// getSummary : 単一対象のリアクション集計を取得
+func (r *reactionUsecase) getSummary(ctx context.Context, targetType reaction.TargetType, targetID, viewerID string) (*reaction.Summary, error) {
+	summaries, err := r.GetSummaries(ctx, targetType, []string{targetID}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return summaries[targetID], nil
+}
+
+// ensureTargetExists : リアクション対象の存在確認
+func (r *reactionUsecase) ensureTargetExists(ctx context.Context, targetType reaction.TargetType, targetID string) error {
+	switch targetType {
+	case reaction.TargetBlog:
+		if _, err := r.blogRepo.FindByID(ctx, targetID); err != nil {
+			return fmt.Errorf("ブログ取得エラー: %w", err)
+		}
+	case reaction.TargetComment:
+		if _, err := r.commentRepo.FindByID(ctx, targetID); err != nil {
+			return fmt.Errorf("コメント取得エラー: %w", err)
+		}
+	default:
+		return fmt.Errorf("不正なリアクション対象です: %s", targetType)
+	}
+	return nil
+}
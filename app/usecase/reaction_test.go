@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"myblog/app/domain/model/reaction"
+)
+
+// newTestReactionUsecase : デフォルトの絵文字を使用するリアクションユースケースの生成
+func newTestReactionUsecase(t *testing.T, repos *testRepos) ReactionUsecase {
+	t.Helper()
+
+	emojis, err := reaction.NewEmojiSet(reaction.DefaultEmojis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewReactionUsecase(repos.reactions, repos.blogs, repos.comments, emojis)
+}
+
+func TestReactionUsecase_AddReaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		targetType reaction.TargetType
+		targetID   string
+		emptyUser  bool
+		emoji      string
+		replace    string
+		wantErr    string
+		want       *reaction.Summary
+	}{
+		{name: "ブログにリアクションできる", targetType: reaction.TargetBlog, emoji: "🎉", want: &reaction.Summary{Counts: map[string]int{"👍": 1, "🎉": 1}, Mine: "🎉"}},
+		{name: "コメントにリアクションできる", targetType: reaction.TargetComment, emoji: "🎉", want: &reaction.Summary{Counts: map[string]int{"👍": 1, "🎉": 1}, Mine: "🎉"}},
+		{name: "別の絵文字でリアクション済みの場合は置き換える", targetType: reaction.TargetBlog, replace: "😢", emoji: "🎉", want: &reaction.Summary{Counts: map[string]int{"👍": 1, "🎉": 1}, Mine: "🎉"}},
+		{name: "利用できない絵文字", targetType: reaction.TargetBlog, emoji: "🍣", wantErr: "利用できない絵文字です"},
+		{name: "不正なリアクション対象", targetType: reaction.TargetType("user"), emoji: "🎉", wantErr: "不正なリアクション対象です"},
+		{name: "存在しないブログ", targetType: reaction.TargetBlog, targetID: "unknown", emoji: "🎉", wantErr: "ブログ取得エラー"},
+		{name: "存在しないコメント", targetType: reaction.TargetComment, targetID: "unknown", emoji: "🎉", wantErr: "コメント取得エラー"},
+		{name: "ユーザーIDが空", targetType: reaction.TargetBlog, emptyUser: true, emoji: "🎉", wantErr: "ユーザーID検証エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			c := repos.createComment(t, b, alice, "comment")
+			uc := newTestReactionUsecase(t, repos)
+
+			// aliceは対象に👍でリアクションしている
+			targetID := tt.targetID
+			if targetID == "" {
+				targetID = b.ID().String()
+				if tt.targetType == reaction.TargetComment {
+					targetID = c.ID().String()
+				}
+			}
+			for _, target := range []struct {
+				targetType reaction.TargetType
+				id         string
+			}{{reaction.TargetBlog, b.ID().String()}, {reaction.TargetComment, c.ID().String()}} {
+				if _, err := uc.AddReaction(ctx, target.targetType, target.id, alice.ID().String(), "👍"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			userID := bob.ID().String()
+			if tt.emptyUser {
+				userID = ""
+			}
+			if tt.replace != "" {
+				if _, err := uc.AddReaction(ctx, tt.targetType, targetID, userID, tt.replace); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := uc.AddReaction(ctx, tt.targetType, targetID, userID, tt.emoji)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("リアクション集計が異なります: got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReactionUsecase_RemoveReaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		targetType reaction.TargetType
+		targetID   string
+		reacted    bool
+		wantErr    string
+		want       *reaction.Summary
+	}{
+		{name: "リアクションを削除できる", targetType: reaction.TargetBlog, reacted: true, want: &reaction.Summary{Counts: map[string]int{"👍": 1}}},
+		{name: "未リアクションの場合も成功する", targetType: reaction.TargetBlog, want: &reaction.Summary{Counts: map[string]int{"👍": 1}}},
+		{name: "不正なリアクション対象", targetType: reaction.TargetType("user"), wantErr: "不正なリアクション対象です"},
+		{name: "存在しないブログ", targetType: reaction.TargetBlog, targetID: "unknown", wantErr: "ブログ取得エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			bob := repos.createUser(t, "bob", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			uc := newTestReactionUsecase(t, repos)
+			if _, err := uc.AddReaction(ctx, reaction.TargetBlog, b.ID().String(), alice.ID().String(), "👍"); err != nil {
+				t.Fatal(err)
+			}
+			if tt.reacted {
+				if _, err := uc.AddReaction(ctx, reaction.TargetBlog, b.ID().String(), bob.ID().String(), "😂"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			targetID := tt.targetID
+			if targetID == "" {
+				targetID = b.ID().String()
+			}
+
+			got, err := uc.RemoveReaction(ctx, tt.targetType, targetID, bob.ID().String())
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("リアクション集計が異なります: got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReactionUsecase_GetSummaries(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	bob := repos.createUser(t, "bob", "password")
+	popular := repos.createBlog(t, alice, "popular", "content")
+	quiet := repos.createBlog(t, alice, "quiet", "content")
+	uc := newTestReactionUsecase(t, repos)
+	for _, r := range []struct{ userID, emoji string }{{alice.ID().String(), "👍"}, {bob.ID().String(), "❤️"}} {
+		if _, err := uc.AddReaction(ctx, reaction.TargetBlog, popular.ID().String(), r.userID, r.emoji); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		viewerID string
+		want     map[string]*reaction.Summary
+	}{
+		{
+			name:     "閲覧ユーザーのリアクションを含む",
+			viewerID: bob.ID().String(),
+			want: map[string]*reaction.Summary{
+				popular.ID().String(): {Counts: map[string]int{"👍": 1, "❤️": 1}, Mine: "❤️"},
+				quiet.ID().String():   {Counts: map[string]int{}},
+			},
+		},
+		{
+			name: "未ログインの場合は自身のリアクションを含めない",
+			want: map[string]*reaction.Summary{
+				popular.ID().String(): {Counts: map[string]int{"👍": 1, "❤️": 1}},
+				quiet.ID().String():   {Counts: map[string]int{}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.GetSummaries(ctx, reaction.TargetBlog, []string{popular.ID().String(), quiet.ID().String()}, tt.viewerID)
+			assertError(t, err, "")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("リアクション集計が異なります: got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// StreamEventType : ストリーミング配信するイベントの種別
+type StreamEventType string
+
+const (
+	// StreamEventComment : コメントが投稿された
+	StreamEventComment StreamEventType = "comment"
+)
+
+// StreamEvent : ストリーミング配信するイベント
+// ブログを購読している接続と、UserIDsに含まれるユーザーの接続に配信する
+type StreamEvent struct {
+	ID      int64 // 接続の再開に使用する単調増加するID（Publish時に採番する）
+	Type    StreamEventType
+	BlogID  string
+	UserIDs []string    // ブログを購読していなくても配信するユーザー（ブログの著者など）
+	Payload interface{} // イベントの内容（StreamEventCommentの場合は*comment.Comment）
+}
+
+// StreamPublisher : ストリーミング配信するイベントの発行インターフェース
+type StreamPublisher interface {
+	// Publish : イベントを発行する（呼び出し元をブロックしない）
+	Publish(e StreamEvent)
+}
+
+// ErrStreamClosed : 停止したStreamHubへの購読
+var ErrStreamClosed = errors.New("ストリーミング配信は停止しています")
+
+// StreamSubscription : ストリーミング配信の購読
+type StreamSubscription struct {
+	userID  string
+	blogIDs map[string]bool
+	events  chan StreamEvent
+}
+
+// Events : 購読したイベントのチャネルの取得
+// 受信が追いつかなかった場合とStreamHubが停止した場合は閉じられる
+func (s *StreamSubscription) Events() <-chan StreamEvent {
+	return s.events
+}
+
+// matches : イベントを購読しているかどうか
+func (s *StreamSubscription) matches(e StreamEvent) bool {
+	if s.blogIDs[e.BlogID] {
+		return true
+	}
+	for _, userID := range e.UserIDs {
+		if userID == s.userID {
+			return true
+		}
+	}
+	return false
+}
+
+// StreamHubConfig : StreamHubの設定
+type StreamHubConfig struct {
+	HistorySize int // 接続の再開時に再送するため保持する直近のイベントの件数
+	BufferSize  int // 購読ごとの未送信のイベントのバッファのサイズ（溢れた購読は切断する）
+}
+
+// DefaultStreamHubConfig : StreamHubのデフォルト設定
+var DefaultStreamHubConfig = StreamHubConfig{
+	HistorySize: 1000,
+	BufferSize:  64,
+}
+
+// StreamHub : プロセス内でイベントを購読者に配信する
+type StreamHub struct {
+	config        StreamHubConfig
+	mu            sync.Mutex
+	lastID        int64
+	history       []StreamEvent
+	subscriptions map[*StreamSubscription]struct{}
+	closed        bool
+}
+
+// NewStreamHub : StreamHubの生成
+func NewStreamHub(config StreamHubConfig) *StreamHub {
+	return &StreamHub{
+		config: config,
+		// 再起動前のIDで再開されても新しいイベントを取りこぼさないよう、起動時刻からIDを採番する
+		lastID:        time.Now().UnixNano(),
+		subscriptions: make(map[*StreamSubscription]struct{}),
+	}
+}
+
+// Publish : イベントを採番して購読者に配信する
+func (h *StreamHub) Publish(e StreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	h.lastID++
+	e.ID = h.lastID
+
+	h.history = append(h.history, e)
+	if len(h.history) > h.config.HistorySize {
+		h.history = h.history[len(h.history)-h.config.HistorySize:]
+	}
+
+	for s := range h.subscriptions {
+		if !s.matches(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			// 受信が追いつかない購読は切断し、クライアントに再接続させる
+			h.remove(s)
+		}
+	}
+}
+
+// Subscribe : ユーザー宛てのイベントとblogIDsのブログのイベントの購読
+// lastEventIDが0より大きい場合は、そのIDより後の保持しているイベントを購読と同時に返す
+func (h *StreamHub) Subscribe(userID string, blogIDs []string, lastEventID int64) (*StreamSubscription, []StreamEvent, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, ErrStreamClosed
+	}
+
+	s := &StreamSubscription{
+		userID:  userID,
+		blogIDs: make(map[string]bool, len(blogIDs)),
+		events:  make(chan StreamEvent, h.config.BufferSize),
+	}
+	for _, blogID := range blogIDs {
+		s.blogIDs[blogID] = true
+	}
+
+	var missed []StreamEvent
+	if lastEventID > 0 {
+		for _, e := range h.history {
+			if e.ID > lastEventID && s.matches(e) {
+				missed = append(missed, e)
+			}
+		}
+	}
+
+	h.subscriptions[s] = struct{}{}
+	return s, missed, nil
+}
+
+// Unsubscribe : 購読の解除（解除済みの場合は何もしない）
+func (h *StreamHub) Unsubscribe(s *StreamSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(s)
+}
+
+// remove : 購読を解除してチャネルを閉じる（ロックを取得して呼び出すこと）
+func (h *StreamHub) remove(s *StreamSubscription) {
+	if _, ok := h.subscriptions[s]; !ok {
+		return
+	}
+	delete(h.subscriptions, s)
+	close(s.events)
+}
+
+// Close : 全ての購読を解除し、以降の発行と購読を受け付けない
+func (h *StreamHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for s := range h.subscriptions {
+		h.remove(s)
+	}
+}
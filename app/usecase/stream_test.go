@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+)
+
+// receivedEvents : チャネルに溜まっているイベント
+func receivedEvents(s *StreamSubscription) []StreamEvent {
+	var events []StreamEvent
+	for {
+		select {
+		case e, ok := <-s.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestStreamHub_Publish(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   StreamEvent
+		wantBob bool
+	}{
+		{name: "購読しているブログのイベントを受信する", event: StreamEvent{Type: StreamEventComment, BlogID: "blog-1"}, wantBob: true},
+		{name: "自分宛てのイベントを受信する", event: StreamEvent{Type: StreamEventComment, BlogID: "blog-2", UserIDs: []string{"bob"}}, wantBob: true},
+		{name: "購読していないブログのイベントは受信しない", event: StreamEvent{Type: StreamEventComment, BlogID: "blog-2", UserIDs: []string{"carol"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewStreamHub(DefaultStreamHubConfig)
+			defer hub.Close()
+			s, _, err := hub.Subscribe("bob", []string{"blog-1"}, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hub.Publish(tt.event)
+
+			if got := len(receivedEvents(s)) == 1; got != tt.wantBob {
+				t.Errorf("受信の有無が異なります: got %v, want %v", got, tt.wantBob)
+			}
+		})
+	}
+}
+
+func TestStreamHub_Subscribe(t *testing.T) {
+	hub := NewStreamHub(StreamHubConfig{HistorySize: 2, BufferSize: 8})
+	defer hub.Close()
+	first, _, err := hub.Subscribe("bob", []string{"blog-1"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, blogID := range []string{"blog-1", "blog-2", "blog-1", "blog-1", "blog-1"} {
+		hub.Publish(StreamEvent{Type: StreamEventComment, BlogID: blogID})
+	}
+	var ids []int64
+	for _, e := range receivedEvents(first) {
+		ids = append(ids, e.ID)
+	}
+	if len(ids) != 4 {
+		t.Fatalf("前提のイベントを受信できません: %v", ids)
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID int64
+		wantMissed  int
+	}{
+		{name: "再開時は保持している未受信のイベントを返す", lastEventID: ids[2], wantMissed: 1},
+		{name: "保持している件数より古いイベントは返さない", lastEventID: ids[0], wantMissed: 2},
+		{name: "最初の接続では返さない", lastEventID: 0, wantMissed: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, missed, err := hub.Subscribe("bob", []string{"blog-1"}, tt.lastEventID)
+			assertError(t, err, "")
+			defer hub.Unsubscribe(s)
+			if len(missed) != tt.wantMissed {
+				t.Errorf("再送するイベントの件数が異なります: got %d, want %d", len(missed), tt.wantMissed)
+			}
+			for _, e := range missed {
+				if e.ID <= tt.lastEventID || e.BlogID != "blog-1" {
+					t.Errorf("再送するイベントが異なります: %+v", e)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamHub_SlowSubscriber(t *testing.T) {
+	hub := NewStreamHub(StreamHubConfig{HistorySize: 10, BufferSize: 1})
+	defer hub.Close()
+	s, _, err := hub.Subscribe("bob", []string{"blog-1"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hub.Publish(StreamEvent{Type: StreamEventComment, BlogID: "blog-1"})
+	hub.Publish(StreamEvent{Type: StreamEventComment, BlogID: "blog-1"})
+
+	if _, ok := <-s.Events(); !ok {
+		t.Fatal("バッファ内のイベントを受信できません")
+	}
+	if _, ok := <-s.Events(); ok {
+		t.Error("受信が追いつかない購読が切断されていません")
+	}
+}
+
+func TestStreamHub_Close(t *testing.T) {
+	hub := NewStreamHub(DefaultStreamHubConfig)
+	s, _, err := hub.Subscribe("bob", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hub.Close()
+
+	if _, ok := <-s.Events(); ok {
+		t.Error("停止後に購読のチャネルが閉じられていません")
+	}
+	if _, _, err := hub.Subscribe("bob", nil, 0); !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("停止後の購読のエラーが異なります: %v", err)
+	}
+	// 停止後の発行は無視される
+	hub.Publish(StreamEvent{Type: StreamEventComment, BlogID: "blog-1", UserIDs: []string{"bob"}})
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"time"
 
+	"myblog/app/domain/model/event"
 	"myblog/app/domain/model/user"
 	"myblog/app/domain/repository"
+	"myblog/app/infra/db/rdb"
 
 	"github.com/golang-jwt/jwt/v4"
 )
@@ -23,15 +25,19 @@ type UserUsecase interface {
 
 // userUsecase : ユーザーユースケースの実装
 type userUsecase struct {
-	userRepo  repository.User
-	jwtSecret string
+	userRepo   repository.User
+	outboxRepo repository.Outbox
+	txManager  rdb.TransactionManager
+	jwtSecret  string
 }
 
 // NewUserUsecase : ユーザーユースケースの生成
-func NewUserUsecase(userRepo repository.User, jwtSecret string) UserUsecase {
+func NewUserUsecase(userRepo repository.User, outboxRepo repository.Outbox, txManager rdb.TransactionManager, jwtSecret string) UserUsecase {
 	return &userUsecase{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo:   userRepo,
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
+		jwtSecret:  jwtSecret,
 	}
 }
 
@@ -49,9 +55,23 @@ func (u *userUsecase) Register(ctx context.Context, username, email, password st
 		return nil, fmt.Errorf("ユーザー作成エラー: %w", err)
 	}
 
-	// ユーザーの保存
-	if err := u.userRepo.Save(ctx, newUser); err != nil {
-		return nil, fmt.Errorf("ユーザー保存エラー: %w", err)
+	registered, err := event.NewUserRegistered(newUser)
+	if err != nil {
+		return nil, fmt.Errorf("イベント作成エラー: %w", err)
+	}
+
+	// ユーザーと登録イベントをトランザクション内で保存
+	err = u.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.userRepo.Save(ctx, newUser); err != nil {
+			return fmt.Errorf("ユーザー保存エラー: %w", err)
+		}
+		if err := u.outboxRepo.Save(ctx, registered); err != nil {
+			return fmt.Errorf("イベント保存エラー: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return newUser, nil
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"myblog/app/domain/model/event"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const testJWTSecret = "test-secret"
+
+func TestUserUsecase_Register(t *testing.T) {
+	tests := []struct {
+		name          string
+		username      string
+		email         string
+		password      string
+		failingOutbox bool
+		wantErr       string
+	}{
+		{name: "登録できる", username: "alice", email: "alice@example.com", password: "password"},
+		{name: "メールアドレスが使用済み", username: "alice2", email: "taken@example.com", password: "password", wantErr: "このメールアドレスは既に使用されています"},
+		{name: "メールアドレスの大文字小文字が異なっても使用済み", username: "alice2", email: "TAKEN@example.com", password: "password", wantErr: "このメールアドレスは既に使用されています"},
+		{name: "ユーザー名が空", username: "", email: "alice@example.com", password: "password", wantErr: "ユーザー名が空です"},
+		{name: "メールアドレスが空", username: "alice", email: "", password: "password", wantErr: "メールアドレスが空です"},
+		{name: "パスワードが空", username: "alice", email: "alice@example.com", password: "", wantErr: "パスワードが空です"},
+		{name: "イベントの保存に失敗した場合はユーザーも保存しない", username: "alice", email: "alice@example.com", password: "password", failingOutbox: true, wantErr: "イベント保存エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			taken := repos.createUser(t, "taken", "password")
+			if taken.Email() != "taken@example.com" {
+				t.Fatalf("前提のメールアドレスが異なります: %s", taken.Email())
+			}
+
+			outbox := repos.outbox
+			if tt.failingOutbox {
+				outbox = failingOutbox{outbox}
+			}
+			uc := NewUserUsecase(repos.users, outbox, repos.tx, testJWTSecret)
+
+			registered, err := uc.Register(ctx, tt.username, tt.email, tt.password)
+			assertError(t, err, tt.wantErr)
+
+			if tt.wantErr != "" {
+				if users, _ := repos.users.FindByUsernames(ctx, []string{tt.username}); len(users) != 0 {
+					t.Errorf("失敗した登録のユーザーが保存されています: %s", tt.username)
+				}
+				if events := repos.pendingEvents(t); len(events) != 0 {
+					t.Errorf("失敗した登録のイベントが保存されています: %v", events)
+				}
+				return
+			}
+
+			saved, err := repos.users.FindByID(ctx, registered.ID().String())
+			if err != nil {
+				t.Fatalf("登録したユーザーが保存されていません: %v", err)
+			}
+			if saved.Username() != tt.username || saved.Email() != tt.email {
+				t.Errorf("保存されたユーザーが異なります: %s, %s", saved.Username(), saved.Email())
+			}
+			if !saved.VerifyPassword(tt.password) {
+				t.Error("保存されたパスワードで認証できません")
+			}
+			if events := repos.pendingEvents(t); len(events) != 1 || events[0] != event.TypeUserRegistered {
+				t.Errorf("登録イベントが保存されていません: %v", events)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_Login(t *testing.T) {
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	uc := NewUserUsecase(repos.users, repos.outbox, repos.tx, testJWTSecret)
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		wantErr  string
+	}{
+		{name: "ログインできる", email: "alice@example.com", password: "password"},
+		{name: "パスワードが誤っている", email: "alice@example.com", password: "wrong", wantErr: "メールアドレスまたはパスワードが正しくありません"},
+		{name: "存在しないメールアドレス", email: "unknown@example.com", password: "password", wantErr: "メールアドレスまたはパスワードが正しくありません"},
+		{name: "メールアドレスが空", email: "", password: "password", wantErr: "メールアドレスまたはパスワードが正しくありません"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := uc.Login(context.Background(), tt.email, tt.password)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				if token != "" {
+					t.Errorf("失敗したログインでトークンが返されました: %s", token)
+				}
+				return
+			}
+
+			claims := jwt.MapClaims{}
+			if _, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+				return []byte(testJWTSecret), nil
+			}); err != nil {
+				t.Fatalf("トークンを検証できません: %v", err)
+			}
+			if claims["id"] != alice.ID().String() {
+				t.Errorf("トークンのユーザーIDが異なります: %v", claims["id"])
+			}
+		})
+	}
+}
+
+func TestUserUsecase_UpdateUser(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           func(alice string) string
+		username     string
+		email        string
+		password     string
+		wantErr      string
+		wantUsername string
+		wantEmail    string
+	}{
+		{name: "全て更新できる", username: "alice2", email: "alice2@example.com", password: "newpassword", wantUsername: "alice2", wantEmail: "alice2@example.com"},
+		{name: "空の項目は変更しない", wantUsername: "alice", wantEmail: "alice@example.com"},
+		{name: "自分のメールアドレスは重複としない", email: "alice@example.com", wantUsername: "alice", wantEmail: "alice@example.com"},
+		{name: "他のユーザーのメールアドレスは使用できない", email: "bob@example.com", wantErr: "このメールアドレスは既に使用されています", wantUsername: "alice", wantEmail: "alice@example.com"},
+		{name: "存在しないユーザー", id: func(string) string { return "unknown" }, username: "alice2", wantErr: "ユーザー取得エラー", wantUsername: "alice", wantEmail: "alice@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			repos.createUser(t, "bob", "password")
+			uc := NewUserUsecase(repos.users, repos.outbox, repos.tx, testJWTSecret)
+
+			id := alice.ID().String()
+			if tt.id != nil {
+				id = tt.id(id)
+			}
+
+			_, err := uc.UpdateUser(ctx, id, tt.username, tt.email, tt.password)
+			assertError(t, err, tt.wantErr)
+
+			saved, err := repos.users.FindByID(ctx, alice.ID().String())
+			if err != nil {
+				t.Fatalf("ユーザーの取得に失敗しました: %v", err)
+			}
+			if saved.Username() != tt.wantUsername || saved.Email() != tt.wantEmail {
+				t.Errorf("保存されたユーザーが異なります: got (%s, %s), want (%s, %s)", saved.Username(), saved.Email(), tt.wantUsername, tt.wantEmail)
+			}
+			wantPassword := "password"
+			if tt.wantErr == "" && tt.password != "" {
+				wantPassword = tt.password
+			}
+			if !saved.VerifyPassword(wantPassword) {
+				t.Errorf("パスワード %q で認証できません", wantPassword)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_DeleteUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      func(alice string) string
+		wantErr string
+	}{
+		{name: "ユーザーとブログを削除できる"},
+		{name: "存在しないユーザー", id: func(string) string { return "unknown" }, wantErr: "ユーザー取得エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			uc := NewUserUsecase(repos.users, repos.outbox, repos.tx, testJWTSecret)
+
+			id := alice.ID().String()
+			if tt.id != nil {
+				id = tt.id(id)
+			}
+
+			err := uc.DeleteUser(ctx, id)
+			assertError(t, err, tt.wantErr)
+
+			_, userErr := repos.users.FindByID(ctx, alice.ID().String())
+			_, blogErr := repos.blogs.FindByID(ctx, b.ID().String())
+			deleted := tt.wantErr == ""
+			if (userErr != nil) != deleted || (blogErr != nil) != deleted {
+				t.Errorf("削除の結果が異なります: user=%v, blog=%v, 削除されるべきか=%v", userErr, blogErr, deleted)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_GetUserByID(t *testing.T) {
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	uc := NewUserUsecase(repos.users, repos.outbox, repos.tx, testJWTSecret)
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr string
+	}{
+		{name: "取得できる", id: alice.ID().String()},
+		{name: "存在しないユーザー", id: "unknown", wantErr: "user not found with id: unknown"},
+		{name: "IDが空", id: "", wantErr: "ユーザー取得エラー"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.GetUserByID(context.Background(), tt.id)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr == "" && got.ID() != alice.ID() {
+				t.Errorf("取得したユーザーが異なります: %s", got.ID().String())
+			}
+		})
+	}
+}
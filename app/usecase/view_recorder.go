@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"myblog/app/domain/model/blog"
+	"myblog/app/domain/model/view"
+	"myblog/app/domain/repository"
+)
+
+// ViewRecorder : ブログ閲覧の記録インターフェース
+type ViewRecorder interface {
+	// Record : 閲覧を記録する（呼び出し元をブロックしない）
+	Record(v *view.View)
+}
+
+// ViewRecorderConfig : BufferedViewRecorderの設定
+type ViewRecorderConfig struct {
+	BufferSize    int           // 未処理の閲覧を保持するバッファのサイズ（溢れた閲覧は破棄する）
+	BatchSize     int           // この件数の閲覧が溜まったら書き込む
+	FlushInterval time.Duration // 件数に達しなくてもこの間隔で書き込む
+	DedupWindow   time.Duration // 同一閲覧者による同一ブログの閲覧をこの期間は1回と数える
+}
+
+// DefaultViewRecorderConfig : BufferedViewRecorderのデフォルト設定
+var DefaultViewRecorderConfig = ViewRecorderConfig{
+	BufferSize:    10000,
+	BatchSize:     500,
+	FlushInterval: 10 * time.Second,
+	DedupWindow:   30 * time.Minute,
+}
+
+// BufferedViewRecorder : 閲覧をメモリ上で重複排除・集計し、日別閲覧数としてまとめて書き込む
+type BufferedViewRecorder struct {
+	blogViewRepo repository.BlogView
+	config       ViewRecorderConfig
+	views        chan *view.View
+	done         chan struct{}
+	stopped      chan struct{}
+	closeOnce    sync.Once
+}
+
+// NewBufferedViewRecorder : BufferedViewRecorderの生成
+func NewBufferedViewRecorder(blogViewRepo repository.BlogView, config ViewRecorderConfig) *BufferedViewRecorder {
+	return &BufferedViewRecorder{
+		blogViewRepo: blogViewRepo,
+		config:       config,
+		views:        make(chan *view.View, config.BufferSize),
+		done:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+}
+
+// Record : 閲覧をバッファに追加する（バッファが溢れている場合や停止後は破棄する）
+func (r *BufferedViewRecorder) Record(v *view.View) {
+	select {
+	case <-r.done:
+		return
+	default:
+	}
+
+	select {
+	case r.views <- v:
+	default:
+		// リクエスト処理をブロックしないよう、取りこぼしを許容する
+	}
+}
+
+// dailyKey : 日別集計のキー
+type dailyKey struct {
+	blogID string
+	date   time.Time
+}
+
+// Run : バッファの閲覧を集計して書き込む。Closeが呼ばれるかctxが終了するまでブロックする
+func (r *BufferedViewRecorder) Run(ctx context.Context) {
+	defer close(r.stopped)
+
+	ticker := time.NewTicker(r.config.FlushInterval)
+	defer ticker.Stop()
+
+	pending := make(map[dailyKey]int)
+	total := 0
+	lastSeen := make(map[string]time.Time)
+
+	add := func(v *view.View) {
+		// 重複排除期間内の同一閲覧者による閲覧は数えない
+		seenKey := v.BlogID().String() + "\x00" + v.ViewerKey()
+		if last, ok := lastSeen[seenKey]; ok && v.ViewedAt().Sub(last) < r.config.DedupWindow {
+			return
+		}
+		lastSeen[seenKey] = v.ViewedAt()
+
+		pending[dailyKey{blogID: v.BlogID().String(), date: v.Date()}]++
+		total++
+	}
+
+	flush := func(ctx context.Context) {
+		if total == 0 {
+			return
+		}
+
+		counts := make([]*view.DailyCount, 0, len(pending))
+		for key, views := range pending {
+			blogID, err := blog.NewID(key.blogID)
+			if err != nil {
+				continue
+			}
+			counts = append(counts, &view.DailyCount{BlogID: *blogID, Date: key.date, Views: views})
+		}
+
+		if err := r.blogViewRepo.IncrementDailyCounts(ctx, counts); err != nil {
+			log.Printf("閲覧数の書き込みに失敗しました（%d件を破棄）: %v", total, err)
+		}
+
+		pending = make(map[dailyKey]int)
+		total = 0
+	}
+
+	// 停止時は残りの閲覧を書き込んでから終了する
+	shutdown := func() {
+		for {
+			select {
+			case v := <-r.views:
+				add(v)
+			default:
+				flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				flush(flushCtx)
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case v := <-r.views:
+			add(v)
+			if total >= r.config.BatchSize {
+				flush(ctx)
+			}
+		case now := <-ticker.C:
+			flush(ctx)
+			for key, last := range lastSeen {
+				if now.Sub(last) >= r.config.DedupWindow {
+					delete(lastSeen, key)
+				}
+			}
+		case <-r.done:
+			shutdown()
+			return
+		case <-ctx.Done():
+			shutdown()
+			return
+		}
+	}
+}
+
+// Close : 記録を停止し、残りの閲覧の書き込み完了を待つ
+func (r *BufferedViewRecorder) Close(ctx context.Context) error {
+	r.closeOnce.Do(func() {
+		close(r.done)
+	})
+
+	select {
+	case <-r.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
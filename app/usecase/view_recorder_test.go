@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"myblog/app/domain/model/view"
+)
+
+func TestBufferedViewRecorder(t *testing.T) {
+	tests := []struct {
+		name        string
+		dedupWindow time.Duration
+		viewers     []string
+		want        int
+	}{
+		{name: "閲覧者ごとに数える", dedupWindow: time.Hour, viewers: []string{"a", "b", "c"}, want: 3},
+		{name: "重複排除期間内の同一閲覧者の閲覧は1回と数える", dedupWindow: time.Hour, viewers: []string{"a", "a", "b", "a"}, want: 2},
+		{name: "重複排除期間がない場合は全て数える", viewers: []string{"a", "a", "a"}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newTestRepos()
+			alice := repos.createUser(t, "alice", "password")
+			b := repos.createBlog(t, alice, "title", "content")
+			recorder := NewBufferedViewRecorder(repos.blogViews, ViewRecorderConfig{
+				BufferSize:    100,
+				BatchSize:     100,
+				FlushInterval: time.Hour,
+				DedupWindow:   tt.dedupWindow,
+			})
+			go recorder.Run(context.Background())
+
+			var date string
+			for _, viewer := range tt.viewers {
+				v, err := view.NewView(b.ID(), viewer)
+				if err != nil {
+					t.Fatal(err)
+				}
+				date = v.Date().Format("2006-01-02")
+				recorder.Record(v)
+			}
+
+			// 停止時に残りの閲覧が書き込まれる
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := recorder.Close(ctx); err != nil {
+				t.Fatalf("停止できません: %v", err)
+			}
+
+			want := map[string]int{date: tt.want}
+			if got := repos.store.DailyViews(b.ID().String()); !reflect.DeepEqual(got, want) {
+				t.Errorf("日別閲覧数が異なります: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestBufferedViewRecorder_RecordAfterClose(t *testing.T) {
+	repos := newTestRepos()
+	alice := repos.createUser(t, "alice", "password")
+	b := repos.createBlog(t, alice, "title", "content")
+	recorder := NewBufferedViewRecorder(repos.blogViews, DefaultViewRecorderConfig)
+	go recorder.Run(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := recorder.Close(ctx); err != nil {
+		t.Fatalf("停止できません: %v", err)
+	}
+
+	v, err := view.NewView(b.ID(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.Record(v)
+
+	if got := repos.store.DailyViews(b.ID().String()); len(got) != 0 {
+		t.Errorf("停止後の閲覧が記録されています: %v", got)
+	}
+}
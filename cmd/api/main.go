@@ -2,118 +2,37 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
-	"myblog/app/infra/dao"
+	"myblog/app/config"
 	"myblog/app/infra/db/rdb"
-	"myblog/app/ui/http/handler"
-	"myblog/app/ui/http/middleware/auth"
-	"myblog/app/usecase"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
+	"myblog/app/server"
 )
 
 func main() {
-	// データベース接続
-	db, err := rdb.NewDB()
+	// 設定
+	cfg, err := config.Load(config.AppAPI)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		log.Fatalf("Failed to load config: %v", err)
 	}
-	defer db.Close()
-
-	// リポジトリ
-	userRepo := dao.NewUserRepository(db)
-	blogRepo := dao.NewBlogRepository(db)
-	commentRepo := dao.NewCommentRepository(db)
-
-	// JWT Secret
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "default_jwt_secret_for_development"
+	if cfg.UsesDevJWTSecret() {
 		log.Println("Warning: Using default JWT secret")
 	}
 
-	// ユースケース
-	userUsecase := usecase.NewUserUsecase(userRepo, jwtSecret)
-	blogUsecase := usecase.NewBlogUsecase(blogRepo, userRepo)
-	commentUsecase := usecase.NewCommentUsecase(commentRepo, blogRepo, userRepo)
-
-	// ハンドラー
-	userHandler := handler.NewUserHandler(userUsecase)
-	blogHandler := handler.NewBlogHandler(blogUsecase)
-	commentHandler := handler.NewCommentHandler(commentUsecase)
-
-	// ルーター
-	r := chi.NewRouter()
-
-	// ミドルウェア
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
-
-	// ヘルスチェック
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
-
-	// API ルート
-	r.Route("/api", func(r chi.Router) {
-		// 認証不要のエンドポイント
-		r.Post("/users/register", userHandler.Register)
-		r.Post("/users/login", userHandler.Login)
-
-		// 認証が必要なエンドポイント
-		r.Group(func(r chi.Router) {
-			r.Use(auth.JWTMiddleware(jwtSecret))
-			r.Use(auth.RequireAuth)
-
-			// ユーザー関連
-			r.Get("/users/{id}", userHandler.GetUser)
-			r.Put("/users/{id}", userHandler.UpdateUser)
-			r.Delete("/users/{id}", userHandler.DeleteUser)
-
-			// ブログ関連
-			r.Post("/blogs", blogHandler.CreateBlog)
-			r.Get("/blogs", blogHandler.GetAllBlogs)
-			r.Get("/blogs/{id}", blogHandler.GetBlog)
-			r.Get("/users/{id}/blogs", blogHandler.GetUserBlogs)
-			r.Put("/blogs/{id}", blogHandler.UpdateBlog)
-			r.Delete("/blogs/{id}", blogHandler.DeleteBlog)
-
-			// コメント関連
-			r.Post("/blogs/{id}/comments", commentHandler.CreateComment)
-			r.Get("/blogs/{id}/comments", commentHandler.GetBlogComments)
-			r.Put("/comments/{id}", commentHandler.UpdateComment)
-			r.Delete("/comments/{id}", commentHandler.DeleteComment)
-		})
-	})
-
-	// サーバー起動
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// データベース接続
+	db, err := rdb.Open(cfg.Database.RDB())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
 	}
+	defer db.Close()
 
-	server := &http.Server{
-		Addr:    fmt.Sprintf(":%s", port),
-		Handler: r,
+	// サーバー
+	srv, err := server.New(cfg, server.NewRDBRepositories(db))
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
 	}
 
 	// グレースフルシャットダウン
@@ -124,7 +43,8 @@ func main() {
 	go func() {
 		<-sig
 
-		shutdownCtx, _ := context.WithTimeout(serverCtx, 30*time.Second)
+		shutdownCtx, cancel := context.WithTimeout(serverCtx, cfg.Server.ShutdownTimeout)
+		defer cancel()
 
 		go func() {
 			<-shutdownCtx.Done()
@@ -133,16 +53,14 @@ func main() {
 			}
 		}()
 
-		err := server.Shutdown(shutdownCtx)
-		if err != nil {
+		if err := srv.Stop(shutdownCtx); err != nil {
 			log.Fatal(err)
 		}
 		serverStopCtx()
 	}()
 
-	log.Printf("Server is running on port %s", port)
-	err = server.ListenAndServe()
-	if err != nil && err != http.ErrServerClosed {
+	log.Printf("Server is running on port %d (%s)", cfg.Server.Port, cfg.Profile)
+	if err := srv.Start(); err != nil {
 		log.Fatal(err)
 	}
 
@@ -3,16 +3,25 @@ package main
 import (
 	"context"
 	"fmt"
+	"io/fs"
+	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
+	"myblog/app/config"
+	"myblog/app/domain/model/event"
 	"myblog/app/infra/dao"
+	"myblog/app/infra/db/migration"
 	"myblog/app/infra/db/rdb"
+	"myblog/app/infra/lock"
 	"myblog/app/infra/query"
 	"myblog/app/ui/batch"
 	"myblog/app/ui/http"
 	"myblog/app/usecase"
+	"myblog/db/migrations"
 
 	"github.com/spf13/cobra"
 )
@@ -26,10 +35,20 @@ var RootCmd = &cobra.Command{
 // run はメイン処理
 func run() int {
 	startTime := time.Now()
-	ctx := context.Background()
+
+	// 終了シグナルを受け取ったらコンテキストをキャンセルする（スケジューラーの停止に使用）
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 設定
+	cfg, err := config.Load(config.AppBatch)
+	if err != nil {
+		fmt.Printf("設定の読み込みに失敗しました: %v\n", err)
+		return 1
+	}
 
 	// データベース接続
-	db, err := rdb.NewDB()
+	db, err := rdb.Open(cfg.Database.RDB())
 	if err != nil {
 		fmt.Printf("データベース接続に失敗しました: %v\n", err)
 		return 1
@@ -37,18 +56,100 @@ func run() int {
 	defer db.Close()
 
 	// 依存関係の構築
-	mutex := http.NewMutex(nil) // TODO: キャッシュクライアントを渡す
-	txManager := rdb.NewDefaultTransactionManager(db)
+	var lockStore http.LockStore = lock.NewMySQLStore(db)
+	if db.Driver() == rdb.DriverSQLite {
+		lockStore = lock.NewSQLiteStore(db)
+	}
+	mutex := http.NewMutex(lockStore)
+
+	// 実行履歴の記録・リトライ・失敗の通知
+	jobRunRepository := dao.NewJobRunRepository(db)
+	jobRunUsecase := usecase.NewJobRunUsecase(jobRunRepository)
+	var notifier batch.Notifier
+	if cfg.Batch.FailureWebhookURL != "" {
+		notifier = batch.NewWebhookNotifier(cfg.Batch.FailureWebhookURL)
+	}
+	runner := batch.NewRunner(jobRunUsecase, notifier, batch.DefaultRetryPolicy)
+	RootCmd.PersistentFlags().Int("max-retries", batch.DefaultRetryPolicy.MaxRetries, "一時的なデータベースエラーで失敗した場合の最大リトライ回数")
+	RootCmd.PersistentFlags().Duration("retry-backoff", batch.DefaultRetryPolicy.InitialBackoff, "初回のリトライまでの待機時間（リトライのたびに2倍にする）")
+	RootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		maxRetries, err := cmd.Flags().GetInt("max-retries")
+		if err != nil {
+			return fmt.Errorf("最大リトライ回数の指定が不正です: %w", err)
+		}
+		retryBackoff, err := cmd.Flags().GetDuration("retry-backoff")
+		if err != nil {
+			return fmt.Errorf("リトライまでの待機時間の指定が不正です: %w", err)
+		}
+		if maxRetries < 0 || retryBackoff < 0 {
+			return fmt.Errorf("リトライの指定には0以上を指定してください")
+		}
+		runner.SetRetryPolicy(batch.RetryPolicy{
+			MaxRetries:     maxRetries,
+			InitialBackoff: retryBackoff,
+			MaxBackoff:     batch.DefaultRetryPolicy.MaxBackoff,
+		})
+		return nil
+	}
 
 	// ランキング関連の依存関係
 	rankingRepository := dao.NewRankingRepository(db)
 	blogStatsQuery := query.NewBlogStats(db)
-	rankingUseCase := usecase.NewRankingUseCase(rankingRepository, blogStatsQuery, txManager)
+	rankingListQuery := query.NewRankingList(db)
+	rankingUseCase := usecase.NewRankingUseCase(rankingRepository, blogStatsQuery, rankingListQuery)
 	rankingHandler := batch.NewRanking(rankingUseCase, *mutex)
-	calculatePopularRankingCmd := batch.NewCalculatePopularRankingCmd(rankingHandler)
+	calculatePopularRankingCmd := runner.Wrap(batch.NewCalculatePopularRankingCmd(rankingHandler), nil)
+
+	// ドメインイベントの配信関連の依存関係
+	outboxRepository := dao.NewOutboxRepository(db)
+	eventBus := usecase.NewEventBus()
+	for _, eventType := range []event.Type{event.TypeBlogPublished, event.TypeCommentAdded, event.TypeUserRegistered} {
+		err = eventBus.Subscribe("log:"+string(eventType), eventType, func(ctx context.Context, e *event.Event) error {
+			log.Printf("イベント: %s %s %s", e.Type(), e.AggregateID(), e.Payload())
+			return nil
+		})
+		if err != nil {
+			fmt.Printf("購読者の登録に失敗しました: %v\n", err)
+			return 1
+		}
+	}
+	outboxRelay := usecase.NewOutboxRelay(outboxRepository, eventBus)
+	outboxRelayCmd := batch.NewOutboxRelayCmd(outboxRelay, *mutex)
+
+	// スケジューラー関連の依存関係
+	scheduler := batch.NewScheduler(*mutex, runner)
+	err = scheduler.Register(batch.Job{
+		Name:     "calculate-popular-ranking",
+		Schedule: "0 * * * *", // 毎時0分
+		NewCommand: func() *cobra.Command {
+			return batch.NewCalculatePopularRankingCmd(rankingHandler)
+		},
+	})
+	if err != nil {
+		fmt.Printf("ジョブの登録に失敗しました: %v\n", err)
+		return 1
+	}
+	schedulerCmd := batch.NewSchedulerCmd(scheduler)
+	jobRunsCmd := batch.NewJobRunsCmd(jobRunUsecase)
+
+	// マイグレーション関連の依存関係（実行履歴のテーブルがない状態でも実行するため、Runnerを使用しない）
+	migrationFS := fs.FS(migrations.FS)
+	if db.Driver() == rdb.DriverSQLite {
+		migrationFS = migrations.SQLiteFS()
+	}
+	migrationFiles, err := migration.Load(migrationFS)
+	if err != nil {
+		fmt.Printf("マイグレーションファイルの読み込みに失敗しました: %v\n", err)
+		return 1
+	}
+	migrateCmd := batch.NewMigrateCmd(migration.NewMigrator(db, migrationFiles))
 
 	// コマンドの登録
 	RootCmd.AddCommand(calculatePopularRankingCmd)
+	RootCmd.AddCommand(schedulerCmd)
+	RootCmd.AddCommand(jobRunsCmd)
+	RootCmd.AddCommand(outboxRelayCmd)
+	RootCmd.AddCommand(migrateCmd)
 
 	// コマンドの実行
 	if err := RootCmd.ExecuteContext(ctx); err != nil {
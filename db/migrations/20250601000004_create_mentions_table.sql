@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS mentions (
+    id VARCHAR(36) PRIMARY KEY,
+    blog_id VARCHAR(36) NOT NULL,
+    comment_id VARCHAR(36) NULL,
+    user_id VARCHAR(36) NOT NULL,
+    username VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id) REFERENCES comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_mentions_blog_id ON mentions(blog_id);
+CREATE INDEX idx_mentions_comment_id ON mentions(comment_id);
+CREATE INDEX idx_mentions_user_id ON mentions(user_id);
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS blog_reactions (
+    blog_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (blog_id, user_id),
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_blog_reactions_user_id ON blog_reactions(user_id);
+
+CREATE TABLE IF NOT EXISTS comment_reactions (
+    comment_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (comment_id, user_id),
+    FOREIGN KEY (comment_id) REFERENCES comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_comment_reactions_user_id ON comment_reactions(user_id);
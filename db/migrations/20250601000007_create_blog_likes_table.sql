@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS blog_likes (
+    blog_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blog_id, user_id),
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_blog_likes_user_id ON blog_likes(user_id);
+CREATE INDEX idx_blog_likes_created_at ON blog_likes(created_at);
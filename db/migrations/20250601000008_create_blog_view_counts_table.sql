@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS blog_view_counts (
+    blog_id VARCHAR(36) NOT NULL,
+    view_date DATE NOT NULL,
+    views INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (blog_id, view_date),
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_blog_view_counts_view_date ON blog_view_counts(view_date);
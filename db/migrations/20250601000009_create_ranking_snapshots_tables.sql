@@ -0,0 +1,25 @@
+-- ランキングは種別ごとのスナップショットとして保存するため、単一のrankingsテーブルは廃止する
+DROP TABLE IF EXISTS rankings;
+
+CREATE TABLE IF NOT EXISTS ranking_snapshots (
+    id VARCHAR(36) PRIMARY KEY,
+    ranking_type VARCHAR(16) NOT NULL,
+    calculated_at TIMESTAMP(6) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_ranking_snapshots_type_calculated_at ON ranking_snapshots(ranking_type, calculated_at);
+
+CREATE TABLE IF NOT EXISTS ranking_entries (
+    snapshot_id VARCHAR(36) NOT NULL,
+    blog_id VARCHAR(36) NOT NULL,
+    ranking_position INT NOT NULL,
+    score INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (snapshot_id, blog_id),
+    FOREIGN KEY (snapshot_id) REFERENCES ranking_snapshots(id) ON DELETE CASCADE,
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_ranking_entries_position ON ranking_entries(snapshot_id, ranking_position);
+CREATE INDEX idx_ranking_entries_blog_id ON ranking_entries(blog_id);
@@ -0,0 +1,2 @@
+-- 減衰を伴うスコアの算出方法に対応するため、スコアを浮動小数点数で保持する
+ALTER TABLE ranking_entries MODIFY score DOUBLE NOT NULL;
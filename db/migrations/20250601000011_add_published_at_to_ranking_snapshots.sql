@@ -0,0 +1,7 @@
+-- スナップショットは全ての順位を書き込んでから公開する（公開前のスナップショットは参照しない）
+ALTER TABLE ranking_snapshots ADD COLUMN published_at TIMESTAMP(6) NULL AFTER calculated_at;
+
+-- 既存のスナップショットは公開済みとする
+UPDATE ranking_snapshots SET published_at = calculated_at WHERE published_at IS NULL;
+
+CREATE INDEX idx_ranking_snapshots_type_published_at ON ranking_snapshots(ranking_type, published_at);
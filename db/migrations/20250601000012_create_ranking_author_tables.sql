@@ -0,0 +1,30 @@
+-- 著者ランキング（著者の記事のスコアの合計による順位）
+CREATE TABLE IF NOT EXISTS ranking_author_entries (
+    snapshot_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    ranking_position INT NOT NULL,
+    score DOUBLE NOT NULL,
+    blog_count INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (snapshot_id, user_id),
+    FOREIGN KEY (snapshot_id) REFERENCES ranking_snapshots(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_ranking_author_entries_position ON ranking_author_entries(snapshot_id, ranking_position);
+
+-- 著者ごとの人気記事ランキング（著者の記事の中での順位）
+CREATE TABLE IF NOT EXISTS ranking_author_blog_entries (
+    snapshot_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    blog_id VARCHAR(36) NOT NULL,
+    ranking_position INT NOT NULL,
+    score DOUBLE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (snapshot_id, blog_id),
+    FOREIGN KEY (snapshot_id) REFERENCES ranking_snapshots(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_ranking_author_blog_entries_position ON ranking_author_blog_entries(snapshot_id, user_id, ranking_position);
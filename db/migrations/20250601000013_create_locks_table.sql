@@ -0,0 +1,6 @@
+-- バッチの多重実行を防ぐためのロック（期限を過ぎたロックは他の所有者が取得できる）
+CREATE TABLE IF NOT EXISTS locks (
+    id VARCHAR(191) PRIMARY KEY,
+    owner VARCHAR(36) NOT NULL,
+    expires_at TIMESTAMP(6) NOT NULL
+);
@@ -0,0 +1,15 @@
+-- バッチジョブの実行履歴
+-- スケジューラーからの実行は(job_name, scheduled_at)で一意とし、複数のプロセスが同じ予定を重複して実行しないようにする
+CREATE TABLE IF NOT EXISTS job_runs (
+    id VARCHAR(36) PRIMARY KEY,
+    job_name VARCHAR(100) NOT NULL,
+    scheduled_at TIMESTAMP NULL,
+    status VARCHAR(16) NOT NULL,
+    error_message TEXT NULL,
+    started_at TIMESTAMP(6) NOT NULL,
+    finished_at TIMESTAMP(6) NULL,
+    UNIQUE KEY uk_job_runs_scheduled (job_name, scheduled_at)
+);
+
+CREATE INDEX idx_job_runs_started_at ON job_runs(started_at);
+CREATE INDEX idx_job_runs_job_name_started_at ON job_runs(job_name, started_at);
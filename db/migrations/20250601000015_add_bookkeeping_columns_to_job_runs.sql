@@ -0,0 +1,5 @@
+-- バッチの実行ごとに引数・処理件数・試行回数を記録する
+ALTER TABLE job_runs
+    ADD COLUMN params TEXT NULL AFTER job_name,
+    ADD COLUMN rows_processed BIGINT NOT NULL DEFAULT 0 AFTER status,
+    ADD COLUMN attempts INT NOT NULL DEFAULT 0 AFTER rows_processed;
@@ -0,0 +1,25 @@
+-- ドメインイベントのアウトボックス（状態の変更と同じトランザクションで記録し、リレーで配信する）
+CREATE TABLE IF NOT EXISTS outbox (
+    id VARCHAR(36) PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    aggregate_id VARCHAR(36) NOT NULL,
+    payload JSON NOT NULL,
+    idempotency_key VARCHAR(191) NOT NULL,
+    occurred_at TIMESTAMP(6) NOT NULL,
+    published_at TIMESTAMP(6) NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uk_outbox_idempotency_key (idempotency_key)
+);
+
+CREATE INDEX idx_outbox_pending ON outbox(published_at, next_attempt_at, occurred_at);
+
+-- 購読者ごとの配信完了の記録（再配信時に配信済みの購読者をスキップする）
+CREATE TABLE IF NOT EXISTS outbox_deliveries (
+    event_id VARCHAR(36) NOT NULL,
+    subscriber VARCHAR(100) NOT NULL,
+    delivered_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (event_id, subscriber),
+    FOREIGN KEY (event_id) REFERENCES outbox(id) ON DELETE CASCADE
+);
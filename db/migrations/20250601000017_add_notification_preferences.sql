@@ -0,0 +1,17 @@
+-- 作成日時が同じ通知の順序を保つため、マイクロ秒まで保存する
+ALTER TABLE notifications
+    MODIFY read_at TIMESTAMP(6) NULL,
+    MODIFY created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6);
+
+-- カーソルによるページングと未読件数の集計に使用
+CREATE INDEX idx_notifications_user_id_created_at_id ON notifications(user_id, created_at, id);
+CREATE INDEX idx_notifications_user_id_read_at ON notifications(user_id, read_at);
+
+-- ユーザーごとの通知種別の受け取り設定（行がない種別は受け取る）
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(36) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS comment_reactions;
+DROP TABLE IF EXISTS blog_reactions;
@@ -0,0 +1,2 @@
+-- 小数点以下は切り捨てられる
+ALTER TABLE ranking_entries MODIFY score INT NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX idx_ranking_snapshots_type_published_at ON ranking_snapshots;
+
+ALTER TABLE ranking_snapshots DROP COLUMN published_at;
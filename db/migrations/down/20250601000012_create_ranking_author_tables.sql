@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ranking_author_blog_entries;
+DROP TABLE IF EXISTS ranking_author_entries;
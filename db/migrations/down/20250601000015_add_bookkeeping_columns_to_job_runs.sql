@@ -0,0 +1,4 @@
+ALTER TABLE job_runs
+    DROP COLUMN params,
+    DROP COLUMN rows_processed,
+    DROP COLUMN attempts;
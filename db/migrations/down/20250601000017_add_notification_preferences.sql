@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS notification_preferences;
+
+DROP INDEX idx_notifications_user_id_read_at ON notifications;
+DROP INDEX idx_notifications_user_id_created_at_id ON notifications;
+
+ALTER TABLE notifications
+    MODIFY read_at TIMESTAMP NULL,
+    MODIFY created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,27 @@
+// Package migrations はデータベースのマイグレーションファイルを埋め込む
+// 適用するSQLはこのディレクトリ、取り消すSQLはdownディレクトリに同じファイル名で配置する
+// SQLite用のマイグレーションはsqliteディレクトリに同じ構成で配置する
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// FS はMySQL用のマイグレーションファイル
+//
+//go:embed *.sql down/*.sql
+var FS embed.FS
+
+//go:embed sqlite/*.sql sqlite/down/*.sql
+var sqliteFS embed.FS
+
+// SQLiteFS はSQLite用のマイグレーションファイル
+func SQLiteFS() fs.FS {
+	sub, err := fs.Sub(sqliteFS, "sqlite")
+	if err != nil {
+		// 埋め込み時に存在を確認しているため発生しない
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,137 @@
+package migrations_test
+
+import (
+	"context"
+	"io/fs"
+	"sort"
+	"testing"
+	"time"
+
+	"myblog/app/config"
+	"myblog/app/infra/db/migration"
+	"myblog/app/infra/db/rdb"
+	"myblog/db/migrations"
+)
+
+// TestSchemaParity : MySQLとSQLiteのマイグレーションを全て適用した結果、同じテーブルと列ができることの検証
+// 列の型と制約はデータベースごとに表現が異なるため比較しない
+func TestSchemaParity(t *testing.T) {
+	sqlite := sqliteColumns(t)
+	if len(sqlite) == 0 {
+		t.Fatal("SQLiteのテーブルがありません")
+	}
+
+	mysql := mysqlColumns(t)
+
+	for _, diff := range []struct {
+		name        string
+		got, others map[string]bool
+	}{
+		{name: "MySQLのみ", got: mysql, others: sqlite},
+		{name: "SQLiteのみ", got: sqlite, others: mysql},
+	} {
+		var missing []string
+		for column := range diff.got {
+			if !diff.others[column] {
+				missing = append(missing, column)
+			}
+		}
+		sort.Strings(missing)
+		for _, column := range missing {
+			t.Errorf("%sの列があります: %s（db/migrationsとdb/migrations/sqliteの両方にマイグレーションを追加してください）", diff.name, column)
+		}
+	}
+}
+
+// sqliteColumns : メモリ上のSQLiteにマイグレーションを適用した「テーブル.列」の一覧
+func sqliteColumns(t *testing.T) map[string]bool {
+	t.Helper()
+
+	db, err := rdb.Open(rdb.Config{Driver: rdb.DriverSQLite, Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("データベースの接続に失敗しました: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	migrate(t, db, migrations.SQLiteFS())
+
+	return columns(t, db, `
+		SELECT m.name, p.name
+		FROM sqlite_master m, pragma_table_info(m.name) p
+		WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%'
+	`)
+}
+
+// mysqlColumns : テスト用のMySQL（rdb-test）にマイグレーションを適用した「テーブル.列」の一覧
+// MySQLに接続できない場合はテストをスキップする
+func mysqlColumns(t *testing.T) map[string]bool {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("MySQLが必要なため-shortでは実行しない")
+	}
+	cfg, err := config.Load(config.AppBatch)
+	if err != nil {
+		t.Skipf("設定を読み込めないためスキップします: %v", err)
+	}
+	// マイグレーションを適用するため、テスト用のデータベース以外では実行しない
+	if cfg.Profile != config.ProfileTest || cfg.Database.Driver != string(rdb.DriverMySQL) {
+		t.Skip("APP_ENV=testのMySQLのデータベース（rdb-test）でのみ実行する")
+	}
+
+	db, err := rdb.Open(cfg.Database.RDB())
+	if err != nil {
+		t.Skipf("MySQLに接続できないためスキップします: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := db.Reader(ctx).ExecContext(ctx, "SELECT 1"); err != nil {
+		t.Skipf("MySQLに接続できないためスキップします: %v", err)
+	}
+	migrate(t, db, migrations.FS)
+
+	return columns(t, db, `
+		SELECT table_name, column_name
+		FROM information_schema.columns
+		WHERE table_schema = DATABASE()
+	`)
+}
+
+// migrate : 未適用のマイグレーションを全て適用
+func migrate(t *testing.T, db *rdb.DB, fsys fs.FS) {
+	t.Helper()
+
+	files, err := migration.Load(fsys)
+	if err != nil {
+		t.Fatalf("マイグレーションファイルの読み込みに失敗しました: %v", err)
+	}
+	if _, err := migration.NewMigrator(db, files).Up(context.Background(), 0); err != nil {
+		t.Fatalf("マイグレーションの適用に失敗しました: %v", err)
+	}
+}
+
+// columns : テーブル名と列名を返すクエリの結果の「テーブル.列」の集合
+func columns(t *testing.T, db *rdb.DB, query string) map[string]bool {
+	t.Helper()
+
+	ctx := context.Background()
+	rows, err := db.Reader(ctx).QueryContext(ctx, query)
+	if err != nil {
+		t.Fatalf("スキーマの取得に失敗しました: %v", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]bool)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			t.Fatalf("スキーマの読み込みに失敗しました: %v", err)
+		}
+		result[table+"."+column] = true
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("スキーマの読み込みに失敗しました: %v", err)
+	}
+	return result
+}
@@ -0,0 +1,231 @@
+-- SQLite用のスキーマ（MySQLの20250601000017までのマイグレーションを適用した状態と同じ）
+-- 日時の列はドライバーが日時として読み込めるよう精度を指定せずにTIMESTAMPとする
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(36) PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password BLOB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS blogs (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_blogs_user_id ON blogs(user_id);
+CREATE INDEX IF NOT EXISTS idx_blogs_created_at ON blogs(created_at);
+
+CREATE TABLE IF NOT EXISTS comments (
+    id VARCHAR(36) PRIMARY KEY,
+    blog_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_blog_id ON comments(blog_id);
+CREATE INDEX IF NOT EXISTS idx_comments_user_id ON comments(user_id);
+CREATE INDEX IF NOT EXISTS idx_comments_created_at ON comments(created_at);
+
+CREATE TABLE IF NOT EXISTS mentions (
+    id VARCHAR(36) PRIMARY KEY,
+    blog_id VARCHAR(36) NOT NULL,
+    comment_id VARCHAR(36) NULL,
+    user_id VARCHAR(36) NOT NULL,
+    username VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id) REFERENCES comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_mentions_blog_id ON mentions(blog_id);
+CREATE INDEX IF NOT EXISTS idx_mentions_comment_id ON mentions(comment_id);
+CREATE INDEX IF NOT EXISTS idx_mentions_user_id ON mentions(user_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    actor_id VARCHAR(36) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    blog_id VARCHAR(36) NOT NULL,
+    comment_id VARCHAR(36) NULL,
+    read_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id) REFERENCES comments(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id_created_at_id ON notifications(user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id_read_at ON notifications(user_id, read_at);
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(36) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS blog_reactions (
+    blog_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blog_id, user_id),
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_blog_reactions_user_id ON blog_reactions(user_id);
+
+CREATE TABLE IF NOT EXISTS comment_reactions (
+    comment_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (comment_id, user_id),
+    FOREIGN KEY (comment_id) REFERENCES comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_reactions_user_id ON comment_reactions(user_id);
+
+CREATE TABLE IF NOT EXISTS blog_likes (
+    blog_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blog_id, user_id),
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_blog_likes_user_id ON blog_likes(user_id);
+CREATE INDEX IF NOT EXISTS idx_blog_likes_created_at ON blog_likes(created_at);
+
+-- 日付は「YYYY-MM-DD」の文字列で保存する
+CREATE TABLE IF NOT EXISTS blog_view_counts (
+    blog_id VARCHAR(36) NOT NULL,
+    view_date DATE NOT NULL,
+    views INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blog_id, view_date),
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_blog_view_counts_view_date ON blog_view_counts(view_date);
+
+CREATE TABLE IF NOT EXISTS ranking_snapshots (
+    id VARCHAR(36) PRIMARY KEY,
+    ranking_type VARCHAR(16) NOT NULL,
+    calculated_at TIMESTAMP NOT NULL,
+    published_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ranking_snapshots_type_calculated_at ON ranking_snapshots(ranking_type, calculated_at);
+CREATE INDEX IF NOT EXISTS idx_ranking_snapshots_type_published_at ON ranking_snapshots(ranking_type, published_at);
+
+CREATE TABLE IF NOT EXISTS ranking_entries (
+    snapshot_id VARCHAR(36) NOT NULL,
+    blog_id VARCHAR(36) NOT NULL,
+    ranking_position INT NOT NULL,
+    score DOUBLE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (snapshot_id, blog_id),
+    FOREIGN KEY (snapshot_id) REFERENCES ranking_snapshots(id) ON DELETE CASCADE,
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_ranking_entries_position ON ranking_entries(snapshot_id, ranking_position);
+CREATE INDEX IF NOT EXISTS idx_ranking_entries_blog_id ON ranking_entries(blog_id);
+
+CREATE TABLE IF NOT EXISTS ranking_author_entries (
+    snapshot_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    ranking_position INT NOT NULL,
+    score DOUBLE NOT NULL,
+    blog_count INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (snapshot_id, user_id),
+    FOREIGN KEY (snapshot_id) REFERENCES ranking_snapshots(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_ranking_author_entries_position ON ranking_author_entries(snapshot_id, ranking_position);
+
+CREATE TABLE IF NOT EXISTS ranking_author_blog_entries (
+    snapshot_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    blog_id VARCHAR(36) NOT NULL,
+    ranking_position INT NOT NULL,
+    score DOUBLE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (snapshot_id, blog_id),
+    FOREIGN KEY (snapshot_id) REFERENCES ranking_snapshots(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (blog_id) REFERENCES blogs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_ranking_author_blog_entries_position ON ranking_author_blog_entries(snapshot_id, user_id, ranking_position);
+
+CREATE TABLE IF NOT EXISTS locks (
+    id VARCHAR(191) PRIMARY KEY,
+    owner VARCHAR(36) NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS job_runs (
+    id VARCHAR(36) PRIMARY KEY,
+    job_name VARCHAR(100) NOT NULL,
+    params TEXT NULL,
+    scheduled_at TIMESTAMP NULL,
+    status VARCHAR(16) NOT NULL,
+    rows_processed BIGINT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    error_message TEXT NULL,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NULL,
+    UNIQUE (job_name, scheduled_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_started_at ON job_runs(started_at);
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_name_started_at ON job_runs(job_name, started_at);
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id VARCHAR(36) PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    aggregate_id VARCHAR(36) NOT NULL,
+    payload TEXT NOT NULL,
+    idempotency_key VARCHAR(191) NOT NULL UNIQUE,
+    occurred_at TIMESTAMP NOT NULL,
+    published_at TIMESTAMP NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(published_at, next_attempt_at, occurred_at);
+
+CREATE TABLE IF NOT EXISTS outbox_deliveries (
+    event_id VARCHAR(36) NOT NULL,
+    subscriber VARCHAR(100) NOT NULL,
+    delivered_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (event_id, subscriber),
+    FOREIGN KEY (event_id) REFERENCES outbox(id) ON DELETE CASCADE
+);
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS outbox_deliveries;
+DROP TABLE IF EXISTS outbox;
+DROP TABLE IF EXISTS job_runs;
+DROP TABLE IF EXISTS locks;
+DROP TABLE IF EXISTS ranking_author_blog_entries;
+DROP TABLE IF EXISTS ranking_author_entries;
+DROP TABLE IF EXISTS ranking_entries;
+DROP TABLE IF EXISTS ranking_snapshots;
+DROP TABLE IF EXISTS blog_view_counts;
+DROP TABLE IF EXISTS blog_likes;
+DROP TABLE IF EXISTS comment_reactions;
+DROP TABLE IF EXISTS blog_reactions;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS mentions;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS blogs;
+DROP TABLE IF EXISTS users;